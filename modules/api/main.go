@@ -31,6 +31,7 @@ import (
 	"k8s.io/dashboard/api/pkg/handler"
 	"k8s.io/dashboard/api/pkg/integration"
 	integrationapi "k8s.io/dashboard/api/pkg/integration/api"
+	"k8s.io/dashboard/api/pkg/recording"
 	"k8s.io/dashboard/certificates"
 	"k8s.io/dashboard/certificates/ecdsa"
 	"k8s.io/dashboard/client"
@@ -61,6 +62,8 @@ func main() {
 		klog.Info("Skipping metrics configuration. Metrics not available in proxy mode.")
 	}
 
+	configureTerminalRecording()
+
 	apiHandler, err := handler.CreateHTTPAPIHandler(integrationManager)
 	if err != nil {
 		handleFatalInitError(err)
@@ -79,7 +82,15 @@ func main() {
 	}
 
 	http.Handle("/", apiHandler)
-	http.Handle("/api/sockjs/", handler.CreateAttachHandler("/api/sockjs"))
+	http.Handle("/api/sockjs/", handler.CreateSockJSHandler("/api/sockjs"))
+	streamingHandler := handler.CreateStreamingHandler(integrationManager)
+	http.Handle("/api/v1/watch/", streamingHandler)
+	http.Handle("/api/v1/logstream/", streamingHandler)
+	http.Handle("/api/v1/portforward/", handler.CreatePortForwardProxyHandler())
+	// Recordings are served by the main API handler, so only terminal connections use the terminal handler
+	terminalHandler := handler.CreateTerminalHandler()
+	http.Handle("/api/v1/terminal/pod/", terminalHandler)
+	http.Handle("/api/v1/terminal/node/", terminalHandler)
 	http.Handle("/metrics", promhttp.Handler())
 
 	if certs != nil {
@@ -123,6 +134,9 @@ func configureMetricsProvider(integrationManager integration.Manager) {
 	case "sidecar":
 		integrationManager.Metric().ConfigureSidecar(args.SidecarHost()).
 			EnableWithRetry(integrationapi.SidecarIntegrationID, time.Duration(args.MetricClientHealthCheckPeriod()))
+	case "prometheus":
+		integrationManager.Metric().ConfigurePrometheus(args.PrometheusHost(), args.PrometheusRange(), args.PrometheusStep()).
+			EnableWithRetry(integrationapi.PrometheusIntegrationID, time.Duration(args.MetricClientHealthCheckPeriod()))
 	case "none":
 		klog.Info("Metrics provider disabled")
 	default:
@@ -133,6 +147,19 @@ func configureMetricsProvider(integrationManager integration.Manager) {
 	}
 }
 
+func configureTerminalRecording() {
+	sink, err := recording.NewSink(args.TerminalRecordingSink(), args.TerminalRecordingDir(), args.Namespace())
+	if err != nil {
+		klog.Fatalf("Error while configuring terminal recording. Reason: %s", err)
+	}
+
+	if sink != nil {
+		klog.InfoS("Terminal sessions are recorded", "sink", args.TerminalRecordingSink())
+	}
+
+	recording.Init(sink)
+}
+
 func configureOpenAPI(container *restful.Container) {
 	config := restfulspec.Config{
 		WebServices:                   container.RegisteredWebServices(),
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/spf13/pflag"
 	"k8s.io/klog/v2"
@@ -57,11 +58,17 @@ var (
 	argPrometheusEnabled        = pflag.Bool("prometheus-enabled", false, "Enable prometheus metrics handler. By default it will be exposed on localhost:8080 under '/metrics'")
 	argApiServerSkipTLSVerify   = pflag.Bool("apiserver-skip-tls-verify", false, "enable if connection with remote Kubernetes API server should skip TLS verify")
 	argAutoGenerateCertificates = pflag.Bool("auto-generate-certificates", false, "enables automatic certificates generation used to serve HTTPS")
+	argEnableNodeShell          = pflag.Bool("enable-node-shell", false, "enables node shell, which runs privileged pods with access to the host namespaces and filesystem")
 
 	argInsecurePort            = pflag.Int("insecure-port", defaultInsecurePort, "port to listen to for incoming HTTP requests")
 	argPort                    = pflag.Int("port", defaultPort, "secure port to listen to for incoming HTTPS requests")
 	argMetricClientCheckPeriod = pflag.Int("metric-client-check-period", 30, "time interval between separate metric client health checks in seconds")
 
+	argFileTransferSizeLimit = pflag.Int64("file-transfer-size-limit", 1<<30, "maximum size in bytes of files uploaded to or downloaded from containers")
+
+	argPrometheusRange = pflag.Duration("prometheus-range", 15*time.Minute, "time range of metrics downloaded by the 'prometheus' metrics provider")
+	argPrometheusStep  = pflag.Duration("prometheus-step", time.Minute, "resolution of metrics downloaded by the 'prometheus' metrics provider")
+
 	argInsecureBindAddress = pflag.IP("insecure-bind-address", net.IPv4(127, 0, 0, 1), "IP address on which to serve the --insecure-port, set to 0.0.0.0 for all interfaces")
 	argBindAddress         = pflag.IP("bind-address", net.IPv4(0, 0, 0, 0), "IP address on which to serve the --port, set to 0.0.0.0 for all interfaces")
 
@@ -69,11 +76,15 @@ var (
 	argCertFile                  = pflag.String("tls-cert-file", "", "file containing the default x509 certificate for HTTPS")
 	argKeyFile                   = pflag.String("tls-key-file", "", "file containing the default x509 private key matching --tls-cert-file")
 	argApiServerHost             = pflag.String("apiserver-host", "", "address of the Kubernetes API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for local discovery attempt")
-	argMetricsProvider           = pflag.String("metrics-provider", "sidecar", "select provider type for metrics, 'none' will not check metrics")
+	argMetricsProvider           = pflag.String("metrics-provider", "sidecar", "select provider type for metrics, one of 'sidecar' or 'prometheus', 'none' will not check metrics")
 	argSidecarHost               = pflag.String("sidecar-host", "", "address of the Sidecar API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for service proxy usage")
+	argPrometheusHost            = pflag.String("prometheus-host", "", "address of the Prometheus server used by the 'prometheus' metrics provider in the format of protocol://address:port")
 	argKubeConfigFile            = pflag.String("kubeconfig", "", "path to kubeconfig file with control plane location information")
 	argNamespace                 = pflag.String("namespace", helpers.GetEnv("POD_NAMESPACE", "kubernetes-dashboard"), "Namespace to use when accessing Dashboard specific resources, i.e. metrics scraper service")
 	argMetricsScraperServiceName = pflag.String("metrics-scraper-service-name", "kubernetes-dashboard-metrics-scraper", "name of the dashboard metrics scraper service")
+	argTerminalRecordingSink     = pflag.String("terminal-recording-sink", "", "enables recording of terminal sessions in the asciicast v2 format, one of 'directory' or 'configmap'")
+	argTerminalRecordingDir      = pflag.String("terminal-recording-dir", "/tmp/terminal-recordings", "directory used to store terminal recordings by the 'directory' sink, i.e. a mounted persistent volume")
+	argDebugImage                = pflag.String("debug-image", "busybox:1.36", "default image of ephemeral containers used to debug running pods")
 )
 
 func init() {
@@ -136,6 +147,18 @@ func SidecarHost() string {
 	return *argSidecarHost
 }
 
+func PrometheusHost() string {
+	return *argPrometheusHost
+}
+
+func PrometheusRange() time.Duration {
+	return *argPrometheusRange
+}
+
+func PrometheusStep() time.Duration {
+	return *argPrometheusStep
+}
+
 func KubeconfigPath() string {
 	return *argKubeConfigFile
 }
@@ -167,6 +190,22 @@ func MetricsScraperServiceName() string {
 	return *argMetricsScraperServiceName
 }
 
+func TerminalRecordingSink() string {
+	return *argTerminalRecordingSink
+}
+
+func TerminalRecordingDir() string {
+	return *argTerminalRecordingDir
+}
+
+func DebugImage() string {
+	return *argDebugImage
+}
+
+func FileTransferSizeLimit() int64 {
+	return *argFileTransferSizeLimit
+}
+
 func Namespace() string {
 	return *argNamespace
 }
@@ -182,3 +221,7 @@ func IsProxyEnabled() bool {
 func IsOpenAPIEnabled() bool {
 	return *argOpenAPIEnabled
 }
+
+func IsNodeShellEnabled() bool {
+	return *argEnableNodeShell
+}
@@ -0,0 +1,327 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bulk applies a single operation, i.e. delete or scale, to many resources at once.
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"k8s.io/dashboard/api/pkg/metadata"
+	"k8s.io/dashboard/api/pkg/resource/deployment"
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+const (
+	// maxItems is the maximum number of resources that can be changed by a single bulk operation.
+	maxItems = 1000
+
+	// maxConcurrentRequests is the maximum number of requests sent to the API server at once.
+	maxConcurrentRequests = 10
+)
+
+// Operation is an operation that can be applied to all bulk operation targets.
+type Operation string
+
+const (
+	OperationDelete   Operation = "delete"
+	OperationRestart  Operation = "restart"
+	OperationScale    Operation = "scale"
+	OperationLabel    Operation = "label"
+	OperationAnnotate Operation = "annotate"
+	OperationCordon   Operation = "cordon"
+	OperationUncordon Operation = "uncordon"
+)
+
+// restartableKinds are kinds restarted in the manner of `kubectl rollout restart`.
+var restartableKinds = map[types.ResourceKind]bool{
+	types.ResourceKindDeployment:  true,
+	types.ResourceKindStatefulSet: true,
+	types.ResourceKindDaemonSet:   true,
+}
+
+// Target identifies a single resource.
+type Target struct {
+	Kind      types.ResourceKind `json:"kind"`
+	Namespace string             `json:"namespace,omitempty"`
+	Name      string             `json:"name"`
+}
+
+// TargetSelector selects all resources of the kind matching the label selector. Empty namespace selects resources from
+// all namespaces.
+type TargetSelector struct {
+	Kind          types.ResourceKind `json:"kind"`
+	Namespace     string             `json:"namespace,omitempty"`
+	LabelSelector string             `json:"labelSelector"`
+}
+
+// BulkOperationSpec is a specification of the bulk operation. Targets are given either as a list of items, or as
+// a selector, or both.
+type BulkOperationSpec struct {
+	Operation Operation       `json:"operation"`
+	Items     []Target        `json:"items,omitempty"`
+	Selector  *TargetSelector `json:"selector,omitempty"`
+
+	// Propagation policy and immediate deletion used by the delete operation.
+	Propagation string `json:"propagation,omitempty"`
+	DeleteNow   bool   `json:"deleteNow,omitempty"`
+
+	// Replicas is the desired number of replicas used by the scale operation.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Labels and annotations set by the label and annotate operations. Null value removes the key.
+	Labels      map[string]*string `json:"labels,omitempty"`
+	Annotations map[string]*string `json:"annotations,omitempty"`
+}
+
+// BulkItemResult is a result of the operation applied to a single target.
+type BulkItemResult struct {
+	Target  `json:",inline"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkOperationResult is a result of the bulk operation. Failure of some items does not stop the others, so the
+// result has to be checked per item.
+type BulkOperationResult struct {
+	Operation Operation        `json:"operation"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Items     []BulkItemResult `json:"items"`
+}
+
+// RunBulkOperation resolves targets of the spec and applies the operation to each of them. Invalid spec results in
+// a bad request error, while errors of the single items are reported in the result.
+func RunBulkOperation(verber client.ResourceVerber, spec *BulkOperationSpec) (*BulkOperationResult, error) {
+	apply, err := newOperation(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := resolveTargets(verber, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, target := range targets {
+		if err := validateTarget(spec.Operation, target); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &BulkOperationResult{
+		Operation: spec.Operation,
+		Total:     len(targets),
+		Items:     make([]BulkItemResult, len(targets)),
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentRequests)
+	for i, target := range targets {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, target Target) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			result.Items[i] = BulkItemResult{Target: target, Success: true}
+			if err := apply(verber, target); err != nil {
+				result.Items[i].Success = false
+				result.Items[i].Error = err.Error()
+			}
+		}(i, target)
+	}
+	wg.Wait()
+
+	for _, item := range result.Items {
+		if item.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+type operationFunc func(verber client.ResourceVerber, target Target) error
+
+func newOperation(spec *BulkOperationSpec) (operationFunc, error) {
+	switch spec.Operation {
+	case OperationDelete:
+		return func(verber client.ResourceVerber, target Target) error {
+			return verber.Delete(target.Kind.String(), target.Namespace, target.Name, spec.Propagation, spec.DeleteNow)
+		}, nil
+	case OperationRestart:
+		// Timestamp is shared, so all targets are marked as restarted at the same time.
+		restartedAt := time.Now().Format(time.RFC3339)
+		return newMergePatchOperation(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]string{deployment.RestartedAtAnnotationKey: restartedAt},
+					},
+				},
+			},
+		})
+	case OperationScale:
+		if spec.Replicas == nil || *spec.Replicas < 0 {
+			return nil, errors.NewBadRequest("scale operation requires non-negative replicas")
+		}
+		return newMergePatchOperation(map[string]interface{}{
+			"spec": map[string]interface{}{"replicas": *spec.Replicas},
+		}, "scale")
+	case OperationLabel:
+		if len(spec.Labels) == 0 {
+			return nil, errors.NewBadRequest("label operation requires labels")
+		}
+		if err := metadata.ValidateLabels(toMetadataPatchSpec(spec.Labels)); err != nil {
+			return nil, err
+		}
+		return newMergePatchOperation(map[string]interface{}{
+			"metadata": map[string]interface{}{"labels": spec.Labels},
+		})
+	case OperationAnnotate:
+		if len(spec.Annotations) == 0 {
+			return nil, errors.NewBadRequest("annotate operation requires annotations")
+		}
+		if err := metadata.ValidateAnnotations(toMetadataPatchSpec(spec.Annotations)); err != nil {
+			return nil, err
+		}
+		return newMergePatchOperation(map[string]interface{}{
+			"metadata": map[string]interface{}{"annotations": spec.Annotations},
+		})
+	case OperationCordon, OperationUncordon:
+		return newMergePatchOperation(map[string]interface{}{
+			"spec": map[string]interface{}{"unschedulable": spec.Operation == OperationCordon},
+		})
+	}
+
+	return nil, errors.NewBadRequest(fmt.Sprintf("unsupported operation %q", spec.Operation))
+}
+
+func newMergePatchOperation(patch map[string]interface{}, subresources ...string) (operationFunc, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(verber client.ResourceVerber, target Target) error {
+		_, err := verber.Patch(target.Kind.String(), target.Namespace, target.Name, k8stypes.MergePatchType, data,
+			subresources...)
+		return err
+	}, nil
+}
+
+// toMetadataPatchSpec converts keys with null values to removed keys.
+func toMetadataPatchSpec(values map[string]*string) *metadata.MetadataPatchSpec {
+	spec := &metadata.MetadataPatchSpec{Set: make(map[string]string)}
+	for key, value := range values {
+		if value == nil {
+			spec.Remove = append(spec.Remove, key)
+		} else {
+			spec.Set[key] = *value
+		}
+	}
+
+	return spec
+}
+
+// resolveTargets returns the listed items followed by the resources matching the selector. Duplicates are removed, so
+// the operation is applied to each resource only once.
+func resolveTargets(verber client.ResourceVerber, spec *BulkOperationSpec) ([]Target, error) {
+	if len(spec.Items) == 0 && spec.Selector == nil {
+		return nil, errors.NewBadRequest("bulk operation requires items or selector")
+	}
+
+	targets := make([]Target, 0, len(spec.Items))
+	seen := make(map[Target]bool)
+	add := func(target Target) {
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+
+	for _, item := range spec.Items {
+		if len(item.Kind) == 0 || len(item.Name) == 0 {
+			return nil, errors.NewBadRequest("bulk operation items require kind and name")
+		}
+		add(item)
+	}
+
+	if selector := spec.Selector; selector != nil {
+		if len(selector.Kind) == 0 {
+			return nil, errors.NewBadRequest("bulk operation selector requires kind")
+		}
+
+		// Empty selector would match all resources of the kind, which is rarely intended.
+		if len(selector.LabelSelector) == 0 {
+			return nil, errors.NewBadRequest("bulk operation selector requires label selector")
+		}
+
+		if _, err := labels.Parse(selector.LabelSelector); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid label selector: %s", err.Error()))
+		}
+
+		list, err := verber.List(selector.Kind.String(), selector.Namespace,
+			metaV1.ListOptions{LabelSelector: selector.LabelSelector})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list.Items {
+			add(Target{Kind: selector.Kind, Namespace: item.GetNamespace(), Name: item.GetName()})
+		}
+	}
+
+	if len(targets) > maxItems {
+		return nil, errors.NewBadRequest(fmt.Sprintf("bulk operation is limited to %d items, got %d", maxItems,
+			len(targets)))
+	}
+
+	return targets, nil
+}
+
+func validateTarget(operation Operation, target Target) error {
+	var supported bool
+	switch operation {
+	case OperationRestart:
+		supported = restartableKinds[target.Kind]
+	case OperationScale:
+		supported = target.Kind.Scalable()
+	case OperationCordon, OperationUncordon:
+		supported = target.Kind == types.ResourceKindNode
+	default:
+		supported = true
+	}
+
+	if !supported {
+		return errors.NewBadRequest(fmt.Sprintf("%s operation is not supported for %s kind", operation, target.Kind))
+	}
+
+	return nil
+}
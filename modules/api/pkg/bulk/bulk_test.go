@@ -0,0 +1,199 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// fakeVerber records performed actions and fails on the resources with the "broken" name.
+type fakeVerber struct {
+	lock    sync.Mutex
+	actions []string
+	objects []unstructured.Unstructured
+}
+
+func (self *fakeVerber) record(action string) error {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.actions = append(self.actions, action)
+	return nil
+}
+
+func (self *fakeVerber) Update(*unstructured.Unstructured) error {
+	return nil
+}
+
+func (self *fakeVerber) Apply(*unstructured.Unstructured, client.ApplyOptions) (*client.ApplyResult, error) {
+	return nil, nil
+}
+
+func (self *fakeVerber) Diff(*unstructured.Unstructured, *client.ApplyOptions) (*client.ResourceDiff, error) {
+	return nil, nil
+}
+
+func (self *fakeVerber) Get(string, string, string) (runtime.Object, error) {
+	return nil, nil
+}
+
+func (self *fakeVerber) Delete(kind string, namespace string, name string, propagation string, _ bool) error {
+	if name == "broken" {
+		return fmt.Errorf("cannot delete %s", name)
+	}
+
+	return self.record(fmt.Sprintf("delete %s %s/%s %s", kind, namespace, name, propagation))
+}
+
+func (self *fakeVerber) Patch(kind string, namespace string, name string, patchType k8stypes.PatchType, data []byte,
+	subresources ...string) (*unstructured.Unstructured, error) {
+	return nil, self.record(fmt.Sprintf("patch %s %s/%s %s %s %v", kind, namespace, name, patchType, data, subresources))
+}
+
+func (self *fakeVerber) List(kind string, namespace string, options metaV1.ListOptions) (*unstructured.UnstructuredList, error) {
+	if options.LabelSelector != "app=failed" {
+		return &unstructured.UnstructuredList{}, nil
+	}
+
+	return &unstructured.UnstructuredList{Items: self.objects}, nil
+}
+
+func newObject(namespace, name string) unstructured.Unstructured {
+	object := unstructured.Unstructured{}
+	object.SetNamespace(namespace)
+	object.SetName(name)
+	return object
+}
+
+func TestRunBulkOperation(t *testing.T) {
+	verber := &fakeVerber{objects: []unstructured.Unstructured{
+		newObject("default", "job-1"),
+		newObject("default", "broken"),
+		newObject("kube-system", "job-2"),
+	}}
+
+	actual, err := RunBulkOperation(verber, &BulkOperationSpec{
+		Operation:   OperationDelete,
+		Propagation: "Background",
+		Items:       []Target{{Kind: types.ResourceKindJob, Namespace: "default", Name: "job-1"}},
+		Selector:    &TargetSelector{Kind: types.ResourceKindJob, LabelSelector: "app=failed"},
+	})
+	if err != nil {
+		t.Fatalf("RunBulkOperation() returned error: %v", err)
+	}
+
+	expected := &BulkOperationResult{
+		Operation: OperationDelete,
+		Total:     3,
+		Succeeded: 2,
+		Failed:    1,
+		Items: []BulkItemResult{
+			{Target: Target{Kind: types.ResourceKindJob, Namespace: "default", Name: "job-1"}, Success: true},
+			{Target: Target{Kind: types.ResourceKindJob, Namespace: "default", Name: "broken"},
+				Error: "cannot delete broken"},
+			{Target: Target{Kind: types.ResourceKindJob, Namespace: "kube-system", Name: "job-2"}, Success: true},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("RunBulkOperation() == \n%#v\nexpected \n%#v", actual, expected)
+	}
+
+	if len(verber.actions) != 2 {
+		t.Errorf("RunBulkOperation() performed actions %v, expected 2 deletions", verber.actions)
+	}
+}
+
+func TestRunBulkOperationPatch(t *testing.T) {
+	replicas := int32(3)
+	value := "team-a"
+	cases := []struct {
+		spec     *BulkOperationSpec
+		expected string
+	}{
+		{
+			&BulkOperationSpec{
+				Operation: OperationScale,
+				Replicas:  &replicas,
+				Items:     []Target{{Kind: types.ResourceKindStatefulSet, Namespace: "default", Name: "db"}},
+			},
+			`patch statefulset default/db application/merge-patch+json {"spec":{"replicas":3}} [scale]`,
+		},
+		{
+			&BulkOperationSpec{
+				Operation: OperationLabel,
+				Labels:    map[string]*string{"owner": &value, "stale": nil},
+				Items:     []Target{{Kind: types.ResourceKindConfigMap, Namespace: "default", Name: "config"}},
+			},
+			`patch configmap default/config application/merge-patch+json ` +
+				`{"metadata":{"labels":{"owner":"team-a","stale":null}}} []`,
+		},
+		{
+			&BulkOperationSpec{
+				Operation: OperationCordon,
+				Items:     []Target{{Kind: types.ResourceKindNode, Name: "node-1"}},
+			},
+			`patch node /node-1 application/merge-patch+json {"spec":{"unschedulable":true}} []`,
+		},
+	}
+
+	for _, c := range cases {
+		verber := &fakeVerber{}
+		if _, err := RunBulkOperation(verber, c.spec); err != nil {
+			t.Fatalf("RunBulkOperation(%#v) returned error: %v", c.spec, err)
+		}
+
+		if len(verber.actions) != 1 || verber.actions[0] != c.expected {
+			t.Errorf("RunBulkOperation(%#v) performed actions %v, expected %s", c.spec, verber.actions, c.expected)
+		}
+	}
+}
+
+func TestRunBulkOperationInvalidSpec(t *testing.T) {
+	replicas := int32(1)
+	cases := []*BulkOperationSpec{
+		{Operation: "explode", Items: []Target{{Kind: types.ResourceKindPod, Name: "pod"}}},
+		{Operation: OperationDelete},
+		{Operation: OperationDelete, Selector: &TargetSelector{Kind: types.ResourceKindPod}},
+		{Operation: OperationDelete, Selector: &TargetSelector{Kind: types.ResourceKindPod, LabelSelector: "a in (b"}},
+		{Operation: OperationScale, Items: []Target{{Kind: types.ResourceKindDeployment, Name: "web"}}},
+		{Operation: OperationScale, Replicas: &replicas, Items: []Target{{Kind: types.ResourceKindJob, Name: "job"}}},
+		{Operation: OperationRestart, Items: []Target{{Kind: types.ResourceKindReplicaSet, Name: "web"}}},
+		{Operation: OperationCordon, Items: []Target{{Kind: types.ResourceKindPod, Name: "pod"}}},
+		{Operation: OperationLabel, Labels: map[string]*string{"invalid key!": nil},
+			Items: []Target{{Kind: types.ResourceKindPod, Name: "pod"}}},
+	}
+
+	for _, c := range cases {
+		verber := &fakeVerber{}
+		if _, err := RunBulkOperation(verber, c); !errors.IsBadRequest(err) {
+			t.Errorf("RunBulkOperation(%#v) should return bad request error, got %v", c, err)
+		}
+
+		if len(verber.actions) != 0 {
+			t.Errorf("RunBulkOperation(%#v) should not perform any action, got %v", c, verber.actions)
+		}
+	}
+}
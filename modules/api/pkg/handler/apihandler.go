@@ -15,32 +15,42 @@
 package handler
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
 	"k8s.io/dashboard/api/pkg/resource/networkpolicy"
 	"k8s.io/dashboard/client"
 	"k8s.io/dashboard/csrf"
 	"k8s.io/dashboard/errors"
+	resourcekind "k8s.io/dashboard/types"
 
 	"github.com/emicklei/go-restful/v3"
 	"golang.org/x/net/xsrftoken"
-	"k8s.io/client-go/tools/remotecommand"
 
+	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/api/pkg/bulk"
 	"k8s.io/dashboard/api/pkg/handler/parser"
 	"k8s.io/dashboard/api/pkg/integration"
+	"k8s.io/dashboard/api/pkg/metadata"
+	"k8s.io/dashboard/api/pkg/recording"
 	"k8s.io/dashboard/api/pkg/resource/clusterrole"
 	"k8s.io/dashboard/api/pkg/resource/clusterrolebinding"
 	"k8s.io/dashboard/api/pkg/resource/common"
 	"k8s.io/dashboard/api/pkg/resource/configmap"
 	"k8s.io/dashboard/api/pkg/resource/container"
 	"k8s.io/dashboard/api/pkg/resource/controller"
+	"k8s.io/dashboard/api/pkg/resource/controllerrevision"
 	"k8s.io/dashboard/api/pkg/resource/cronjob"
 	"k8s.io/dashboard/api/pkg/resource/customresourcedefinition"
 	"k8s.io/dashboard/api/pkg/resource/customresourcedefinition/types"
@@ -48,6 +58,7 @@ import (
 	"k8s.io/dashboard/api/pkg/resource/dataselect"
 	"k8s.io/dashboard/api/pkg/resource/deployment"
 	"k8s.io/dashboard/api/pkg/resource/event"
+	"k8s.io/dashboard/api/pkg/resource/gatewayapi"
 	"k8s.io/dashboard/api/pkg/resource/horizontalpodautoscaler"
 	"k8s.io/dashboard/api/pkg/resource/ingress"
 	"k8s.io/dashboard/api/pkg/resource/ingressclass"
@@ -58,6 +69,7 @@ import (
 	"k8s.io/dashboard/api/pkg/resource/persistentvolume"
 	"k8s.io/dashboard/api/pkg/resource/persistentvolumeclaim"
 	"k8s.io/dashboard/api/pkg/resource/pod"
+	"k8s.io/dashboard/api/pkg/resource/poddisruptionbudget"
 	"k8s.io/dashboard/api/pkg/resource/replicaset"
 	"k8s.io/dashboard/api/pkg/resource/replicationcontroller"
 	"k8s.io/dashboard/api/pkg/resource/role"
@@ -85,12 +97,6 @@ type APIHandler struct {
 	iManager integration.Manager
 }
 
-// TerminalResponse is sent by handleExecShell. The ID is a random session id that binds the original REST request and the SockJS connection.
-// Any client api in possession of this ID can hijack the terminal session.
-type TerminalResponse struct {
-	ID string `json:"id"`
-}
-
 type JSON string
 
 // CreateHTTPAPIHandler creates a new HTTP handler that handles all requests to the API of the backend.
@@ -108,8 +114,14 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 		Doc("API v1 container").
 		Param(apiV1Ws.QueryParameter("filterBy", "Comma delimited string used to apply filtering: 'propertyName,filterValue'")).
 		Param(apiV1Ws.QueryParameter("sortBy", "Name of the column to sort by")).
+		Param(apiV1Ws.QueryParameter("labelSelector", "Label selector passed to the apiserver when listing resources, "+
+			"not supported by lists of resources related to another resource")).
+		Param(apiV1Ws.QueryParameter("fieldSelector", "Field selector passed to the apiserver when listing resources, "+
+			"not supported by lists of resources related to another resource")).
 		Param(apiV1Ws.QueryParameter("itemsPerPage", "Number of items to return when pagination is applied")).
 		Param(apiV1Ws.QueryParameter("page", "Page number to return items from")).
+		Param(apiV1Ws.QueryParameter("limit", "Maximum number of items to list from the apiserver in a single chunk")).
+		Param(apiV1Ws.QueryParameter("continue", "Continue token returned with the previous chunk of the list")).
 		Param(apiV1Ws.QueryParameter("metricNames", "Metric names to download")).
 		Param(apiV1Ws.QueryParameter("aggregations", "Aggregations to be performed for each metric (default: sum)")).
 		Consumes(restful.MIME_JSON).
@@ -169,7 +181,15 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Doc("create an application from file").
 			Reads(deployment.AppDeploymentFromFileSpec{}).
 			Writes(deployment.AppDeploymentFromFileResponse{}).
-			Returns(http.StatusOK, "OK", deployment.AppDeploymentFromFileResponse{}))
+			Returns(http.StatusOK, "OK", deployment.AppDeploymentFromFileResponse{}).
+			Returns(http.StatusConflict, "Conflict", deployment.AppDeploymentFromFileResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeploymentfromfile/diff").To(apiHandler.handleDiffFromFile).
+			// docs
+			Doc("returns differences between the live objects and the objects from file using server-side dry-run").
+			Reads(deployment.AppDeploymentFromFileSpec{}).
+			Writes(deployment.AppDeploymentFromFileDiff{}).
+			Returns(http.StatusOK, "OK", deployment.AppDeploymentFromFileDiff{}))
 
 	// ReplicationController
 	apiV1Ws.Route(
@@ -302,6 +322,37 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
 			Writes(pod.PodDetail{}).
 			Returns(http.StatusOK, "OK", pod.PodDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/pod/{namespace}/{pod}/container/{container}/files").To(apiHandler.handleGetContainerFiles).
+			// docs
+			Doc("returns a list of files in the directory of the Container").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
+			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
+			Param(apiV1Ws.PathParameter("container", "name of container in the Pod")).
+			Param(apiV1Ws.QueryParameter("path", "absolute path of the directory")).
+			Writes(container.FileList{}).
+			Returns(http.StatusOK, "OK", container.FileList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/pod/{namespace}/{pod}/container/{container}/files/download").
+			To(apiHandler.handleDownloadContainerFile).
+			// docs
+			Doc("downloads the file from the Container, directories are downloaded as tar archives").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
+			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
+			Param(apiV1Ws.PathParameter("container", "name of container in the Pod")).
+			Param(apiV1Ws.QueryParameter("path", "absolute path of the file or directory")).
+			Writes([]byte{}).
+			Returns(http.StatusOK, "OK", []byte{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/pod/{namespace}/{pod}/container/{container}/files").To(apiHandler.handleUploadContainerFiles).
+			Consumes(mimeOctetStream, mimeTar).
+			// docs
+			Doc("uploads the file to the Container, tar archives are extracted to the directory").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
+			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
+			Param(apiV1Ws.PathParameter("container", "name of container in the Pod")).
+			Param(apiV1Ws.QueryParameter("path", "absolute path of the file, or of the directory for tar archives")).
+			Returns(http.StatusNoContent, "", nil))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/pod/{namespace}/{pod}/event").To(apiHandler.handleGetPodEvents).
 			// docs
@@ -311,14 +362,43 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Writes(common.EventList{}).
 			Returns(http.StatusOK, "OK", common.EventList{}))
 	apiV1Ws.Route(
-		apiV1Ws.GET("/pod/{namespace}/{pod}/shell/{container}").To(apiHandler.handleExecShell).
+		apiV1Ws.POST("/pod/{namespace}/{pod}/debug").To(apiHandler.handleDebugContainer).
 			// docs
-			Doc("handles exec into pod").
+			Doc("adds an ephemeral debug container to Pod, terminal is attached to it using the terminal endpoint").
 			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
 			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
-			Param(apiV1Ws.PathParameter("container", "name of container in the Pod")).
-			Writes(TerminalResponse{}).
-			Returns(http.StatusOK, "OK", TerminalResponse{}))
+			Reads(pod.DebugContainerSpec{}).
+			Writes(pod.DebugContainer{}).
+			Returns(http.StatusOK, "OK", pod.DebugContainer{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/terminal/recording").To(apiHandler.handleGetTerminalRecordingList).
+			// docs
+			Doc("returns a list of terminal session recordings the user is allowed to replay").
+			Writes(recording.RecordingList{}).
+			Returns(http.StatusOK, "OK", recording.RecordingList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/terminal/recording/{recording}").To(apiHandler.handleGetTerminalRecording).
+			// docs
+			Doc("returns metadata of the terminal session recording").
+			Param(apiV1Ws.PathParameter("recording", "ID of the recording")).
+			Writes(recording.Recording{}).
+			Returns(http.StatusOK, "OK", recording.Recording{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/terminal/recording/{recording}/cast").To(apiHandler.handleGetTerminalRecordingCast).
+			// docs
+			Doc("returns the terminal session recording in the asciicast v2 format").
+			Param(apiV1Ws.PathParameter("recording", "ID of the recording")).
+			Returns(http.StatusOK, "OK", nil))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/pod/{namespace}/{pod}/portforward/{port}").To(apiHandler.handlePortForward).
+			// docs
+			Doc("handles port forwarding to pod, the returned session is bound by the SockJS connection that sends "+
+				"its id and the bearer token used to create it").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
+			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
+			Param(apiV1Ws.PathParameter("port", "port of the Pod")).
+			Writes(PortForwardResponse{}).
+			Returns(http.StatusOK, "OK", PortForwardResponse{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/pod/{namespace}/{pod}/persistentvolumeclaim").To(apiHandler.handleGetPodPersistentVolumeClaims).
 			// docs
@@ -454,6 +534,36 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Param(apiV1Ws.PathParameter("daemonSet", "name of the DaemonSet")).
 			Writes(common.EventList{}).
 			Returns(http.StatusOK, "OK", common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/daemonset/{namespace}/{daemonSet}/history").
+			To(apiHandler.handleGetRevisionHistory(resourcekind.ResourceKindDaemonSet, "daemonSet")).
+			// docs
+			Doc("returns revision history of the DaemonSet").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the DaemonSet")).
+			Param(apiV1Ws.PathParameter("daemonSet", "name of the DaemonSet")).
+			Writes(controllerrevision.RevisionHistory{}).
+			Returns(http.StatusOK, "OK", controllerrevision.RevisionHistory{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/daemonset/{namespace}/{daemonSet}/history/diff").
+			To(apiHandler.handleGetRevisionDiff(resourcekind.ResourceKindDaemonSet, "daemonSet")).
+			// docs
+			Doc("returns pod template difference between two revisions of the DaemonSet").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the DaemonSet")).
+			Param(apiV1Ws.PathParameter("daemonSet", "name of the DaemonSet")).
+			Param(apiV1Ws.QueryParameter("from", "revision number to compare from")).
+			Param(apiV1Ws.QueryParameter("to", "revision number to compare to")).
+			Writes(controllerrevision.RevisionDiff{}).
+			Returns(http.StatusOK, "OK", controllerrevision.RevisionDiff{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/daemonset/{namespace}/{daemonSet}/rollback").
+			To(apiHandler.handleRevisionRollback(resourcekind.ResourceKindDaemonSet, "daemonSet")).
+			// docs
+			Doc("rolls back the DaemonSet to the target revision").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the DaemonSet")).
+			Param(apiV1Ws.PathParameter("daemonSet", "name of the DaemonSet")).
+			Reads(deployment.RolloutSpec{}).
+			Writes(deployment.RolloutSpec{}).
+			Returns(http.StatusOK, "OK", deployment.RolloutSpec{}))
 
 	// HorizontalPodAutoscaler
 	apiV1Ws.Route(
@@ -487,6 +597,37 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Writes(horizontalpodautoscaler.HorizontalPodAutoscalerDetail{}).
 			Returns(http.StatusOK, "OK", horizontalpodautoscaler.HorizontalPodAutoscalerDetail{}))
 
+	// PodDisruptionBudget
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget").To(apiHandler.handleGetPodDisruptionBudgetList).
+			// docs
+			Doc("returns a list of PodDisruptionBudgets from all namespaces").
+			Writes(poddisruptionbudget.PodDisruptionBudgetList{}).
+			Returns(http.StatusOK, "OK", poddisruptionbudget.PodDisruptionBudgetList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget/{namespace}").To(apiHandler.handleGetPodDisruptionBudgetList).
+			// docs
+			Doc("returns a list of PodDisruptionBudgets in a namespace").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the PodDisruptionBudget")).
+			Writes(poddisruptionbudget.PodDisruptionBudgetList{}).
+			Returns(http.StatusOK, "OK", poddisruptionbudget.PodDisruptionBudgetList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget/{namespace}/{poddisruptionbudget}").To(apiHandler.handleGetPodDisruptionBudgetDetail).
+			// docs
+			Doc("returns detailed information about PodDisruptionBudget").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the PodDisruptionBudget")).
+			Param(apiV1Ws.PathParameter("poddisruptionbudget", "name of the PodDisruptionBudget")).
+			Writes(poddisruptionbudget.PodDisruptionBudgetDetail{}).
+			Returns(http.StatusOK, "OK", poddisruptionbudget.PodDisruptionBudgetDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget/{namespace}/{poddisruptionbudget}/pod").To(apiHandler.handleGetPodDisruptionBudgetPods).
+			// docs
+			Doc("returns a list of Pods matched by PodDisruptionBudget").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the PodDisruptionBudget")).
+			Param(apiV1Ws.PathParameter("poddisruptionbudget", "name of the PodDisruptionBudget")).
+			Writes(pod.PodList{}).
+			Returns(http.StatusOK, "OK", pod.PodList{}))
+
 	// Job
 	apiV1Ws.Route(
 		apiV1Ws.GET("/job").To(apiHandler.handleGetJobList).
@@ -616,6 +757,20 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Param(apiV1Ws.PathParameter("namespace", "namespace to get Events from")).
 			Writes(common.EventList{}).
 			Returns(http.StatusOK, "OK", common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/event/{namespace}/timeline/{kind}/{name}").To(apiHandler.handleGetEventTimeline).
+			// docs
+			Doc("returns merged timeline of Events of the resource and all resources it owns, i.e. ReplicaSets and Pods of Deployment").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the resource")).
+			Param(apiV1Ws.PathParameter("kind", "kind of the resource, one of deployment, replicaset, statefulset, daemonset, cronjob, job or pod")).
+			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Param(apiV1Ws.QueryParameter("type", "comma separated list of Event types to include, i.e. Warning")).
+			Param(apiV1Ws.QueryParameter("reason", "comma separated list of Event reasons to include")).
+			Param(apiV1Ws.QueryParameter("since", "duration of the time window ending now, i.e. 30m")).
+			Param(apiV1Ws.QueryParameter("from", "start of the time window in RFC 3339 format")).
+			Param(apiV1Ws.QueryParameter("to", "end of the time window in RFC 3339 format")).
+			Writes(event.Timeline{}).
+			Returns(http.StatusOK, "OK", event.Timeline{}))
 
 	// Secret
 	apiV1Ws.Route(
@@ -716,6 +871,14 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Param(apiV1Ws.PathParameter("service", "name of the Service")).
 			Writes(ingress.IngressList{}).
 			Returns(http.StatusOK, "OK", ingress.IngressList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/service/{namespace}/{service}/route").To(apiHandler.handleGetServiceRouteList).
+			// docs
+			Doc("returns a list of Gateway API HTTPRoutes and GRPCRoutes for Service").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Service")).
+			Param(apiV1Ws.PathParameter("service", "name of the Service")).
+			Writes(gatewayapi.RouteList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.RouteList{}))
 
 	// ServiceAccount
 	apiV1Ws.Route(
@@ -846,6 +1009,36 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Param(apiV1Ws.PathParameter("statefulset", "name of the StatefulSet")).
 			Writes(common.EventList{}).
 			Returns(http.StatusOK, "OK", common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/statefulset/{namespace}/{statefulset}/history").
+			To(apiHandler.handleGetRevisionHistory(resourcekind.ResourceKindStatefulSet, "statefulset")).
+			// docs
+			Doc("returns revision history of the StatefulSet").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the StatefulSet")).
+			Param(apiV1Ws.PathParameter("statefulset", "name of the StatefulSet")).
+			Writes(controllerrevision.RevisionHistory{}).
+			Returns(http.StatusOK, "OK", controllerrevision.RevisionHistory{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/statefulset/{namespace}/{statefulset}/history/diff").
+			To(apiHandler.handleGetRevisionDiff(resourcekind.ResourceKindStatefulSet, "statefulset")).
+			// docs
+			Doc("returns pod template difference between two revisions of the StatefulSet").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the StatefulSet")).
+			Param(apiV1Ws.PathParameter("statefulset", "name of the StatefulSet")).
+			Param(apiV1Ws.QueryParameter("from", "revision number to compare from")).
+			Param(apiV1Ws.QueryParameter("to", "revision number to compare to")).
+			Writes(controllerrevision.RevisionDiff{}).
+			Returns(http.StatusOK, "OK", controllerrevision.RevisionDiff{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/statefulset/{namespace}/{statefulset}/rollback").
+			To(apiHandler.handleRevisionRollback(resourcekind.ResourceKindStatefulSet, "statefulset")).
+			// docs
+			Doc("rolls back the StatefulSet to the target revision").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the StatefulSet")).
+			Param(apiV1Ws.PathParameter("statefulset", "name of the StatefulSet")).
+			Reads(deployment.RolloutSpec{}).
+			Writes(deployment.RolloutSpec{}).
+			Returns(http.StatusOK, "OK", deployment.RolloutSpec{}))
 
 	// Node
 	apiV1Ws.Route(
@@ -878,10 +1071,56 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 	apiV1Ws.Route(
 		apiV1Ws.PUT("/node/{name}/drain").To(apiHandler.handleNodeDrain).
 			// docs
-			Doc("drains Node").
+			Doc("starts cordoning and draining Node in the background").
 			Param(apiV1Ws.PathParameter("name", "name of the Node")).
 			Reads(node.NodeDrainSpec{}).
-			Returns(http.StatusOK, "OK", nil))
+			Writes(node.NodeDrainStatus{}).
+			Returns(http.StatusAccepted, "Accepted", node.NodeDrainStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/node/{name}/drain").To(apiHandler.handleGetNodeDrainStatusList).
+			// docs
+			Doc("returns running and recently finished drains of Node").
+			Param(apiV1Ws.PathParameter("name", "name of the Node")).
+			Writes(node.NodeDrainStatusList{}).
+			Returns(http.StatusOK, "OK", node.NodeDrainStatusList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/node/{name}/drain/{drain}").To(apiHandler.handleGetNodeDrainStatus).
+			// docs
+			Doc("returns status and progress of Node drain").
+			Param(apiV1Ws.PathParameter("name", "name of the Node")).
+			Param(apiV1Ws.PathParameter("drain", "ID of the drain")).
+			Writes(node.NodeDrainStatus{}).
+			Returns(http.StatusOK, "OK", node.NodeDrainStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/node/{name}/drain/{drain}").To(apiHandler.handleCancelNodeDrain).
+			// docs
+			Doc("cancels Node drain, Node stays cordoned").
+			Param(apiV1Ws.PathParameter("name", "name of the Node")).
+			Param(apiV1Ws.PathParameter("drain", "ID of the drain")).
+			Writes(node.NodeDrainStatus{}).
+			Returns(http.StatusOK, "OK", node.NodeDrainStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/node/{name}/uncordon").To(apiHandler.handleNodeUncordon).
+			// docs
+			Doc("marks Node as schedulable").
+			Param(apiV1Ws.PathParameter("name", "name of the Node")).
+			Returns(http.StatusNoContent, "", nil))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/node/{name}/taint").To(apiHandler.handlePatchNodeTaints).
+			// docs
+			Doc("adds or removes Node taints").
+			Param(apiV1Ws.PathParameter("name", "name of the Node")).
+			Reads(node.NodeTaintSpec{}).
+			Writes(node.NodeTaints{}).
+			Returns(http.StatusOK, "OK", node.NodeTaints{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/node/{name}/drain/check").To(apiHandler.handleNodeDrainCheck).
+			// docs
+			Doc("checks which Pods would be evicted by draining Node and which PodDisruptionBudgets would block it").
+			Param(apiV1Ws.PathParameter("name", "name of the Node")).
+			Reads(node.NodeDrainSpec{}).
+			Writes(node.NodeDrainCheck{}).
+			Returns(http.StatusOK, "OK", node.NodeDrainCheck{}))
 
 	// Verber (namespaced)
 	apiV1Ws.Route(
@@ -910,8 +1149,48 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Param(apiV1Ws.PathParameter("kind", "kind of the resource")).
 			Param(apiV1Ws.PathParameter("namespace", "namespace of the resource")).
 			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Param(apiV1Ws.QueryParameter("serverSideApply", "use server-side apply instead of update")).
+			Param(apiV1Ws.QueryParameter("fieldManager", "name of the field manager used by server-side apply")).
+			Param(apiV1Ws.QueryParameter("force", "take ownership of fields conflicting with other field managers")).
+			Param(apiV1Ws.QueryParameter("dryRun", "set to 'All' to return the applied object without persisting it")).
 			Reads(JSON("")).
-			Returns(http.StatusNoContent, "", nil))
+			Writes(client.ApplyResult{}).
+			Returns(http.StatusNoContent, "", nil).
+			Returns(http.StatusOK, "OK", client.ApplyResult{}).
+			Returns(http.StatusConflict, "Conflict", client.ApplyResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/_raw/{kind}/namespace/{namespace}/name/{name}/diff").To(apiHandler.handleDiffResource).
+			// docs
+			Doc("returns differences between the live resource in a namespace and the given one using server-side dry-run").
+			Param(apiV1Ws.PathParameter("kind", "kind of the resource")).
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the resource")).
+			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Param(apiV1Ws.QueryParameter("serverSideApply", "use server-side apply instead of update")).
+			Param(apiV1Ws.QueryParameter("fieldManager", "name of the field manager used by server-side apply")).
+			Param(apiV1Ws.QueryParameter("force", "take ownership of fields conflicting with other field managers")).
+			Reads(JSON("")).
+			Writes(client.ResourceDiff{}).
+			Returns(http.StatusOK, "OK", client.ResourceDiff{}))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/namespace/{namespace}/name/{name}/labels").To(apiHandler.handlePatchMetadata(metadata.PatchLabels)).
+			// docs
+			Doc("adds, removes or replaces labels of a resource in a namespace").
+			Param(apiV1Ws.PathParameter("kind", "kind of the resource")).
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the resource")).
+			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Reads(metadata.MetadataPatchSpec{}).
+			Writes(metadata.Metadata{}).
+			Returns(http.StatusOK, "OK", metadata.Metadata{}))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/namespace/{namespace}/name/{name}/annotations").To(apiHandler.handlePatchMetadata(metadata.PatchAnnotations)).
+			// docs
+			Doc("adds, removes or replaces annotations of a resource in a namespace").
+			Param(apiV1Ws.PathParameter("kind", "kind of the resource")).
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the resource")).
+			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Reads(metadata.MetadataPatchSpec{}).
+			Writes(metadata.Metadata{}).
+			Returns(http.StatusOK, "OK", metadata.Metadata{}))
 
 	// Verber (non-namespaced)
 	apiV1Ws.Route(
@@ -937,8 +1216,45 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Doc("creates or updates a non-namespaced resource").
 			Param(apiV1Ws.PathParameter("kind", "kind of the resource")).
 			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Param(apiV1Ws.QueryParameter("serverSideApply", "use server-side apply instead of update")).
+			Param(apiV1Ws.QueryParameter("fieldManager", "name of the field manager used by server-side apply")).
+			Param(apiV1Ws.QueryParameter("force", "take ownership of fields conflicting with other field managers")).
+			Param(apiV1Ws.QueryParameter("dryRun", "set to 'All' to return the applied object without persisting it")).
 			Reads(JSON("")).
-			Returns(http.StatusNoContent, "", nil))
+			Writes(client.ApplyResult{}).
+			Returns(http.StatusNoContent, "", nil).
+			Returns(http.StatusOK, "OK", client.ApplyResult{}).
+			Returns(http.StatusConflict, "Conflict", client.ApplyResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/_raw/{kind}/name/{name}/diff").To(apiHandler.handleDiffResource).
+			// docs
+			Doc("returns differences between the live non-namespaced resource and the given one using server-side dry-run").
+			Param(apiV1Ws.PathParameter("kind", "kind of the resource")).
+			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Param(apiV1Ws.QueryParameter("serverSideApply", "use server-side apply instead of update")).
+			Param(apiV1Ws.QueryParameter("fieldManager", "name of the field manager used by server-side apply")).
+			Param(apiV1Ws.QueryParameter("force", "take ownership of fields conflicting with other field managers")).
+			Reads(JSON("")).
+			Writes(client.ResourceDiff{}).
+			Returns(http.StatusOK, "OK", client.ResourceDiff{}))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/name/{name}/labels").To(apiHandler.handlePatchMetadata(metadata.PatchLabels)).
+			// docs
+			Doc("adds, removes or replaces labels of a non-namespaced resource").
+			Param(apiV1Ws.PathParameter("kind", "kind of the resource")).
+			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Reads(metadata.MetadataPatchSpec{}).
+			Writes(metadata.Metadata{}).
+			Returns(http.StatusOK, "OK", metadata.Metadata{}))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/name/{name}/annotations").To(apiHandler.handlePatchMetadata(metadata.PatchAnnotations)).
+			// docs
+			Doc("adds, removes or replaces annotations of a non-namespaced resource").
+			Param(apiV1Ws.PathParameter("kind", "kind of the resource")).
+			Param(apiV1Ws.PathParameter("name", "name of the resource")).
+			Reads(metadata.MetadataPatchSpec{}).
+			Writes(metadata.Metadata{}).
+			Returns(http.StatusOK, "OK", metadata.Metadata{}))
 
 	// Generic resource scaling
 	apiV1Ws.Route(
@@ -978,6 +1294,15 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Writes(scaling.ReplicaCounts{}).
 			Returns(http.StatusOK, "OK", scaling.ReplicaCounts{}))
 
+	// Bulk operations
+	apiV1Ws.Route(
+		apiV1Ws.POST("/bulk").To(apiHandler.handleBulkOperation).
+			// docs
+			Doc("applies delete, restart, scale, label, annotate, cordon or uncordon operation to many resources at once").
+			Reads(bulk.BulkOperationSpec{}).
+			Writes(bulk.BulkOperationResult{}).
+			Returns(http.StatusOK, "OK", bulk.BulkOperationResult{}))
+
 	// ClusterRole
 	apiV1Ws.Route(
 		apiV1Ws.GET("/clusterrole").To(apiHandler.handleGetClusterRoleList).
@@ -1190,6 +1515,113 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Writes(ingressclass.IngressClass{}).
 			Returns(http.StatusOK, "OK", ingressclass.IngressClass{}))
 
+	// GatewayClass
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gatewayclass").To(apiHandler.handleGetGatewayClassList).
+			// docs
+			Doc("returns a list of GatewayClasses").
+			Writes(gatewayapi.GatewayClassList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.GatewayClassList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gatewayclass/{name}").To(apiHandler.handleGetGatewayClassDetail).
+			// docs
+			Doc("returns detailed information about GatewayClass").
+			Param(apiV1Ws.PathParameter("name", "name of the GatewayClass")).
+			Writes(gatewayapi.GatewayClassDetail{}).
+			Returns(http.StatusOK, "OK", gatewayapi.GatewayClassDetail{}))
+
+	// Gateway
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gateway").To(apiHandler.handleGetGatewayList).
+			// docs
+			Doc("returns a list of Gateways from all namespaces").
+			Writes(gatewayapi.GatewayList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.GatewayList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gateway/{namespace}").To(apiHandler.handleGetGatewayList).
+			// docs
+			Doc("returns a list of Gateways in a namespace").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Gateway")).
+			Writes(gatewayapi.GatewayList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.GatewayList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gateway/{namespace}/{name}").To(apiHandler.handleGetGatewayDetail).
+			// docs
+			Doc("returns detailed information about Gateway").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Gateway")).
+			Param(apiV1Ws.PathParameter("name", "name of the Gateway")).
+			Writes(gatewayapi.GatewayDetail{}).
+			Returns(http.StatusOK, "OK", gatewayapi.GatewayDetail{}))
+
+	// HTTPRoute
+	apiV1Ws.Route(
+		apiV1Ws.GET("/httproute").To(apiHandler.handleGetRouteList(resourcekind.ResourceKindHTTPRoute)).
+			// docs
+			Doc("returns a list of HTTPRoutes from all namespaces").
+			Writes(gatewayapi.RouteList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.RouteList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/httproute/{namespace}").To(apiHandler.handleGetRouteList(resourcekind.ResourceKindHTTPRoute)).
+			// docs
+			Doc("returns a list of HTTPRoutes in a namespace").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the HTTPRoute")).
+			Writes(gatewayapi.RouteList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.RouteList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/httproute/{namespace}/{name}").To(apiHandler.handleGetRouteDetail(resourcekind.ResourceKindHTTPRoute)).
+			// docs
+			Doc("returns detailed information about HTTPRoute").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the HTTPRoute")).
+			Param(apiV1Ws.PathParameter("name", "name of the HTTPRoute")).
+			Writes(gatewayapi.RouteDetail{}).
+			Returns(http.StatusOK, "OK", gatewayapi.RouteDetail{}))
+
+	// GRPCRoute
+	apiV1Ws.Route(
+		apiV1Ws.GET("/grpcroute").To(apiHandler.handleGetRouteList(resourcekind.ResourceKindGRPCRoute)).
+			// docs
+			Doc("returns a list of GRPCRoutes from all namespaces").
+			Writes(gatewayapi.RouteList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.RouteList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/grpcroute/{namespace}").To(apiHandler.handleGetRouteList(resourcekind.ResourceKindGRPCRoute)).
+			// docs
+			Doc("returns a list of GRPCRoutes in a namespace").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the GRPCRoute")).
+			Writes(gatewayapi.RouteList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.RouteList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/grpcroute/{namespace}/{name}").To(apiHandler.handleGetRouteDetail(resourcekind.ResourceKindGRPCRoute)).
+			// docs
+			Doc("returns detailed information about GRPCRoute").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the GRPCRoute")).
+			Param(apiV1Ws.PathParameter("name", "name of the GRPCRoute")).
+			Writes(gatewayapi.RouteDetail{}).
+			Returns(http.StatusOK, "OK", gatewayapi.RouteDetail{}))
+
+	// ReferenceGrant
+	apiV1Ws.Route(
+		apiV1Ws.GET("/referencegrant").To(apiHandler.handleGetReferenceGrantList).
+			// docs
+			Doc("returns a list of ReferenceGrants from all namespaces").
+			Writes(gatewayapi.ReferenceGrantList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.ReferenceGrantList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/referencegrant/{namespace}").To(apiHandler.handleGetReferenceGrantList).
+			// docs
+			Doc("returns a list of ReferenceGrants in a namespace").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the ReferenceGrant")).
+			Writes(gatewayapi.ReferenceGrantList{}).
+			Returns(http.StatusOK, "OK", gatewayapi.ReferenceGrantList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/referencegrant/{namespace}/{name}").To(apiHandler.handleGetReferenceGrantDetail).
+			// docs
+			Doc("returns detailed information about ReferenceGrant").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the ReferenceGrant")).
+			Param(apiV1Ws.PathParameter("name", "name of the ReferenceGrant")).
+			Writes(gatewayapi.ReferenceGrantDetail{}).
+			Returns(http.StatusOK, "OK", gatewayapi.ReferenceGrantDetail{}))
+
 	// Logs
 	apiV1Ws.Route(
 		apiV1Ws.GET("/log/source/{namespace}/{resourceName}/{resourceType}").
@@ -1208,6 +1640,8 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Doc("returns logs from a Pod").
 			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
 			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
+			Param(apiV1Ws.QueryParameter("fieldFilter", "comma separated conditions on structured log fields, i.e. 'level>=warn,trace_id=abc'")).
+			Param(apiV1Ws.QueryParameter("structured", "set to 'true' to return format and fields of structured log lines")).
 			Writes(logs.LogDetails{}).
 			Returns(http.StatusOK, "OK", logs.LogDetails{}))
 	apiV1Ws.Route(
@@ -1218,8 +1652,41 @@ func CreateHTTPAPIHandler(iManager integration.Manager) (*restful.Container, err
 			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
 			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
 			Param(apiV1Ws.PathParameter("container", "name of container in the Pod")).
+			Param(apiV1Ws.QueryParameter("fieldFilter", "comma separated conditions on structured log fields, i.e. 'level>=warn,trace_id=abc'")).
+			Param(apiV1Ws.QueryParameter("structured", "set to 'true' to return format and fields of structured log lines")).
 			Writes(logs.LogDetails{}).
 			Returns(http.StatusOK, "OK", logs.LogDetails{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/log/search/{namespace}/{pod}").
+			To(apiHandler.handleLogSearch).
+			// docs
+			Doc("searches logs from a Pod").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
+			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
+			Param(apiV1Ws.QueryParameter("query", "substring or regular expression to search for")).
+			Param(apiV1Ws.QueryParameter("regex", "whether the query is a regular expression (default: false)")).
+			Param(apiV1Ws.QueryParameter("caseSensitive", "whether the query is case sensitive (default: false)")).
+			Param(apiV1Ws.QueryParameter("sinceTime", "RFC3339 time, older lines are skipped")).
+			Param(apiV1Ws.QueryParameter("untilTime", "RFC3339 time, newer lines are skipped")).
+			Param(apiV1Ws.QueryParameter("contextLines", "number of lines returned before and after each match")).
+			Writes(logs.LogSearchResult{}).
+			Returns(http.StatusOK, "OK", logs.LogSearchResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/log/search/{namespace}/{pod}/{container}").
+			To(apiHandler.handleLogSearch).
+			// docs
+			Doc("searches logs from a Container").
+			Param(apiV1Ws.PathParameter("namespace", "namespace of the Pod")).
+			Param(apiV1Ws.PathParameter("pod", "name of the Pod")).
+			Param(apiV1Ws.PathParameter("container", "name of container in the Pod")).
+			Param(apiV1Ws.QueryParameter("query", "substring or regular expression to search for")).
+			Param(apiV1Ws.QueryParameter("regex", "whether the query is a regular expression (default: false)")).
+			Param(apiV1Ws.QueryParameter("caseSensitive", "whether the query is case sensitive (default: false)")).
+			Param(apiV1Ws.QueryParameter("sinceTime", "RFC3339 time, older lines are skipped")).
+			Param(apiV1Ws.QueryParameter("untilTime", "RFC3339 time, newer lines are skipped")).
+			Param(apiV1Ws.QueryParameter("contextLines", "number of lines returned before and after each match")).
+			Writes(logs.LogSearchResult{}).
+			Returns(http.StatusOK, "OK", logs.LogSearchResult{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/log/file/{namespace}/{pod}/{container}").
 			To(apiHandler.handleLogFile).
@@ -1241,7 +1708,12 @@ func (apiHandler *APIHandler) handleGetClusterRoleList(request *restful.Request,
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := clusterrole.GetClusterRoleList(k8sClient, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1273,7 +1745,12 @@ func (apiHandler *APIHandler) handleGetClusterRoleBindingList(request *restful.R
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := clusterrolebinding.GetClusterRoleBindingList(k8sClient, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1306,7 +1783,12 @@ func (apiHandler *APIHandler) handleGetRoleList(request *restful.Request, respon
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := role.GetRoleList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1340,7 +1822,12 @@ func (apiHandler *APIHandler) handleGetRoleBindingList(request *restful.Request,
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := rolebinding.GetRoleBindingList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1380,7 +1867,12 @@ func (apiHandler *APIHandler) handleGetStatefulSetList(request *restful.Request,
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := statefulset.GetStatefulSetList(k8sClient, namespace, dataSelect,
 		apiHandler.iManager.Metric().Client())
@@ -1418,7 +1910,12 @@ func (apiHandler *APIHandler) handleGetStatefulSetPods(request *restful.Request,
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("statefulset")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := statefulset.GetStatefulSetPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, name, namespace)
 	if err != nil {
@@ -1437,7 +1934,12 @@ func (apiHandler *APIHandler) handleGetStatefulSetEvents(request *restful.Reques
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("statefulset")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1454,7 +1956,12 @@ func (apiHandler *APIHandler) handleGetServiceList(request *restful.Request, res
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := resourceService.GetServiceList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1489,7 +1996,12 @@ func (apiHandler *APIHandler) handleGetServiceEvent(request *restful.Request, re
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("service")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := resourceService.GetServiceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -1507,7 +2019,12 @@ func (apiHandler *APIHandler) handleGetServiceAccountList(request *restful.Reque
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := serviceaccount.GetServiceAccountList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1542,7 +2059,12 @@ func (apiHandler *APIHandler) handleGetServiceAccountImagePullSecrets(request *r
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("serviceaccount")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := serviceaccount.GetServiceAccountImagePullSecrets(k8sClient, namespace, name, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1560,7 +2082,12 @@ func (apiHandler *APIHandler) handleGetServiceAccountSecrets(request *restful.Re
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("serviceaccount")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := serviceaccount.GetServiceAccountSecrets(k8sClient, namespace, name, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1595,7 +2122,12 @@ func (apiHandler *APIHandler) handleGetIngressEvent(request *restful.Request, re
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("ingress")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.NoMetrics
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -1612,7 +2144,12 @@ func (apiHandler *APIHandler) handleGetIngressList(request *restful.Request, res
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	namespace := parseNamespacePathParameter(request)
 	result, err := ingress.GetIngressList(k8sClient, namespace, dataSelect)
 	if err != nil {
@@ -1631,7 +2168,12 @@ func (apiHandler *APIHandler) handleGetServicePods(request *restful.Request, res
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("service")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := resourceService.GetServicePods(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name, dataSelect)
 	if err != nil {
@@ -1650,9 +2192,14 @@ func (apiHandler *APIHandler) handleGetServiceIngressList(request *restful.Reque
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("service")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.NoMetrics
-	result, err := resourceService.GetServiceIngressList(k8sClient, dataSelect, namespace, name)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect.MetricQuery = dataselect.NoMetrics
+	result, err := resourceService.GetServiceIngressList(k8sClient, dataSelect, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1668,7 +2215,12 @@ func (apiHandler *APIHandler) handleGetNetworkPolicyList(request *restful.Reques
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := networkpolicy.GetNetworkPolicyList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1701,7 +2253,12 @@ func (apiHandler *APIHandler) handleGetNodeList(request *restful.Request, respon
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := node.GetNodeList(k8sClient, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
@@ -1719,7 +2276,12 @@ func (apiHandler *APIHandler) handleGetNodeDetail(request *restful.Request, resp
 	}
 
 	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := node.GetNodeDetail(k8sClient, apiHandler.iManager.Metric().Client(), name, dataSelect)
 	if err != nil {
@@ -1737,7 +2299,12 @@ func (apiHandler *APIHandler) handleGetNodeEvents(request *restful.Request, resp
 	}
 
 	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := event.GetNodeEvents(k8sClient, dataSelect, name)
 	if err != nil {
@@ -1755,7 +2322,12 @@ func (apiHandler *APIHandler) handleGetNodePods(request *restful.Request, respon
 	}
 
 	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := node.GetNodePods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, name)
 	if err != nil {
@@ -1779,12 +2351,134 @@ func (apiHandler *APIHandler) handleNodeDrain(request *restful.Request, response
 		return
 	}
 
-	if err := node.DrainNode(k8sClient, name, spec); err != nil {
+	if err := node.CheckNodeDrainAccess(k8sClient, name); err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	response.WriteHeader(http.StatusAccepted)
+	result, err := node.StartNodeDrain(k8sClient, name, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusAccepted, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodeDrainStatusList(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	if err := apiHandler.checkNodeAccess(request, name); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	_ = response.WriteHeaderAndEntity(http.StatusOK, node.GetNodeDrainStatusList(name))
+}
+
+func (apiHandler *APIHandler) handleGetNodeDrainStatus(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	if err := apiHandler.checkNodeAccess(request, name); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := node.GetNodeDrainStatus(name, request.PathParameter("drain"))
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCancelNodeDrain(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	if err := node.CheckNodeDrainAccess(k8sClient, name); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := node.CancelNodeDrain(name, request.PathParameter("drain"))
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// checkNodeAccess verifies that the user can get the Node. Drains run in the background, so their status is not
+// protected by the API server.
+func (apiHandler *APIHandler) checkNodeAccess(request *restful.Request, name string) error {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		return err
+	}
+
+	_, err = k8sClient.CoreV1().Nodes().Get(context.TODO(), name, metaV1.GetOptions{})
+	return err
+}
+
+func (apiHandler *APIHandler) handleNodeUncordon(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	if err := node.UncordonNode(k8sClient, request.PathParameter("name")); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusNoContent)
+}
+
+func (apiHandler *APIHandler) handlePatchNodeTaints(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	spec := new(node.NodeTaintSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := node.PatchNodeTaints(k8sClient, name, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleNodeDrainCheck(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	spec := new(node.NodeDrainSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := node.CheckNodeDrain(k8sClient, name, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
 func (apiHandler *APIHandler) handleDeploy(request *restful.Request, response *restful.Response) {
@@ -1825,6 +2519,27 @@ func (apiHandler *APIHandler) handleScaleResource(request *restful.Request, resp
 	_ = response.WriteHeaderAndEntity(http.StatusOK, replicaCountSpec)
 }
 
+func (apiHandler *APIHandler) handleBulkOperation(request *restful.Request, response *restful.Response) {
+	verber, err := client.VerberClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(bulk.BulkOperationSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := bulk.RunBulkOperation(verber, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleGetReplicaCount(request *restful.Request, response *restful.Response) {
 	cfg, err := client.Config(request.Request)
 	if err != nil {
@@ -1856,6 +2571,11 @@ func (apiHandler *APIHandler) handleDeployFromFile(request *restful.Request, res
 		return
 	}
 
+	if deploymentSpec.ServerSideApply {
+		apiHandler.handleApplyFromFile(cfg, deploymentSpec, response)
+		return
+	}
+
 	isDeployed, err := deployment.DeployAppFromFile(cfg, deploymentSpec)
 	if !isDeployed {
 		errors.HandleInternalError(response, err)
@@ -1874,6 +2594,73 @@ func (apiHandler *APIHandler) handleDeployFromFile(request *restful.Request, res
 	})
 }
 
+func (apiHandler *APIHandler) handleDiffFromFile(request *restful.Request, response *restful.Response) {
+	cfg, err := client.Config(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	deploymentSpec := new(deployment.AppDeploymentFromFileSpec)
+	if err := request.ReadEntity(deploymentSpec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	var applyOptions *client.ApplyOptions
+	if deploymentSpec.ServerSideApply {
+		if applyOptions, err = client.NewApplyOptions(deploymentSpec.FieldManager, deploymentSpec.Force, ""); err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+	}
+
+	result, err := deployment.DiffAppFromFile(cfg, deploymentSpec, applyOptions)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleApplyFromFile(cfg *rest.Config, deploymentSpec *deployment.AppDeploymentFromFileSpec,
+	response *restful.Response) {
+	dryRun := ""
+	if deploymentSpec.DryRun {
+		dryRun = metaV1.DryRunAll
+	}
+
+	applyOptions, err := client.NewApplyOptions(deploymentSpec.FieldManager, deploymentSpec.Force, dryRun)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	results, err := deployment.ApplyAppFromFile(cfg, deploymentSpec, *applyOptions)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if deploymentSpec.DryRun {
+		status = http.StatusOK
+	}
+
+	for _, result := range results {
+		if len(result.Conflicts) > 0 && !deploymentSpec.DryRun {
+			status = http.StatusConflict
+		}
+	}
+
+	_ = response.WriteHeaderAndEntity(status, deployment.AppDeploymentFromFileResponse{
+		Name:         deploymentSpec.Name,
+		Content:      deploymentSpec.Content,
+		ApplyResults: results,
+	})
+}
+
 func (apiHandler *APIHandler) handleDeploymentPause(request *restful.Request, response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
 	if err != nil {
@@ -1913,6 +2700,95 @@ func (apiHandler *APIHandler) handleDeploymentRollback(request *restful.Request,
 	_ = response.WriteHeaderAndEntity(http.StatusOK, rolloutSpec)
 }
 
+func (apiHandler *APIHandler) handleGetRevisionHistory(kind, nameParameter string) restful.RouteFunction {
+	return func(request *restful.Request, response *restful.Response) {
+		k8sClient, err := client.Client(request.Request)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		namespace := request.PathParameter("namespace")
+		name := request.PathParameter(nameParameter)
+		result, err := controllerrevision.GetRevisionHistory(k8sClient, kind, namespace, name)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+		_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+	}
+}
+
+func (apiHandler *APIHandler) handleGetRevisionDiff(kind, nameParameter string) restful.RouteFunction {
+	return func(request *restful.Request, response *restful.Response) {
+		k8sClient, err := client.Client(request.Request)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		from, err := parseRevision(request.QueryParameter("from"))
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		to, err := parseRevision(request.QueryParameter("to"))
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		namespace := request.PathParameter("namespace")
+		name := request.PathParameter(nameParameter)
+		result, err := controllerrevision.GetRevisionDiff(k8sClient, kind, namespace, name, from, to)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+		_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+	}
+}
+
+func (apiHandler *APIHandler) handleRevisionRollback(kind, nameParameter string) restful.RouteFunction {
+	return func(request *restful.Request, response *restful.Response) {
+		k8sClient, err := client.Client(request.Request)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		rolloutSpec := new(deployment.RolloutSpec)
+		if err := request.ReadEntity(rolloutSpec); err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		revision, err := parseRevision(rolloutSpec.Revision)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		namespace := request.PathParameter("namespace")
+		name := request.PathParameter(nameParameter)
+		if err := controllerrevision.Rollback(k8sClient, kind, namespace, name, revision); err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+		_ = response.WriteHeaderAndEntity(http.StatusOK, rolloutSpec)
+	}
+}
+
+func parseRevision(revision string) (int64, error) {
+	result, err := strconv.ParseInt(revision, 10, 64)
+	if err != nil || result <= 0 {
+		return 0, errors.NewBadRequest(fmt.Sprintf("invalid revision %q", revision))
+	}
+
+	return result, nil
+}
+
 func (apiHandler *APIHandler) handleDeploymentRestart(request *restful.Request, response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
 	if err != nil {
@@ -2005,7 +2881,12 @@ func (apiHandler *APIHandler) handleGetReplicationControllerList(request *restfu
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicationcontroller.GetReplicationControllerList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
@@ -2023,7 +2904,12 @@ func (apiHandler *APIHandler) handleGetReplicaSets(request *restful.Request, res
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicaset.GetReplicaSetList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
@@ -2061,7 +2947,12 @@ func (apiHandler *APIHandler) handleGetReplicaSetPods(request *restful.Request,
 
 	namespace := request.PathParameter("namespace")
 	replicaSet := request.PathParameter("replicaSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicaset.GetReplicaSetPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, replicaSet, namespace)
 	if err != nil {
@@ -2081,7 +2972,12 @@ func (apiHandler *APIHandler) handleGetReplicaSetServices(request *restful.Reque
 
 	namespace := request.PathParameter("namespace")
 	replicaSet := request.PathParameter("replicaSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicaset.GetReplicaSetServices(k8sClient, dataSelect, namespace, replicaSet)
 	if err != nil {
@@ -2101,7 +2997,12 @@ func (apiHandler *APIHandler) handleGetReplicaSetEvents(request *restful.Request
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("replicaSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -2122,7 +3023,12 @@ func (apiHandler *APIHandler) handleGetPodEvents(request *restful.Request, respo
 	klog.V(4).Info("Getting events related to a pod in namespace")
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("pod")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := pod.GetEventsForPod(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -2132,33 +3038,89 @@ func (apiHandler *APIHandler) handleGetPodEvents(request *restful.Request, respo
 	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-// Handles execute shell API call
-func (apiHandler *APIHandler) handleExecShell(request *restful.Request, response *restful.Response) {
-	sessionID, err := genTerminalSessionId()
+func (apiHandler *APIHandler) handleGetTerminalRecordingList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	k8sClient, err := client.Client(request.Request)
+	result, err := recording.GetRecordingList(recording.NewAccessFilter(k8sClient))
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
 
-	cfg, err := client.Config(request.Request)
+func (apiHandler *APIHandler) handleGetTerminalRecording(request *restful.Request, response *restful.Response) {
+	result, data, err := getTerminalRecording(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	terminalSessions.Set(sessionID, TerminalSession{
-		id:       sessionID,
-		bound:    make(chan error),
-		sizeChan: make(chan remotecommand.TerminalSize),
-	})
-	go WaitForTerminal(k8sClient, cfg, request, sessionID)
-	_ = response.WriteHeaderAndEntity(http.StatusOK, TerminalResponse{ID: sessionID})
+	_ = data.Close()
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetTerminalRecordingCast(request *restful.Request, response *restful.Response) {
+	_, data, err := getTerminalRecording(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	defer data.Close()
+	response.AddHeader(restful.HEADER_ContentType, "application/x-asciicast")
+	if _, err = io.Copy(response, data); err != nil {
+		klog.ErrorS(err, "Could not send terminal recording")
+	}
+}
+
+// getTerminalRecording returns the recording, unless the user is not allowed to replay it. Such recording is reported
+// as not found, so its existence is not revealed.
+func getTerminalRecording(request *restful.Request) (*recording.Recording, io.ReadCloser, error) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id := request.PathParameter("recording")
+	result, data, err := recording.GetRecording(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !recording.NewAccessFilter(k8sClient)(*result) {
+		_ = data.Close()
+		return nil, nil, errors.NewNotFound(fmt.Sprintf("terminal recording %s not found", id))
+	}
+
+	return result, data, nil
+}
+
+// Handles debug container API call
+func (apiHandler *APIHandler) handleDebugContainer(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(pod.DebugContainerSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := pod.CreateDebugContainer(k8sClient, request.PathParameter("namespace"), request.PathParameter("pod"),
+		spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
 func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, response *restful.Response) {
@@ -2169,7 +3131,12 @@ func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, res
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := deployment.GetDeploymentList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
@@ -2206,7 +3173,12 @@ func (apiHandler *APIHandler) handleGetDeploymentEvents(request *restful.Request
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("deployment")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2224,7 +3196,12 @@ func (apiHandler *APIHandler) handleGetDeploymentOldReplicaSets(request *restful
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("deployment")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := deployment.GetDeploymentOldReplicaSets(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -2243,7 +3220,12 @@ func (apiHandler *APIHandler) handleGetDeploymentNewReplicaSet(request *restful.
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("deployment")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := deployment.GetDeploymentNewReplicaSet(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -2261,7 +3243,12 @@ func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics // download standard metrics - cpu, and memory - by default
 	result, err := pod.GetPodList(k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect)
 	if err != nil {
@@ -2368,12 +3355,111 @@ func (apiHandler *APIHandler) handlePutResource(
 		return
 	}
 
-	if err = verber.Update(raw); err != nil {
+	applyOptions, err := parseApplyOptions(request)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	response.WriteHeader(http.StatusNoContent)
+	if applyOptions == nil {
+		if err = verber.Update(raw); err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		response.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	result, err := verber.Apply(raw, *applyOptions)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	if len(result.Conflicts) > 0 && !applyOptions.DryRun {
+		_ = response.WriteHeaderAndEntity(http.StatusConflict, result)
+		return
+	}
+
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handlePatchMetadata(patch func(verber client.ResourceVerber, kind, namespace,
+	name string, spec *metadata.MetadataPatchSpec) (*metadata.Metadata, error)) restful.RouteFunction {
+	return func(request *restful.Request, response *restful.Response) {
+		verber, err := client.VerberClient(request.Request)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		spec := new(metadata.MetadataPatchSpec)
+		if err := request.ReadEntity(spec); err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		kind := request.PathParameter("kind")
+		namespace := request.PathParameters()["namespace"]
+		name := request.PathParameter("name")
+		result, err := patch(verber, kind, namespace, name, spec)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+		_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+	}
+}
+
+func (apiHandler *APIHandler) handleDiffResource(request *restful.Request, response *restful.Response) {
+	verber, err := client.VerberClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	raw := &unstructured.Unstructured{}
+	bytes, err := io.ReadAll(request.Request.Body)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	if err = raw.UnmarshalJSON(bytes); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	applyOptions, err := parseApplyOptions(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := verber.Diff(raw, applyOptions)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// parseApplyOptions returns server-side apply options or nil if the server-side apply was not requested.
+func parseApplyOptions(request *restful.Request) (*client.ApplyOptions, error) {
+	fieldManager := request.QueryParameter("fieldManager")
+	force := request.QueryParameter("force") == "true"
+	dryRun := request.QueryParameter("dryRun")
+
+	if request.QueryParameter("serverSideApply") != "true" {
+		if len(fieldManager) > 0 || force || len(dryRun) > 0 {
+			return nil, errors.NewBadRequest("fieldManager, force and dryRun require serverSideApply=true")
+		}
+		return nil, nil
+	}
+
+	return client.NewApplyOptions(fieldManager, force, dryRun)
 }
 
 func (apiHandler *APIHandler) handleDeleteResource(
@@ -2407,7 +3493,12 @@ func (apiHandler *APIHandler) handleGetReplicationControllerPods(request *restfu
 
 	namespace := request.PathParameter("namespace")
 	rc := request.PathParameter("replicationController")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicationcontroller.GetReplicationControllerPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, rc, namespace)
 	if err != nil {
@@ -2443,7 +3534,12 @@ func (apiHandler *APIHandler) handleGetNamespaces(request *restful.Request, resp
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := ns.GetNamespaceList(k8sClient, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2476,7 +3572,12 @@ func (apiHandler *APIHandler) handleGetNamespaceEvents(request *restful.Request,
 	}
 
 	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := event.GetNamespaceEvents(k8sClient, dataSelect, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2485,6 +3586,30 @@ func (apiHandler *APIHandler) handleGetNamespaceEvents(request *restful.Request,
 	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleGetEventTimeline(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	filter, err := event.NewTimelineFilter(request.QueryParameter("type"), request.QueryParameter("reason"),
+		request.QueryParameter("since"), request.QueryParameter("from"), request.QueryParameter("to"))
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	kind := resourcekind.ResourceKind(request.PathParameter("kind"))
+	result, err := event.GetEventTimeline(k8sClient, kind, request.PathParameter("namespace"),
+		request.PathParameter("name"), filter)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleGetEventList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
 	if err != nil {
@@ -2492,7 +3617,12 @@ func (apiHandler *APIHandler) handleGetEventList(request *restful.Request, respo
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	namespace := parseNamespacePathParameter(request)
 	result, err := event.GetEventList(k8sClient, namespace, dataSelect)
 	if err != nil {
@@ -2546,7 +3676,12 @@ func (apiHandler *APIHandler) handleGetSecretList(request *restful.Request, resp
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	namespace := parseNamespacePathParameter(request)
 	result, err := secret.GetSecretList(k8sClient, namespace, dataSelect)
 	if err != nil {
@@ -2564,7 +3699,12 @@ func (apiHandler *APIHandler) handleGetConfigMapList(request *restful.Request, r
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := configmap.GetConfigMapList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2597,7 +3737,12 @@ func (apiHandler *APIHandler) handleGetPersistentVolumeList(request *restful.Req
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := persistentvolume.GetPersistentVolumeList(k8sClient, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2630,7 +3775,12 @@ func (apiHandler *APIHandler) handleGetPersistentVolumeClaimList(request *restfu
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := persistentvolumeclaim.GetPersistentVolumeClaimList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2673,6 +3823,95 @@ func (apiHandler *APIHandler) handleGetPodContainers(request *restful.Request, r
 	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleGetContainerFiles(request *restful.Request, response *restful.Response) {
+	exec, err := containerFileExecutor(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := container.ListFiles(request.Request.Context(), exec, request.QueryParameter("path"))
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDownloadContainerFile(request *restful.Request, response *restful.Response) {
+	exec, err := containerFileExecutor(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := container.DownloadFile(request.Request.Context(), exec, request.QueryParameter("path"),
+		args.FileTransferSizeLimit())
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	handleFileDownload(response, result)
+}
+
+func (apiHandler *APIHandler) handleUploadContainerFiles(request *restful.Request, response *restful.Response) {
+	exec, err := containerFileExecutor(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	ctx := request.Request.Context()
+	path := request.QueryParameter("path")
+	size := request.Request.ContentLength
+	if strings.HasPrefix(request.HeaderParameter(restful.HEADER_ContentType), mimeTar) {
+		err = container.UploadArchive(ctx, exec, path, request.Request.Body, size, args.FileTransferSizeLimit())
+	} else {
+		err = container.UploadFile(ctx, exec, path, request.Request.Body, size, args.FileTransferSizeLimit())
+	}
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusNoContent)
+}
+
+// containerFileExecutor checks that the user can exec into the pod, because files are copied using tar over exec.
+func containerFileExecutor(request *restful.Request) (container.Executor, error) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+	if !canExec(request, namespace, podName) {
+		return nil, errors.NewForbidden(errors.MsgForbiddenError,
+			fmt.Errorf("copying files of pod %s/%s is not allowed", namespace, podName))
+	}
+
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := client.Config(request.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.NewExecutor(k8sClient, cfg, namespace, podName, request.PathParameter("container")), nil
+}
+
+func canExec(request *restful.Request, namespace, podName string) bool {
+	return client.CanI(request.Request, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Name:        podName,
+				Verb:        "create",
+				Resource:    "pods",
+				Subresource: "exec",
+			},
+		},
+	})
+}
+
 func (apiHandler *APIHandler) handleGetReplicationControllerEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
 	if err != nil {
@@ -2682,7 +3921,12 @@ func (apiHandler *APIHandler) handleGetReplicationControllerEvents(request *rest
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("replicationController")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2701,7 +3945,12 @@ func (apiHandler *APIHandler) handleGetReplicationControllerServices(request *re
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("replicationController")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := replicationcontroller.GetReplicationControllerServices(k8sClient, dataSelect, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2718,7 +3967,12 @@ func (apiHandler *APIHandler) handleGetDaemonSetList(request *restful.Request, r
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := daemonset.GetDaemonSetList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
@@ -2755,7 +4009,12 @@ func (apiHandler *APIHandler) handleGetDaemonSetPods(request *restful.Request, r
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("daemonSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := daemonset.GetDaemonSetPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, name, namespace)
 	if err != nil {
@@ -2774,7 +4033,12 @@ func (apiHandler *APIHandler) handleGetDaemonSetServices(request *restful.Reques
 
 	namespace := request.PathParameter("namespace")
 	daemonSet := request.PathParameter("daemonSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := daemonset.GetDaemonSetServices(k8sClient, dataSelect, namespace, daemonSet)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2783,7 +4047,72 @@ func (apiHandler *APIHandler) handleGetDaemonSetServices(request *restful.Reques
 	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetDaemonSetEvents(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetDaemonSetEvents(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("daemonSet")
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerList(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerList(k8sClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerListForResource(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	kind := request.PathParameter("kind")
+	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerListForResource(k8sClient, namespace, kind, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2791,9 +4120,8 @@ func (apiHandler *APIHandler) handleGetDaemonSetEvents(request *restful.Request,
 	}
 
 	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("daemonSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
+	name := request.PathParameter("horizontalpodautoscaler")
+	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerDetail(k8sClient, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -2801,8 +4129,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetEvents(request *restful.Request,
 	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerList(request *restful.Request,
-	response *restful.Response) {
+func (apiHandler *APIHandler) handleGetPodDisruptionBudgetList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2810,8 +4137,13 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerList(request *rest
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerList(k8sClient, namespace, dataSelect)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := poddisruptionbudget.GetPodDisruptionBudgetList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -2819,8 +4151,7 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerList(request *rest
 	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerListForResource(request *restful.Request,
-	response *restful.Response) {
+func (apiHandler *APIHandler) handleGetPodDisruptionBudgetDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2828,9 +4159,8 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerListForResource(re
 	}
 
 	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("name")
-	kind := request.PathParameter("kind")
-	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerListForResource(k8sClient, namespace, kind, name)
+	name := request.PathParameter("poddisruptionbudget")
+	result, err := poddisruptionbudget.GetPodDisruptionBudgetDetail(k8sClient, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -2838,7 +4168,7 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerListForResource(re
 	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerDetail(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetPodDisruptionBudgetPods(request *restful.Request, response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2846,8 +4176,16 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerDetail(request *re
 	}
 
 	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("horizontalpodautoscaler")
-	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerDetail(k8sClient, namespace, name)
+	name := request.PathParameter("poddisruptionbudget")
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := poddisruptionbudget.GetPodDisruptionBudgetPods(k8sClient, apiHandler.iManager.Metric().Client(),
+		namespace, name, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -2863,7 +4201,12 @@ func (apiHandler *APIHandler) handleGetJobList(request *restful.Request, respons
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := job.GetJobList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
@@ -2899,7 +4242,12 @@ func (apiHandler *APIHandler) handleGetJobPods(request *restful.Request, respons
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := job.GetJobPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, namespace, name)
 	if err != nil {
@@ -2918,7 +4266,12 @@ func (apiHandler *APIHandler) handleGetJobEvents(request *restful.Request, respo
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := job.GetJobEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2935,7 +4288,12 @@ func (apiHandler *APIHandler) handleGetCronJobList(request *restful.Request, res
 	}
 
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.NoMetrics
 	result, err := cronjob.GetCronJobList(k8sClient, namespace, dataSelect)
 	if err != nil {
@@ -2976,7 +4334,12 @@ func (apiHandler *APIHandler) handleGetCronJobJobs(request *restful.Request, res
 		active = false
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := cronjob.GetCronJobJobs(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, namespace, name, active)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2994,7 +4357,12 @@ func (apiHandler *APIHandler) handleGetCronJobEvents(request *restful.Request, r
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := cronjob.GetCronJobEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -3027,7 +4395,12 @@ func (apiHandler *APIHandler) handleGetStorageClassList(request *restful.Request
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := storageclass.GetStorageClassList(k8sClient, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -3061,7 +4434,12 @@ func (apiHandler *APIHandler) handleGetStorageClassPersistentVolumes(request *re
 	}
 
 	name := request.PathParameter("storageclass")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := persistentvolume.GetStorageClassPersistentVolumes(k8sClient,
 		name, dataSelect)
 	if err != nil {
@@ -3078,7 +4456,12 @@ func (apiHandler *APIHandler) handleGetIngressClassList(request *restful.Request
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := ingressclass.GetIngressClassList(k8sClient, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -3103,6 +4486,187 @@ func (apiHandler *APIHandler) handleGetIngressClass(request *restful.Request, re
 	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleGetServiceRouteList(request *restful.Request, response *restful.Response) {
+	dynamicClient, err := client.DynamicClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("service")
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := gatewayapi.GetServiceRouteList(dynamicClient, dataSelect, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetGatewayClassList(request *restful.Request, response *restful.Response) {
+	dynamicClient, err := client.DynamicClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := gatewayapi.GetGatewayClassList(dynamicClient, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetGatewayClassDetail(request *restful.Request, response *restful.Response) {
+	dynamicClient, err := client.DynamicClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := gatewayapi.GetGatewayClassDetail(dynamicClient, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetGatewayList(request *restful.Request, response *restful.Response) {
+	dynamicClient, err := client.DynamicClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	result, err := gatewayapi.GetGatewayList(dynamicClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetGatewayDetail(request *restful.Request, response *restful.Response) {
+	dynamicClient, err := client.DynamicClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := gatewayapi.GetGatewayDetail(dynamicClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetRouteList(kind resourcekind.ResourceKind) restful.RouteFunction {
+	return func(request *restful.Request, response *restful.Response) {
+		dynamicClient, err := client.DynamicClient(request.Request)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		dataSelect, err := parser.ParseDataSelectPathParameter(request)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		namespace := parseNamespacePathParameter(request)
+		result, err := gatewayapi.GetRouteList(dynamicClient, kind, namespace, dataSelect)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+		_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+	}
+}
+
+func (apiHandler *APIHandler) handleGetRouteDetail(kind resourcekind.ResourceKind) restful.RouteFunction {
+	return func(request *restful.Request, response *restful.Response) {
+		dynamicClient, err := client.DynamicClient(request.Request)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+
+		namespace := request.PathParameter("namespace")
+		name := request.PathParameter("name")
+		result, err := gatewayapi.GetRouteDetail(dynamicClient, kind, namespace, name)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+		_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+	}
+}
+
+func (apiHandler *APIHandler) handleGetReferenceGrantList(request *restful.Request, response *restful.Response) {
+	dynamicClient, err := client.DynamicClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	result, err := gatewayapi.GetReferenceGrantList(dynamicClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetReferenceGrantDetail(request *restful.Request, response *restful.Response) {
+	dynamicClient, err := client.DynamicClient(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := gatewayapi.GetReferenceGrantDetail(dynamicClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleGetPodPersistentVolumeClaims(request *restful.Request,
 	response *restful.Response) {
 	k8sClient, err := client.Client(request.Request)
@@ -3113,7 +4677,12 @@ func (apiHandler *APIHandler) handleGetPodPersistentVolumeClaims(request *restfu
 
 	name := request.PathParameter("pod")
 	namespace := request.PathParameter("namespace")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := persistentvolumeclaim.GetPodPersistentVolumeClaims(k8sClient,
 		namespace, name, dataSelect)
 	if err != nil {
@@ -3130,7 +4699,12 @@ func (apiHandler *APIHandler) handleGetCustomResourceDefinitionList(request *res
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := customresourcedefinition.GetCustomResourceDefinitionList(apiextensionsclient, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -3178,7 +4752,12 @@ func (apiHandler *APIHandler) handleGetCustomResourceObjectList(request *restful
 
 	crdName := request.PathParameter("crd")
 	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	result, err := customresourcedefinition.GetCustomResourceObjectList(apiextensionsclient, config, namespace, dataSelect, crdName)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -3224,7 +4803,12 @@ func (apiHandler *APIHandler) handleGetCustomResourceObjectEvents(request *restf
 
 	name := request.PathParameter("object")
 	namespace := request.PathParameter("namespace")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect, err := parser.ParseSubresourceDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := customresourcedefinition.GetEventsForCustomResourceObject(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -3291,7 +4875,54 @@ func (apiHandler *APIHandler) handleLogs(request *restful.Request, response *res
 		}
 	}
 
-	result, err := container.GetLogDetails(k8sClient, namespace, podID, containerID, logSelector, usePreviousLogs)
+	fieldFilter, err := logs.ParseFieldFilter(request.QueryParameter("fieldFilter"))
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	structured := request.QueryParameter("structured") == "true"
+	result, err := container.GetLogDetails(k8sClient, namespace, podID, containerID, logSelector, fieldFilter,
+		structured, usePreviousLogs)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	_ = response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleLogSearch(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	podID := request.PathParameter("pod")
+	containerID := request.PathParameter("container")
+	usePreviousLogs := request.QueryParameter("previous") == "true"
+	logFilePosition := request.QueryParameter("logFilePosition")
+
+	contextLines, err := strconv.Atoi(request.QueryParameter("contextLines"))
+	if err != nil {
+		contextLines = 0
+	}
+
+	query, err := logs.NewSearchQuery(
+		request.QueryParameter("query"),
+		request.QueryParameter("regex") == "true",
+		request.QueryParameter("caseSensitive") == "true",
+		request.QueryParameter("sinceTime"),
+		request.QueryParameter("untilTime"),
+		contextLines,
+	)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := container.SearchLogs(k8sClient, namespace, podID, containerID, query, logFilePosition, usePreviousLogs)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -26,6 +27,8 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
 )
 
 func TestCreateHTTPAPIHandler(t *testing.T) {
@@ -131,3 +134,34 @@ func TestFormatRequestLog(t *testing.T) {
 		}
 	}
 }
+
+func TestParseApplyOptions(t *testing.T) {
+	cases := []struct {
+		query       string
+		expected    *client.ApplyOptions
+		expectedErr bool
+	}{
+		{"", nil, false},
+		{"serverSideApply=true", &client.ApplyOptions{FieldManager: client.DefaultFieldManager}, false},
+		{"serverSideApply=true&fieldManager=argocd&force=true&dryRun=All",
+			&client.ApplyOptions{FieldManager: "argocd", Force: true, DryRun: true}, false},
+		{"serverSideApply=true&dryRun=true", nil, true},
+		{"force=true", nil, true},
+	}
+
+	for _, c := range cases {
+		req, err := http.NewRequest("PUT", "/api/v1/_raw/deployment/name/test?"+c.query, nil)
+		if err != nil {
+			t.Fatal("Cannot mockup request")
+		}
+
+		actual, err := parseApplyOptions(restful.NewRequest(req))
+		if c.expectedErr != errors.IsBadRequest(err) {
+			t.Errorf("parseApplyOptions(%s) returned error %v", c.query, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("parseApplyOptions(%s) == %#v, expected %#v", c.query, actual, c.expected)
+		}
+	}
+}
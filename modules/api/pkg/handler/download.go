@@ -16,12 +16,21 @@ package handler
 
 import (
 	"io"
+	"mime"
 
 	restful "github.com/emicklei/go-restful/v3"
+	"k8s.io/klog/v2"
 
+	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/api/pkg/resource/container"
 	"k8s.io/dashboard/errors"
 )
 
+const (
+	mimeOctetStream = "application/octet-stream"
+	mimeTar         = "application/x-tar"
+)
+
 func handleDownload(response *restful.Response, result io.ReadCloser) {
 	response.AddHeader(restful.HEADER_ContentType, "text/plain")
 	defer result.Close()
@@ -30,3 +39,20 @@ func handleDownload(response *restful.Response, result io.ReadCloser) {
 		errors.HandleInternalError(response, err)
 	}
 }
+
+// handleFileDownload streams the file copied from the container as an attachment. Errors that happen after the
+// response is started can only be logged and the client gets a truncated file.
+func handleFileDownload(response *restful.Response, result *container.Download) {
+	defer result.Close()
+
+	contentType := mimeOctetStream
+	if result.Archive {
+		contentType = mimeTar
+	}
+
+	response.AddHeader(restful.HEADER_ContentType, contentType)
+	response.AddHeader("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": result.Name}))
+	if _, err := io.Copy(response, result); err != nil {
+		klog.V(args.LogLevelVerbose).InfoS("Download failed", "file", result.Name, "error", err)
+	}
+}
@@ -64,14 +64,19 @@ func formatRequestLog(request *restful.Request) string {
 		uri = request.Request.URL.RequestURI()
 	}
 
-	byteArr, err := io.ReadAll(request.Request.Body)
-	if err == nil {
-		content = string(byteArr)
+	// Uploaded files are streamed to the handler, so they cannot be read into memory here
+	if isBinaryContent(request) {
+		content = "{ binary content }"
+	} else {
+		byteArr, err := io.ReadAll(request.Request.Body)
+		if err == nil {
+			content = string(byteArr)
+		}
+
+		// Restore request body so we can read it again in regular request handlers
+		request.Request.Body = io.NopCloser(bytes.NewReader(byteArr))
 	}
 
-	// Restore request body so we can read it again in regular request handlers
-	request.Request.Body = io.NopCloser(bytes.NewReader(byteArr))
-
 	// Hide sensitive url content for log level lower than debug
 	if args.APILogLevel() < args.LogLevelDebug && checkSensitiveURL(&uri) {
 		content = "{ content hidden }"
@@ -87,6 +92,11 @@ func formatRequestLog(request *restful.Request) string {
 	)
 }
 
+func isBinaryContent(request *restful.Request) bool {
+	contentType := request.HeaderParameter(restful.HEADER_ContentType)
+	return strings.HasPrefix(contentType, mimeOctetStream) || strings.HasPrefix(contentType, mimeTar)
+}
+
 // formatResponseLog formats response log string.
 func formatResponseLog(response *restful.Response, request *restful.Request) string {
 	return fmt.Sprintf(
@@ -0,0 +1,116 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/resource/container"
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
+)
+
+const (
+	// logStreamEventLines is the name of server-sent event that carries a batch of container.StreamedLogLine.
+	logStreamEventLines = "logs"
+	// logStreamEventError is the name of server-sent event sent right before the stream is closed due to an error.
+	logStreamEventError = "error"
+)
+
+// installLogStream registers routes that follow logs of all containers of a resource as server-sent events.
+func installLogStream(apiHandler APIHandler, wsContainer *restful.Container) {
+	logStreamWs := new(restful.WebService)
+	InstallFilters(logStreamWs)
+
+	logStreamWs.Path("/api/v1/logstream").
+		// docs
+		Doc("follows merged logs as server-sent events").
+		Produces(mimeEventStream)
+	wsContainer.Add(logStreamWs)
+
+	logStreamWs.Route(
+		logStreamWs.GET("/{namespace}/{resourceName}/{resourceType}").To(apiHandler.handleLogStream).
+			// docs
+			Doc("follows logs of all containers of all pods of the resource, interleaved by timestamp").
+			Param(logStreamWs.PathParameter("namespace", "namespace of the resource")).
+			Param(logStreamWs.PathParameter("resourceName", "name of the resource")).
+			Param(logStreamWs.PathParameter("resourceType", "type of the resource, one of pod, deployment, replicaset, statefulset, daemonset or job")).
+			Param(logStreamWs.QueryParameter("tailLines", "number of lines loaded from every container when it is added to the stream")).
+			Writes([]container.StreamedLogLine{}).
+			Returns(http.StatusOK, "OK", []container.StreamedLogLine{}))
+}
+
+func (apiHandler *APIHandler) handleLogStream(request *restful.Request, response *restful.Response) {
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	resourceName := request.PathParameter("resourceName")
+	resourceType := request.PathParameter("resourceType")
+
+	opts := container.StreamOptions{TailLines: container.DefaultStreamTailLines}
+	if tailLines, err := strconv.ParseInt(request.QueryParameter("tailLines"), 10, 64); err == nil && tailLines >= 0 {
+		opts.TailLines = tailLines
+	}
+
+	// Resource is resolved before the response is started, so errors are reported with the proper status code
+	ctx := request.Request.Context()
+	listOptions, err := container.PodListOptions(ctx, k8sClient, namespace, resourceName, resourceType)
+	if errors.IsNotFound(err) {
+		response.AddHeader("Content-Type", "text/plain")
+		_ = response.WriteError(http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	startServerSentEvents(response)
+
+	batches := make(chan []container.StreamedLogLine)
+	result := make(chan error, 1)
+	go func() {
+		result <- container.StreamLogs(ctx, k8sClient, namespace, listOptions, opts, batches)
+	}()
+
+	heartbeat := time.NewTicker(serverSentEventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case batch, ok := <-batches:
+			if !ok {
+				if err := <-result; err != nil {
+					klog.V(4).InfoS("Log stream closed", "resource", resourceName, "error", err)
+					writeServerSentEvent(response, logStreamEventError, err.Error())
+				}
+				return
+			}
+
+			writeServerSentEvent(response, logStreamEventLines, batch)
+		case <-heartbeat.C:
+			writeServerSentHeartbeat(response)
+		}
+	}
+}
@@ -15,12 +15,14 @@
 package parser
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/emicklei/go-restful/v3"
 	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
 	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
 )
 
 func parsePaginationPathParameter(request *restful.Request) *dataselect.PaginationQuery {
@@ -38,8 +40,30 @@ func parsePaginationPathParameter(request *restful.Request) *dataselect.Paginati
 	return dataselect.NewPaginationQuery(int(itemsPerPage), int(page-1))
 }
 
-func parseFilterPathParameter(request *restful.Request) *dataselect.FilterQuery {
-	return dataselect.NewFilterQuery(strings.Split(request.QueryParameter("filterBy"), ","))
+// Parses query parameters of the request and returns a FilterQuery object. Returns bad request error
+// if filter expression is not valid.
+func parseFilterPathParameter(request *restful.Request) (*dataselect.FilterQuery, error) {
+	return dataselect.ParseFilterQuery(request.QueryParameter("filterBy"))
+}
+
+// Parses query parameters of the request and returns a SelectorQuery object. Returns bad request error
+// if any of the selectors is not valid.
+func parseSelectorPathParameter(request *restful.Request) (*dataselect.SelectorQuery, error) {
+	return dataselect.NewSelectorQuery(request.QueryParameter("labelSelector"), request.QueryParameter("fieldSelector"))
+}
+
+// Parses query parameters of the request and returns a ChunkQuery object. Returns bad request error
+// if the limit is not valid.
+func parseChunkPathParameter(request *restful.Request) (*dataselect.ChunkQuery, error) {
+	var limit int64
+	if rawLimit := request.QueryParameter("limit"); len(rawLimit) > 0 {
+		var err error
+		if limit, err = strconv.ParseInt(rawLimit, 10, 64); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid limit: %s", rawLimit))
+		}
+	}
+
+	return dataselect.NewChunkQuery(limit, request.QueryParameter("continue"))
 }
 
 // Parses query parameters of the request and returns a SortQuery object
@@ -71,11 +95,42 @@ func parseMetricPathParameter(request *restful.Request) *dataselect.MetricQuery
 
 }
 
-// ParseDataSelectPathParameter parses query parameters of the request and returns a DataSelectQuery object
-func ParseDataSelectPathParameter(request *restful.Request) *dataselect.DataSelectQuery {
+// ParseSubresourceDataSelectPathParameter parses query parameters of the request listing resources related to
+// another resource, i.e. pods of a deployment. Such lists are found by the relation, so label and field selectors
+// can not be passed to the apiserver. Returns bad request error if they are used.
+func ParseSubresourceDataSelectPathParameter(request *restful.Request) (*dataselect.DataSelectQuery, error) {
+	for _, param := range []string{"labelSelector", "fieldSelector"} {
+		if len(request.QueryParameter(param)) > 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("%s is not supported by this list", param))
+		}
+	}
+
+	return ParseDataSelectPathParameter(request)
+}
+
+// ParseDataSelectPathParameter parses query parameters of the request and returns a DataSelectQuery object.
+// Returns bad request error if filter expression, selectors or limit are not valid.
+func ParseDataSelectPathParameter(request *restful.Request) (*dataselect.DataSelectQuery, error) {
 	paginationQuery := parsePaginationPathParameter(request)
 	sortQuery := parseSortPathParameter(request)
-	filterQuery := parseFilterPathParameter(request)
+	filterQuery, err := parseFilterPathParameter(request)
+	if err != nil {
+		return nil, err
+	}
+
+	selectorQuery, err := parseSelectorPathParameter(request)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkQuery, err := parseChunkPathParameter(request)
+	if err != nil {
+		return nil, err
+	}
+
 	metricQuery := parseMetricPathParameter(request)
-	return dataselect.NewDataSelectQuery(paginationQuery, sortQuery, filterQuery, metricQuery)
+	dataSelectQuery := dataselect.NewDataSelectQuery(paginationQuery, sortQuery, filterQuery, metricQuery)
+	dataSelectQuery.SelectorQuery = selectorQuery
+	dataSelectQuery.ChunkQuery = chunkQuery
+	return dataSelectQuery, nil
 }
@@ -0,0 +1,48 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"k8s.io/dashboard/errors"
+)
+
+func TestParseSubresourceDataSelectPathParameter(t *testing.T) {
+	cases := []struct {
+		query       string
+		expectedErr bool
+	}{
+		{"", false},
+		{"sortBy=d,name&itemsPerPage=10&page=1", false},
+		{"labelSelector=app%3Dweb", true},
+		{"fieldSelector=status.phase%3DRunning", true},
+	}
+
+	for _, c := range cases {
+		req, err := http.NewRequest("GET", "/api/v1/deployment/default/name/pod?"+c.query, nil)
+		if err != nil {
+			t.Fatal("Cannot mockup request")
+		}
+
+		_, err = ParseSubresourceDataSelectPathParameter(restful.NewRequest(req))
+		if c.expectedErr != errors.IsBadRequest(err) {
+			t.Errorf("ParseSubresourceDataSelectPathParameter(%s) returned error %v", c.query, err)
+		}
+	}
+}
@@ -0,0 +1,564 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"gopkg.in/igm/sockjs-go.v2/sockjs"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
+)
+
+const (
+	// portForwardBindTimeout is the time that client has to open the SockJS connection after the session is created.
+	portForwardBindTimeout = 10 * time.Second
+	// portForwardIdleTimeout is the time after which port forwarding session without any traffic is closed.
+	portForwardIdleTimeout = 10 * time.Minute
+	// portForwardBufferSize is the maximum number of bytes sent to the client in a single message.
+	portForwardBufferSize = 32 * 1024
+)
+
+// PortForwardResponse is sent by handlePortForward. The ID is a random session id that, together with the token
+// of the original REST request, binds the request and the SockJS connection.
+type PortForwardResponse struct {
+	ID string `json:"id"`
+}
+
+// PortForwardMessage is the messaging protocol between the client and PortForwardSession.
+//
+// OP      DIRECTION  FIELD(S) USED  DESCRIPTION
+// ---------------------------------------------------------------------
+// bind    fe->be     SessionID      Id sent back from PortForwardResponse
+// bind    fe->be     Token          Bearer token used to create the session
+// data    fe->be     Data           Base64 encoded bytes to be written to the pod port
+// data    be->fe     Data           Base64 encoded bytes read from the pod port
+type PortForwardMessage struct {
+	Op, Data, SessionID, Token string
+}
+
+// PortForwardSession tunnels a single TCP stream to the pod port through a SockJS connection.
+type PortForwardSession struct {
+	id string
+	// tokenHash is the hash of the bearer token used to create the session. Only the connection that sends the same
+	// token can be bound, so the session can not be taken over by anyone who learns its id.
+	tokenHash [sha256.Size]byte
+	// bound is closed once the SockJS connection is bound to the session. It is never written to.
+	bound         chan struct{}
+	sockJSSession sockjs.Session
+}
+
+// PortForwardSessionMap stores a map of all PortForwardSession objects and a lock to avoid concurrent conflict
+type PortForwardSessionMap struct {
+	Sessions map[string]PortForwardSession
+	Lock     sync.RWMutex
+}
+
+// Get return a given PortForwardSession by sessionId
+func (sm *PortForwardSessionMap) Get(sessionId string) PortForwardSession {
+	sm.Lock.RLock()
+	defer sm.Lock.RUnlock()
+	return sm.Sessions[sessionId]
+}
+
+// Set store a PortForwardSession to PortForwardSessionMap
+func (sm *PortForwardSessionMap) Set(sessionId string, session PortForwardSession) {
+	sm.Lock.Lock()
+	defer sm.Lock.Unlock()
+	sm.Sessions[sessionId] = session
+}
+
+// Bind binds the SockJS connection to the session with given id. Only the first connection with the token used
+// to create the session can be bound to it, so the stream can not be taken over by another connection. Returns
+// false if the session does not exist, the token does not match or the session has already been bound.
+func (sm *PortForwardSessionMap) Bind(sessionId, token string, session sockjs.Session) bool {
+	sm.Lock.Lock()
+	defer sm.Lock.Unlock()
+	ses, ok := sm.Sessions[sessionId]
+	if !ok || ses.sockJSSession != nil {
+		return false
+	}
+
+	tokenHash := sha256.Sum256([]byte(token))
+	if subtle.ConstantTimeCompare(tokenHash[:], ses.tokenHash[:]) != 1 {
+		return false
+	}
+
+	ses.sockJSSession = session
+	sm.Sessions[sessionId] = ses
+	close(ses.bound)
+	return true
+}
+
+// Close shuts down the SockJS connection and sends the status code and reason to the client
+func (sm *PortForwardSessionMap) Close(sessionId string, status uint32, reason string) {
+	sm.Lock.Lock()
+	defer sm.Lock.Unlock()
+	ses := sm.Sessions[sessionId]
+	if ses.sockJSSession != nil {
+		if err := ses.sockJSSession.Close(status, reason); err != nil {
+			klog.Error(err)
+		}
+	}
+	delete(sm.Sessions, sessionId)
+}
+
+var portForwardSessions = PortForwardSessionMap{Sessions: make(map[string]PortForwardSession)}
+
+// handleSockJSSession is called by net/http for any new /api/sockjs connections. It binds the connection to the
+// port forwarding session with the id and token sent in the first message.
+func handleSockJSSession(session sockjs.Session) {
+	buf, err := session.Recv()
+	if err != nil {
+		klog.Errorf("handleSockJSSession: can't Recv: %v", err)
+		return
+	}
+
+	var msg PortForwardMessage
+	if err = json.Unmarshal([]byte(buf), &msg); err != nil {
+		klog.Errorf("handleSockJSSession: can't UnMarshal (%v): %s", err, buf)
+		return
+	}
+
+	if msg.Op != "bind" {
+		klog.V(args.LogLevelVerbose).Infof("handleSockJSSession: expected 'bind' message, got: %s", buf)
+		return
+	}
+
+	if !portForwardSessions.Bind(msg.SessionID, msg.Token, session) {
+		klog.V(args.LogLevelVerbose).Infof("handleSockJSSession: can't bind session '%s'", msg.SessionID)
+		_ = session.Close(2, "Session does not exist, does not match the token or has already been bound")
+	}
+}
+
+// CreateSockJSHandler is called from main for /api/sockjs
+func CreateSockJSHandler(path string) http.Handler {
+	return sockjs.NewHandler(path, sockjs.DefaultOptions, handleSockJSSession)
+}
+
+// genSessionId generates a random session ID string. The format is not really interesting.
+// This ID is used to identify the session when the client opens the SockJS connection.
+// Not the same as the SockJS session id! We can't use that as that is generated
+// on the client side and we don't have it yet at this point.
+func genSessionId() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	id := make([]byte, hex.EncodedLen(len(bytes)))
+	hex.Encode(id, bytes)
+	return string(id), nil
+}
+
+func (apiHandler *APIHandler) handlePortForward(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+	port, err := parsePortPathParameter(request)
+	if err != nil {
+		_ = response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	if !canPortForward(request, namespace, podName) {
+		errors.HandleInternalError(response, errors.NewForbidden(errors.MsgForbiddenError,
+			fmt.Errorf("port forwarding to pod %s/%s is not allowed", namespace, podName)))
+		return
+	}
+
+	sessionID, err := genSessionId()
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	cfg, err := client.Config(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	portForwardSessions.Set(sessionID, PortForwardSession{
+		id:        sessionID,
+		tokenHash: sha256.Sum256([]byte(client.GetBearerToken(request.Request))),
+		bound:     make(chan struct{}),
+	})
+	go WaitForPortForward(k8sClient, cfg, namespace, podName, port, sessionID)
+	_ = response.WriteHeaderAndEntity(http.StatusOK, PortForwardResponse{ID: sessionID})
+}
+
+// WaitForPortForward is called from handlePortForward as a goroutine. Waits for the SockJS connection
+// to be opened by the client and then tunnels the data until either side closes the connection
+// or the session is idle for longer than portForwardIdleTimeout.
+func WaitForPortForward(k8sClient kubernetes.Interface, cfg *rest.Config, namespace, podName string, port int,
+	sessionId string) {
+	select {
+	case <-portForwardSessions.Get(sessionId).bound:
+		if err := forwardPort(k8sClient, cfg, namespace, podName, port, portForwardSessions.Get(sessionId)); err != nil {
+			klog.V(args.LogLevelVerbose).Infof("Port forwarding to %s/%s:%d failed: %v", namespace, podName, port, err)
+			portForwardSessions.Close(sessionId, 2, err.Error())
+			return
+		}
+
+		portForwardSessions.Close(sessionId, 1, "Connection closed")
+	case <-time.After(portForwardBindTimeout):
+		// Delete session when sockjs connection was timeout
+		portForwardSessions.Close(sessionId, 2, "Session has not been bound in time")
+	}
+}
+
+// forwardPort copies data between the SockJS session and the pod port.
+func forwardPort(k8sClient kubernetes.Interface, cfg *rest.Config, namespace, podName string, port int,
+	session PortForwardSession) error {
+	conn, err := dialPortForward(k8sClient, cfg, namespace, podName, port)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var lastActivity atomic.Int64
+	lastActivity.Store(time.Now().UnixNano())
+	done := make(chan error, 3)
+
+	go func() {
+		if err := conn.remoteError(); err != nil {
+			done <- err
+		}
+	}()
+
+	// pod -> client
+	go func() {
+		buf := make([]byte, portForwardBufferSize)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				lastActivity.Store(time.Now().UnixNano())
+				msg, _ := json.Marshal(PortForwardMessage{Op: "data", Data: base64.StdEncoding.EncodeToString(buf[:n])})
+				if sendErr := session.sockJSSession.Send(string(msg)); sendErr != nil {
+					done <- sendErr
+					return
+				}
+			}
+
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				done <- err
+				return
+			}
+		}
+	}()
+
+	// client -> pod
+	go func() {
+		for {
+			m, err := session.sockJSSession.Recv()
+			if err != nil {
+				// Client has closed the connection.
+				done <- nil
+				return
+			}
+
+			var msg PortForwardMessage
+			if err = json.Unmarshal([]byte(m), &msg); err != nil {
+				done <- err
+				return
+			}
+
+			if msg.Op != "data" {
+				done <- fmt.Errorf("unknown message type '%s'", msg.Op)
+				return
+			}
+
+			data, err := base64.StdEncoding.DecodeString(msg.Data)
+			if err != nil {
+				done <- err
+				return
+			}
+
+			lastActivity.Store(time.Now().UnixNano())
+			if _, err = conn.Write(data); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(portForwardIdleTimeout / 10)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err = <-done:
+			return err
+		case <-ticker.C:
+			if time.Since(time.Unix(0, lastActivity.Load())) > portForwardIdleTimeout {
+				return fmt.Errorf("connection has been idle for more than %s", portForwardIdleTimeout)
+			}
+		}
+	}
+}
+
+// portForwardConn is a net.Conn backed by the data stream of a port forwarding connection to the pod.
+type portForwardConn struct {
+	httpstream.Stream
+
+	connection  httpstream.Connection
+	errorStream httpstream.Stream
+	port        int
+}
+
+// remoteError blocks until the error stream is closed and returns the error reported by the kubelet if any.
+func (in *portForwardConn) remoteError() error {
+	message, err := io.ReadAll(in.errorStream)
+	if err != nil {
+		return err
+	}
+
+	if len(message) > 0 {
+		return fmt.Errorf("error forwarding port %d: %s", in.port, string(message))
+	}
+
+	return nil
+}
+
+// Close closes both the data stream and the underlying connection.
+func (in *portForwardConn) Close() error {
+	_ = in.Stream.Close()
+	return in.connection.Close()
+}
+
+func (in *portForwardConn) LocalAddr() net.Addr {
+	return portForwardAddr(strconv.Itoa(in.port))
+}
+
+func (in *portForwardConn) RemoteAddr() net.Addr {
+	return portForwardAddr(strconv.Itoa(in.port))
+}
+
+func (in *portForwardConn) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (in *portForwardConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (in *portForwardConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+// portForwardAddr implements net.Addr for portForwardConn.
+type portForwardAddr string
+
+func (in portForwardAddr) Network() string {
+	return portforward.PortForwardProtocolV1Name
+}
+
+func (in portForwardAddr) String() string {
+	return string(in)
+}
+
+// dialPortForward opens the port forwarding connection to the pod using the credentials from the config,
+// so RBAC for pods/portforward is enforced by the API server the same way as for exec.
+func dialPortForward(k8sClient kubernetes.Interface, cfg *rest.Config, namespace, podName string, port int) (
+	*portForwardConn, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	req := k8sClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("portforward")
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+	connection, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set(v1.StreamType, v1.StreamTypeError)
+	headers.Set(v1.PortHeader, strconv.Itoa(port))
+	headers.Set(v1.PortForwardRequestIDHeader, "0")
+	errorStream, err := connection.CreateStream(headers)
+	if err != nil {
+		_ = connection.Close()
+		return nil, err
+	}
+	// Error stream is used only to read errors from the kubelet.
+	_ = errorStream.Close()
+
+	headers.Set(v1.StreamType, v1.StreamTypeData)
+	dataStream, err := connection.CreateStream(headers)
+	if err != nil {
+		_ = connection.Close()
+		return nil, err
+	}
+
+	return &portForwardConn{Stream: dataStream, connection: connection, errorStream: errorStream, port: port}, nil
+}
+
+// CreatePortForwardProxyHandler creates a handler that proxies plain HTTP requests to the pod port through
+// the port forwarding connection. It uses a separate container, because content encoding used by the main
+// API container would be applied on top of the encoding used by the proxied service.
+//
+// The proxy is read-only. Requests that modify data would have to pass the CSRF validation, and the proxied
+// service can not provide the CSRF token of the Dashboard. Use the SockJS port forwarding for anything else.
+func CreatePortForwardProxyHandler() *restful.Container {
+	wsContainer := restful.NewContainer()
+
+	proxyWs := new(restful.WebService)
+	InstallFilters(proxyWs)
+
+	proxyWs.Path("/api/v1/portforward").
+		// docs
+		Doc("proxies read-only HTTP requests to pod ports")
+	wsContainer.Add(proxyWs)
+
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		proxyWs.Route(
+			proxyWs.Method(method).Path("/{namespace}/{pod}/{port}/proxy/{path:*}").To(handlePortForwardProxy).
+				// docs
+				Doc("proxies HTTP request to the pod port through port forwarding").
+				Param(proxyWs.PathParameter("namespace", "namespace of the Pod")).
+				Param(proxyWs.PathParameter("pod", "name of the Pod")).
+				Param(proxyWs.PathParameter("port", "port of the Pod")).
+				Param(proxyWs.PathParameter("path", "path of the proxied request")))
+	}
+
+	return wsContainer
+}
+
+func handlePortForwardProxy(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+	port, err := parsePortPathParameter(request)
+	if err != nil {
+		_ = response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	if !canPortForward(request, namespace, podName) {
+		errors.HandleInternalError(response, errors.NewForbidden(errors.MsgForbiddenError,
+			fmt.Errorf("port forwarding to pod %s/%s is not allowed", namespace, podName)))
+		return
+	}
+
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	cfg, err := client.Config(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.Out.URL.Scheme = "http"
+			r.Out.URL.Host = net.JoinHostPort("localhost", strconv.Itoa(port))
+			r.Out.URL.Path = "/" + request.PathParameter("path")
+			r.Out.URL.RawPath = ""
+			r.Out.Host = r.Out.URL.Host
+			// Dashboard credentials must not be passed to the proxied service.
+			r.Out.Header.Del("Authorization")
+		},
+		Transport: &http.Transport{
+			DialContext: func(context.Context, string, string) (net.Conn, error) {
+				return dialPortForward(k8sClient, cfg, namespace, podName, port)
+			},
+			DisableKeepAlives: true,
+		},
+		ModifyResponse: isolateProxiedResponse,
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			klog.V(args.LogLevelVerbose).Infof("Proxying to %s/%s:%d failed: %v", namespace, podName, port, err)
+			errors.HandleInternalError(response, err)
+		},
+	}
+
+	proxy.ServeHTTP(response, request.Request)
+}
+
+// isolateProxiedResponse makes sure that content served by the pod can not access the Dashboard. Proxied pages
+// are served from the Dashboard origin, so they are sandboxed into a unique origin and can not set cookies.
+// Scripts are allowed, but without the same origin they can not read the auth token of the user.
+func isolateProxiedResponse(response *http.Response) error {
+	response.Header.Del("Set-Cookie")
+	response.Header.Set("Content-Security-Policy", "sandbox allow-scripts allow-forms allow-popups")
+	return nil
+}
+
+// canPortForward checks if the user is allowed to create port forwarding connection to the pod.
+func canPortForward(request *restful.Request, namespace, podName string) bool {
+	return client.CanI(request.Request, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Name:        podName,
+				Verb:        "create",
+				Resource:    "pods",
+				Subresource: "portforward",
+			},
+		},
+	})
+}
+
+func parsePortPathParameter(request *restful.Request) (int, error) {
+	port, err := strconv.Atoi(request.PathParameter("port"))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", request.PathParameter("port"))
+	}
+
+	return port, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"testing"
+
+	"gopkg.in/igm/sockjs-go.v2/sockjs"
+)
+
+type fakeSockJSSession struct {
+	sockjs.Session
+}
+
+func TestPortForwardSessionMapBind(t *testing.T) {
+	sessions := PortForwardSessionMap{Sessions: make(map[string]PortForwardSession)}
+	sessions.Set("id", PortForwardSession{id: "id", tokenHash: sha256.Sum256([]byte("token")),
+		bound: make(chan struct{})})
+
+	if sessions.Bind("id", "other", &fakeSockJSSession{}) {
+		t.Error("Bind() == true, expected session with different token to be rejected")
+	}
+
+	if !sessions.Bind("id", "token", &fakeSockJSSession{}) {
+		t.Fatal("Bind() == false, expected first bind to succeed")
+	}
+
+	select {
+	case <-sessions.Get("id").bound:
+	default:
+		t.Error("Expected bound channel to be closed after bind")
+	}
+
+	first := sessions.Get("id").sockJSSession
+	if sessions.Bind("id", "token", &fakeSockJSSession{}) {
+		t.Error("Bind() == true, expected bound session to be rejected")
+	}
+
+	if sessions.Get("id").sockJSSession != first {
+		t.Error("Expected bound session not to be replaced")
+	}
+
+	if sessions.Bind("unknown", "token", &fakeSockJSSession{}) {
+		t.Error("Bind() == true, expected unknown session to be rejected")
+	}
+}
+
+func TestIsolateProxiedResponse(t *testing.T) {
+	response := &http.Response{Header: http.Header{}}
+	response.Header.Add("Set-Cookie", "session=1")
+	response.Header.Set("Content-Security-Policy", "default-src *")
+
+	if err := isolateProxiedResponse(response); err != nil {
+		t.Fatalf("isolateProxiedResponse() returned error: %v", err)
+	}
+
+	if cookie := response.Header.Get("Set-Cookie"); cookie != "" {
+		t.Errorf("Expected Set-Cookie to be removed, got %q", cookie)
+	}
+
+	if csp := response.Header.Get("Content-Security-Policy"); csp != "sandbox allow-scripts allow-forms allow-popups" {
+		t.Errorf("Unexpected Content-Security-Policy %q", csp)
+	}
+}
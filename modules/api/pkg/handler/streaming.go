@@ -0,0 +1,75 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/integration"
+)
+
+const (
+	// serverSentEventsHeartbeatInterval is the interval of comments sent to keep idle streams open.
+	serverSentEventsHeartbeatInterval = 30 * time.Second
+
+	mimeEventStream = "text/event-stream"
+)
+
+// CreateStreamingHandler creates a handler for endpoints that stream data as server-sent events.
+// It uses a separate container, because content encoding used by the main API container buffers
+// the response and would break streaming.
+func CreateStreamingHandler(iManager integration.Manager) *restful.Container {
+	apiHandler := APIHandler{iManager: iManager}
+	wsContainer := restful.NewContainer()
+
+	installWatch(apiHandler, wsContainer)
+	installLogStream(apiHandler, wsContainer)
+
+	return wsContainer
+}
+
+// startServerSentEvents writes headers of the event stream response and flushes them to the client.
+func startServerSentEvents(response *restful.Response) {
+	response.AddHeader("Content-Type", mimeEventStream)
+	response.AddHeader("Cache-Control", "no-cache")
+	response.AddHeader("Connection", "keep-alive")
+	response.AddHeader("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+}
+
+// writeServerSentEvent writes a single named event in the text/event-stream format and flushes it to the client.
+func writeServerSentEvent(response *restful.Response, name string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		klog.ErrorS(err, "Could not marshal server-sent event", "event", name)
+		return
+	}
+
+	_, _ = fmt.Fprintf(response, "event: %s\ndata: %s\n\n", name, payload)
+	response.Flush()
+}
+
+// writeServerSentHeartbeat writes a comment that is ignored by the client, but keeps the connection open.
+func writeServerSentHeartbeat(response *restful.Response) {
+	_, _ = fmt.Fprint(response, ": heartbeat\n\n")
+	response.Flush()
+}
@@ -15,306 +15,271 @@
 package handler
 
 import (
-	"context"
-	"crypto/rand"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"sync"
-	"time"
+	"strconv"
 
 	"github.com/emicklei/go-restful/v3"
-	"gopkg.in/igm/sockjs-go.v2/sockjs"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/klog/v2"
 
 	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/api/pkg/recording"
+	"k8s.io/dashboard/api/pkg/resource/node"
+	"k8s.io/dashboard/api/pkg/resource/pod"
+	"k8s.io/dashboard/api/pkg/terminal"
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
 )
 
-const END_OF_TRANSMISSION = "\u0004"
-
-// PtyHandler is what remotecommand expects from a pty
-type PtyHandler interface {
-	io.Reader
-	io.Writer
-	remotecommand.TerminalSizeQueue
+// CreateTerminalHandler creates a handler for terminals served over WebSocket using the v5.channel.k8s.io protocol.
+// It uses a separate container, because the connection has to be hijacked from the response writer.
+func CreateTerminalHandler() *restful.Container {
+	wsContainer := restful.NewContainer()
+
+	terminalWs := new(restful.WebService)
+	InstallFilters(terminalWs)
+
+	terminalWs.Path("/api/v1/terminal").
+		// docs
+		Doc("connects terminals to containers over WebSocket using the " + terminal.ProtocolV5 + " protocol")
+	wsContainer.Add(terminalWs)
+
+	terminalWs.Route(
+		terminalWs.GET("/pod/{namespace}/{pod}/{container}").To(handleTerminalExec).
+			// docs
+			Doc("executes command in the container, it runs the interactive shell when no command is given").
+			Param(terminalWs.PathParameter("namespace", "namespace of the Pod")).
+			Param(terminalWs.PathParameter("pod", "name of the Pod")).
+			Param(terminalWs.PathParameter("container", "name of container in the Pod")).
+			Param(terminalWs.QueryParameter("command", "command and its arguments, repeated for every argument").
+				AllowMultiple(true)).
+			Param(terminalWs.QueryParameter("shell", "shell used when no command is given, one of bash, sh, powershell or cmd")).
+			Param(terminalWs.QueryParameter("tty", "whether the terminal is allocated, defaults to true when no command is given")).
+			Param(terminalWs.QueryParameter("stdin", "whether stdin is passed to the command, defaults to the value of tty")).
+			Returns(http.StatusSwitchingProtocols, "Switching Protocols", nil))
+	terminalWs.Route(
+		terminalWs.GET("/pod/{namespace}/{pod}/{container}/attach").To(handleTerminalAttach).
+			// docs
+			Doc("attaches to the interactive container in pod, i.e. an ephemeral debug container").
+			Param(terminalWs.PathParameter("namespace", "namespace of the Pod")).
+			Param(terminalWs.PathParameter("pod", "name of the Pod")).
+			Param(terminalWs.PathParameter("container", "name of container in the Pod")).
+			Returns(http.StatusSwitchingProtocols, "Switching Protocols", nil))
+	terminalWs.Route(
+		terminalWs.GET("/node/{name}").To(handleTerminalNodeShell).
+			// docs
+			Doc("runs privileged Pod on Node and attaches to it, the Pod is deleted when the connection is closed").
+			Param(terminalWs.PathParameter("name", "name of the Node")).
+			Param(terminalWs.QueryParameter("namespace", "namespace of the node shell Pod, defaults to 'default'")).
+			Param(terminalWs.QueryParameter("image", "image of the node shell container")).
+			Returns(http.StatusSwitchingProtocols, "Switching Protocols", nil))
+
+	return wsContainer
 }
 
-// TerminalSession implements PtyHandler (using a SockJS connection)
-type TerminalSession struct {
-	id            string
-	bound         chan error
-	sockJSSession sockjs.Session
-	sizeChan      chan remotecommand.TerminalSize
-}
+func handleTerminalExec(request *restful.Request, response *restful.Response) {
+	k8sClient, cfg, err := terminalClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
 
-// TerminalMessage is the messaging protocol between ShellController and TerminalSession.
-//
-// OP      DIRECTION  FIELD(S) USED  DESCRIPTION
-// ---------------------------------------------------------------------
-// bind    fe->be     SessionID      Id sent back from TerminalResponse
-// stdin   fe->be     Data           Keystrokes/paste buffer
-// resize  fe->be     Rows, Cols     New terminal size
-// stdout  be->fe     Data           Output from the process
-// toast   be->fe     Data           OOB message to be shown to the user
-type TerminalMessage struct {
-	Op, Data, SessionID string
-	Rows, Cols          uint16
-}
+	opts, err := terminalExecOptions(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
 
-// Next handles pty->process resize events
-// Called in a loop from remotecommand as long as the process is running
-func (t TerminalSession) Next() *remotecommand.TerminalSize {
-	size := <-t.sizeChan
-	if size.Height == 0 && size.Width == 0 {
-		return nil
+	shell := request.QueryParameter("shell")
+	command := opts.Command
+	if len(command) == 0 && len(shell) > 0 {
+		command = []string{shell}
 	}
-	return &size
-}
 
-// Read handles pty->process messages (stdin, resize)
-// Called in a loop from remotecommand as long as the process is running
-func (t TerminalSession) Read(p []byte) (int, error) {
-	m, err := t.sockJSSession.Recv()
+	stream, err := startTerminal(k8sClient, request, response, opts, command)
 	if err != nil {
-		// Send terminated signal to process to avoid resource leak
-		return copy(p, END_OF_TRANSMISSION), err
+		errors.HandleInternalError(response, err)
+		return
 	}
-
-	var msg TerminalMessage
-	if err := json.Unmarshal([]byte(m), &msg); err != nil {
-		return copy(p, END_OF_TRANSMISSION), err
+	if stream == nil {
+		return
 	}
 
-	switch msg.Op {
-	case "stdin":
-		return copy(p, msg.Data), nil
-	case "resize":
-		t.sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}
-		return 0, nil
-	default:
-		return copy(p, END_OF_TRANSMISSION), fmt.Errorf("unknown message type '%s'", msg.Op)
+	if len(opts.Command) == 0 {
+		err = terminal.ExecShell(k8sClient, cfg, opts, shell, stream)
+	} else {
+		err = terminal.Exec(k8sClient, cfg, opts, stream)
 	}
+	stream.Finish(err)
 }
 
-// Write handles process->pty stdout
-// Called from remotecommand whenever there is any output
-func (t TerminalSession) Write(p []byte) (int, error) {
-	msg, err := json.Marshal(TerminalMessage{
-		Op:   "stdout",
-		Data: string(p),
-	})
+func handleTerminalAttach(request *restful.Request, response *restful.Response) {
+	k8sClient, cfg, err := terminalClient(request)
 	if err != nil {
-		return 0, err
+		errors.HandleInternalError(response, err)
+		return
 	}
 
-	if err = t.sockJSSession.Send(string(msg)); err != nil {
-		return 0, err
+	opts := terminal.Options{
+		Namespace: request.PathParameter("namespace"),
+		Pod:       request.PathParameter("pod"),
+		Container: request.PathParameter("container"),
+		Stdin:     true,
+		TTY:       true,
 	}
-	return len(p), nil
-}
 
-// Toast can be used to send the user any OOB messages
-// hterm puts these in the center of the terminal
-func (t TerminalSession) Toast(p string) error {
-	msg, err := json.Marshal(TerminalMessage{
-		Op:   "toast",
-		Data: p,
-	})
+	stream, err := startTerminal(k8sClient, request, response, opts, nil)
 	if err != nil {
-		return err
+		errors.HandleInternalError(response, err)
+		return
 	}
-
-	if err = t.sockJSSession.Send(string(msg)); err != nil {
-		return err
+	if stream == nil {
+		return
 	}
-	return nil
-}
-
-// SessionMap stores a map of all TerminalSession objects and a lock to avoid concurrent conflict
-type SessionMap struct {
-	Sessions map[string]TerminalSession
-	Lock     sync.RWMutex
-}
 
-// Get return a given terminalSession by sessionId
-func (sm *SessionMap) Get(sessionId string) TerminalSession {
-	sm.Lock.RLock()
-	defer sm.Lock.RUnlock()
-	return sm.Sessions[sessionId]
+	stream.Finish(attachTerminal(k8sClient, cfg, opts, stream))
 }
 
-// Set store a TerminalSession to SessionMap
-func (sm *SessionMap) Set(sessionId string, session TerminalSession) {
-	sm.Lock.Lock()
-	defer sm.Lock.Unlock()
-	sm.Sessions[sessionId] = session
-}
-
-// Close shuts down the SockJS connection and sends the status code and reason to the client
-// Can happen if the process exits or if there is an error starting up the process
-// For now the status code is unused and reason is shown to the user (unless "")
-func (sm *SessionMap) Close(sessionId string, status uint32, reason string) {
-	sm.Lock.Lock()
-	defer sm.Lock.Unlock()
-	ses := sm.Sessions[sessionId]
-	err := ses.sockJSSession.Close(status, reason)
+func handleTerminalNodeShell(request *restful.Request, response *restful.Response) {
+	k8sClient, cfg, err := terminalClient(request)
 	if err != nil {
-		klog.Error(err)
+		errors.HandleInternalError(response, err)
+		return
 	}
-	close(ses.sizeChan)
-	delete(sm.Sessions, sessionId)
-}
-
-var terminalSessions = SessionMap{Sessions: make(map[string]TerminalSession)}
 
-// handleTerminalSession is Called by net/http for any new /api/sockjs connections
-func handleTerminalSession(session sockjs.Session) {
-	var (
-		buf             string
-		err             error
-		msg             TerminalMessage
-		terminalSession TerminalSession
-	)
+	name := request.PathParameter("name")
+	namespace := request.QueryParameter("namespace")
+	if len(namespace) == 0 {
+		namespace = v1.NamespaceDefault
+	}
 
-	if buf, err = session.Recv(); err != nil {
-		klog.Errorf("handleTerminalSession: can't Recv: %v", err)
+	if err := node.CheckNodeShellAccess(k8sClient, namespace, name); err != nil {
+		errors.HandleInternalError(response, err)
 		return
 	}
 
-	if err = json.Unmarshal([]byte(buf), &msg); err != nil {
-		klog.Errorf("handleTerminalSession: can't UnMarshal (%v): %s", err, buf)
+	shellPod, err := node.CreateNodeShellPod(k8sClient, namespace, name, request.QueryParameter("image"))
+	if err != nil {
+		errors.HandleInternalError(response, err)
 		return
 	}
 
-	if msg.Op != "bind" {
-		klog.V(args.LogLevelVerbose).Infof("handleTerminalSession: expected 'bind' message, got: %s", buf)
-		return
+	defer func() {
+		if err := node.DeleteNodeShellPod(k8sClient, namespace, shellPod.Name); err != nil {
+			klog.ErrorS(err, "Could not delete node shell pod", "namespace", namespace, "pod", shellPod.Name)
+		}
+	}()
+
+	opts := terminal.Options{
+		Namespace: namespace,
+		Pod:       shellPod.Name,
+		Container: node.NodeShellContainerName,
+		Stdin:     true,
+		TTY:       true,
 	}
 
-	if terminalSession = terminalSessions.Get(msg.SessionID); terminalSession.id == "" {
-		klog.V(args.LogLevelVerbose).Infof("handleTerminalSession: can't find session '%s'", msg.SessionID)
+	stream, err := startTerminal(k8sClient, request, response, opts, nil)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	if stream == nil {
 		return
 	}
 
-	terminalSession.sockJSSession = session
-	terminalSessions.Set(msg.SessionID, terminalSession)
-	terminalSession.bound <- nil
+	stream.Finish(attachTerminal(k8sClient, cfg, opts, stream))
 }
 
-// CreateAttachHandler is called from main for /api/sockjs
-func CreateAttachHandler(path string) http.Handler {
-	return sockjs.NewHandler(path, sockjs.DefaultOptions, handleTerminalSession)
+// terminalClient authenticates the WebSocket handshake and creates the clients of the user.
+func terminalClient(request *restful.Request) (kubernetes.Interface, *rest.Config, error) {
+	if err := terminal.AuthenticateRequest(request.Request); err != nil {
+		return nil, nil, err
+	}
+
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := client.Config(request.Request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return k8sClient, cfg, nil
 }
 
-// startProcess is called by handleAttach
-// Executed cmd in the container specified in request and connects it up with the ptyHandler (a session)
-func startProcess(k8sClient kubernetes.Interface, cfg *rest.Config, request *restful.Request, cmd []string, ptyHandler PtyHandler) error {
-	namespace := request.PathParameter("namespace")
-	podName := request.PathParameter("pod")
-	containerName := request.PathParameter("container")
-
-	req := k8sClient.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(podName).
-		Namespace(namespace).
-		SubResource("exec")
-
-	req.VersionedParams(&v1.PodExecOptions{
-		Container: containerName,
-		Command:   cmd,
-		Stdin:     true,
-		Stdout:    true,
-		Stderr:    true,
-		TTY:       true,
-	}, scheme.ParameterCodec)
+// terminalExecOptions reads the command from the request. Without a command the interactive shell is started, so
+// the terminal and stdin are enabled by default.
+func terminalExecOptions(request *restful.Request) (terminal.Options, error) {
+	opts := terminal.Options{
+		Namespace: request.PathParameter("namespace"),
+		Pod:       request.PathParameter("pod"),
+		Container: request.PathParameter("container"),
+		Command:   request.QueryParameters("command"),
+	}
 
-	exec, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	tty, err := parseBoolQueryParameter(request, "tty", len(opts.Command) == 0)
 	if err != nil {
-		return err
+		return opts, err
 	}
+	opts.TTY = tty
 
-	err = exec.StreamWithContext(context.Background(), remotecommand.StreamOptions{
-		Stdin:             ptyHandler,
-		Stdout:            ptyHandler,
-		Stderr:            ptyHandler,
-		TerminalSizeQueue: ptyHandler,
-		Tty:               true,
-	})
+	stdin, err := parseBoolQueryParameter(request, "stdin", tty)
 	if err != nil {
-		return err
+		return opts, err
 	}
+	opts.Stdin = stdin
 
-	return nil
+	return opts, nil
 }
 
-// genTerminalSessionId generates a random session ID string. The format is not really interesting.
-// This ID is used to identify the session when the client opens the SockJS connection.
-// Not the same as the SockJS session id! We can't use that as that is generated
-// on the client side and we don't have it yet at this point.
-func genTerminalSessionId() (string, error) {
-	bytes := make([]byte, 16)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
+func parseBoolQueryParameter(request *restful.Request, name string, defaultValue bool) (bool, error) {
+	value := request.QueryParameter(name)
+	if len(value) == 0 {
+		return defaultValue, nil
 	}
-	id := make([]byte, hex.EncodedLen(len(bytes)))
-	hex.Encode(id, bytes)
-	return string(id), nil
-}
 
-// isValidShell checks if the shell is an allowed one
-func isValidShell(validShells []string, shell string) bool {
-	for _, validShell := range validShells {
-		if validShell == shell {
-			return true
-		}
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.NewBadRequest(fmt.Sprintf("invalid %s parameter: %s", name, value))
 	}
-	return false
-}
 
-// WaitForTerminal is called from apihandler.handleAttach as a goroutine
-// Waits for the SockJS connection to be opened by the client the session to be bound in handleTerminalSession
-func WaitForTerminal(k8sClient kubernetes.Interface, cfg *rest.Config, request *restful.Request, sessionId string) {
-	shell := request.QueryParameter("shell")
+	return result, nil
+}
 
-	select {
-	case <-terminalSessions.Get(sessionId).bound:
-		close(terminalSessions.Get(sessionId).bound)
-
-		var err error
-		validShells := []string{"bash", "sh", "powershell", "cmd"}
-
-		if isValidShell(validShells, shell) {
-			cmd := []string{shell}
-			err = startProcess(k8sClient, cfg, request, cmd, terminalSessions.Get(sessionId))
-		} else {
-			// No shell given or it was not valid: try some shells until one succeeds or all fail
-			// FIXME: if the first shell fails then the first keyboard event is lost
-			for _, testShell := range validShells {
-				cmd := []string{testShell}
-				if err = startProcess(k8sClient, cfg, request, cmd, terminalSessions.Get(sessionId)); err == nil {
-					break
-				}
-			}
-		}
+// startTerminal starts the recording of the session and upgrades the connection. It returns nil stream when the
+// upgrade fails, the error response is written by the upgrader in that case.
+func startTerminal(k8sClient kubernetes.Interface, request *restful.Request, response *restful.Response,
+	opts terminal.Options, command []string) (*terminal.Stream, error) {
+	recorder, err := recording.Start(k8sClient, opts.Namespace, opts.Pod, opts.Container, command)
+	if err != nil {
+		return nil, err
+	}
 
-		if err != nil {
-			terminalSessions.Close(sessionId, 2, err.Error())
-			return
-		}
+	stream, err := terminal.Upgrade(response.ResponseWriter, request.Request, opts.Stdin, recorder)
+	if err != nil {
+		klog.V(args.LogLevelVerbose).InfoS("Could not upgrade terminal connection", "error", err)
+		recorder.Close()
+		return nil, nil
+	}
 
-		terminalSessions.Close(sessionId, 1, "Process exited")
+	return stream, nil
+}
 
-	case <-time.After(10 * time.Second):
-		// Close chan and delete session when sockjs connection was timeout
-		close(terminalSessions.Get(sessionId).bound)
-		delete(terminalSessions.Sessions, sessionId)
-		return
+// attachTerminal waits for the container to be running and attaches to it.
+func attachTerminal(k8sClient kubernetes.Interface, cfg *rest.Config, opts terminal.Options,
+	stream *terminal.Stream) error {
+	terminal.Message(stream, fmt.Sprintf("Waiting for container %s to start", opts.Container))
+	if err := pod.WaitForContainerRunning(stream.Context(), k8sClient, opts.Namespace, opts.Pod,
+		opts.Container); err != nil {
+		return err
 	}
+
+	// The prompt may be printed before the terminal is attached, as kubectl warns too
+	terminal.Message(stream, "If you don't see a command prompt, try pressing enter")
+	return terminal.Attach(k8sClient, cfg, opts, stream)
 }
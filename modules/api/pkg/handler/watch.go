@@ -0,0 +1,127 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/handler/parser"
+	"k8s.io/dashboard/api/pkg/watch"
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+const (
+	// watchEventDelta is the name of server-sent event that carries watch.Delta.
+	watchEventDelta = "delta"
+	// watchEventError is the name of server-sent event sent right before the stream is closed due to an error.
+	watchEventError = "error"
+)
+
+// installWatch registers routes that stream changes of resource lists as server-sent events.
+func installWatch(apiHandler APIHandler, container *restful.Container) {
+	watchWs := new(restful.WebService)
+	InstallFilters(watchWs)
+
+	watchWs.Path("/api/v1/watch").
+		// docs
+		Doc("streams changes of resource lists as server-sent events").
+		Param(watchWs.QueryParameter("filterBy", "Comma delimited string used to apply filtering: 'propertyName,filterValue'")).
+		Param(watchWs.QueryParameter("sortBy", "Name of the column to sort by")).
+		Param(watchWs.QueryParameter("labelSelector", "Label selector passed to the apiserver when listing resources")).
+		Param(watchWs.QueryParameter("fieldSelector", "Field selector passed to the apiserver when listing resources")).
+		Param(watchWs.QueryParameter("itemsPerPage", "Number of items to return when pagination is applied")).
+		Param(watchWs.QueryParameter("page", "Page number to return items from")).
+		Produces(mimeEventStream)
+	container.Add(watchWs)
+
+	watchWs.Route(
+		watchWs.GET("/{kind}").To(apiHandler.handleWatch).
+			// docs
+			Doc("streams changes of a list of resources of given kind in all namespaces").
+			Param(watchWs.PathParameter("kind", "kind of the resource")).
+			Writes(watch.Delta{}).
+			Returns(http.StatusOK, "OK", watch.Delta{}))
+	watchWs.Route(
+		watchWs.GET("/{kind}/{namespace}").To(apiHandler.handleWatch).
+			// docs
+			Doc("streams changes of a list of resources of given kind in a namespace").
+			Param(watchWs.PathParameter("kind", "kind of the resource")).
+			Param(watchWs.PathParameter("namespace", "comma separated list of namespaces")).
+			Writes(watch.Delta{}).
+			Returns(http.StatusOK, "OK", watch.Delta{}))
+}
+
+func (apiHandler *APIHandler) handleWatch(request *restful.Request, response *restful.Response) {
+	kind := types.ResourceKind(request.PathParameter("kind"))
+	if !watch.IsSupported(kind) {
+		_ = response.WriteError(http.StatusBadRequest, fmt.Errorf("watching %s list is not supported", kind))
+		return
+	}
+
+	k8sClient, err := client.Client(request.Request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect, err := parser.ParseDataSelectPathParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	watcher, err := watch.NewWatcher(k8sClient, kind, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	startServerSentEvents(response)
+
+	ctx := request.Request.Context()
+	deltas := make(chan *watch.Delta)
+	result := make(chan error, 1)
+	go func() {
+		result <- watcher.Run(ctx, deltas)
+	}()
+
+	heartbeat := time.NewTicker(serverSentEventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case delta, ok := <-deltas:
+			if !ok {
+				if err := <-result; err != nil {
+					klog.V(4).InfoS("Watch stream closed", "kind", kind, "error", err)
+					writeServerSentEvent(response, watchEventError, err.Error())
+				}
+				return
+			}
+
+			writeServerSentEvent(response, watchEventDelta, delta)
+		case <-heartbeat.C:
+			writeServerSentHeartbeat(response)
+		}
+	}
+}
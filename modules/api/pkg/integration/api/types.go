@@ -22,7 +22,8 @@ type IntegrationID string
 
 // Integration app IDs should be registered in this block.
 const (
-	SidecarIntegrationID IntegrationID = "sidecar"
+	SidecarIntegrationID    IntegrationID = "sidecar"
+	PrometheusIntegrationID IntegrationID = "prometheus"
 )
 
 // Integration represents application integrated into the dashboard. Every application
@@ -0,0 +1,54 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+	"k8s.io/dashboard/helpers"
+	"k8s.io/dashboard/types"
+)
+
+// GetPodsFromCache returns a full list of pods that belong to the resource described by selector.
+// It is important that cachedPods include ALL pods from the namespace of this resource (but they
+// can also include pods from other namespaces).
+func GetPodsFromCache(selector metricapi.ResourceSelector, cachedPods []v1.Pod) (matchingPods []v1.Pod, err error) {
+	switch {
+	case cachedPods == nil:
+		err = fmt.Errorf(`Pods were not available in cache. Required for resource type: "%s"`,
+			selector.ResourceType)
+	case selector.ResourceType == types.ResourceKindDeployment:
+		for _, pod := range cachedPods {
+			if pod.ObjectMeta.Namespace == selector.Namespace && helpers.IsSelectorMatching(selector.Selector, pod.Labels) {
+				matchingPods = append(matchingPods, pod)
+			}
+		}
+	default:
+		for _, pod := range cachedPods {
+			if pod.Namespace == selector.Namespace {
+				for _, ownerRef := range pod.OwnerReferences {
+					if ownerRef.Controller != nil && *ownerRef.Controller &&
+						ownerRef.UID == selector.UID {
+						matchingPods = append(matchingPods, pod)
+					}
+				}
+			}
+		}
+	}
+	return
+}
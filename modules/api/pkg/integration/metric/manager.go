@@ -23,6 +23,7 @@ import (
 
 	integrationapi "k8s.io/dashboard/api/pkg/integration/api"
 	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+	"k8s.io/dashboard/api/pkg/integration/metric/prometheus"
 	"k8s.io/dashboard/api/pkg/integration/metric/sidecar"
 	"k8s.io/dashboard/client"
 )
@@ -43,6 +44,8 @@ type MetricManager interface {
 	List() []integrationapi.Integration
 	// ConfigureSidecar configures and adds sidecar to clients list.
 	ConfigureSidecar(host string) MetricManager
+	// ConfigurePrometheus configures and adds prometheus to clients list.
+	ConfigurePrometheus(host string, queryRange, step time.Duration) MetricManager
 }
 
 // Implements MetricManager interface.
@@ -127,6 +130,18 @@ func (self *metricManager) ConfigureSidecar(host string) MetricManager {
 	return self
 }
 
+// ConfigurePrometheus implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) ConfigurePrometheus(host string, queryRange, step time.Duration) MetricManager {
+	metricClient, err := prometheus.CreatePrometheusClient(host, queryRange, step)
+	if err != nil {
+		klog.Errorf("There was an error during prometheus client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[metricClient.ID()] = metricClient
+	return self
+}
+
 // NewMetricManager creates metric manager.
 func NewMetricManager() MetricManager {
 	return &metricManager{
@@ -0,0 +1,284 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
+	apimachinery "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/args"
+	integrationapi "k8s.io/dashboard/api/pkg/integration/api"
+	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+	"k8s.io/dashboard/api/pkg/integration/metric/common"
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/types"
+)
+
+// Prometheus client implements MetricClient and Integration interfaces.
+type prometheusClient struct {
+	client rest.Interface
+	// queryRange is how far into the past metrics are downloaded.
+	queryRange time.Duration
+	// step is the resolution of downloaded metrics.
+	step time.Duration
+}
+
+// queryResponse is a response of the Prometheus HTTP API. Only range vectors are supported.
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values []samplePair      `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// samplePair is a single sample of a range vector, encoded as [<unix time>, "<value>"].
+type samplePair struct {
+	Timestamp float64
+	Value     string
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (self *samplePair) UnmarshalJSON(data []byte) error {
+	pair := []interface{}{&self.Timestamp, &self.Value}
+	return json.Unmarshal(data, &pair)
+}
+
+// Implement Integration interface.
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self prometheusClient) HealthCheck() error {
+	if self.client == nil {
+		return errors.New("prometheus not configured")
+	}
+
+	_, err := self.client.Get().AbsPath("/-/ready").DoRaw(context.TODO())
+	return err
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self prometheusClient) ID() integrationapi.IntegrationID {
+	return integrationapi.PrometheusIntegrationID
+}
+
+// Implement MetricClient interface
+
+// DownloadMetrics implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) DownloadMetrics(selectors []metricapi.ResourceSelector,
+	metricNames []string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.MetricPromises{}
+	for _, metricName := range metricNames {
+		collectedMetrics := self.DownloadMetric(selectors, metricName, cachedResources)
+		result = append(result, collectedMetrics...)
+	}
+	return result
+}
+
+// DownloadMetric implements metric client interface. See MetricClient for more information.
+// Selectors of the same resource type and namespace are downloaded with a single range query. Selectors that can
+// not be transformed to the Prometheus selector fail with the error.
+func (self prometheusClient) DownloadMetric(selectors []metricapi.ResourceSelector,
+	metricName string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.NewMetricPromises(len(selectors))
+	go func() {
+		prometheusSelectors := make([]prometheusSelector, len(selectors))
+		groups := map[string][]int{}
+		for i, selector := range selectors {
+			prometheusSelector, err := getPrometheusSelector(selector, cachedResources)
+			if err != nil {
+				result[i].Metric <- nil
+				result[i].Error <- err
+				continue
+			}
+
+			prometheusSelectors[i] = prometheusSelector
+			groups[prometheusSelector.key()] = append(groups[prometheusSelector.key()], i)
+		}
+
+		end := time.Now().Truncate(self.step)
+		for _, indices := range groups {
+			names := []string{}
+			for _, i := range indices {
+				names = append(names, prometheusSelectors[i].Resources...)
+			}
+
+			series := map[string]metricapi.Metric{}
+			var err error
+			if len(names) > 0 {
+				group := prometheusSelectors[indices[0]]
+				series, err = self.queryRangeByName(metricName, group.TargetResourceType, group.Namespace, names, end)
+			}
+
+			for _, i := range indices {
+				if err != nil {
+					result[i].Metric <- nil
+					result[i].Error <- err
+					continue
+				}
+
+				aggregatedMetric := aggregateSelector(prometheusSelectors[i], series, metricName)
+				result[i].Metric <- &aggregatedMetric
+				result[i].Error <- nil
+			}
+		}
+	}()
+	return result
+}
+
+// AggregateMetrics implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return common.AggregateMetricPromises(metrics, metricName, aggregations, nil)
+}
+
+// queryRangeByName runs range query for given metric and resources and returns a metric for each resource
+// name found in the result.
+func (self prometheusClient) queryRangeByName(metricName string, resourceType types.ResourceKind,
+	namespace string, names []string, end time.Time) (map[string]metricapi.Metric, error) {
+	query, err := buildQuery(metricName, resourceType, namespace, names, rateWindow(self.step))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := self.rangeQuery(query, end.Add(-self.queryRange), end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]metricapi.Metric, len(response.Data.Result))
+	for _, series := range response.Data.Result {
+		name := series.Metric[resultLabels[resourceType]]
+		dataPoints, metricPoints := samplesToPoints(series.Values)
+		result[name] = metricapi.Metric{
+			DataPoints:   dataPoints,
+			MetricPoints: metricPoints,
+			MetricName:   metricName,
+		}
+	}
+
+	return result, nil
+}
+
+// rangeQuery performs request to the range query endpoint of the Prometheus HTTP API. Parameters are sent URL-encoded
+// in the request body, as queries with many resource names can exceed the URL length limit.
+func (self prometheusClient) rangeQuery(query string, start, end time.Time) (*queryResponse, error) {
+	params := url.Values{
+		"query": {query},
+		"start": {strconv.FormatInt(start.Unix(), 10)},
+		"end":   {strconv.FormatInt(end.Unix(), 10)},
+		"step":  {formatDuration(self.step)},
+	}
+
+	rawData, err := self.client.Post().AbsPath("/api/v1/query_range").
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		Body([]byte(params.Encode())).
+		DoRaw(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(queryResponse)
+	if err = json.Unmarshal(rawData, response); err != nil {
+		return nil, err
+	}
+
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s", response.Error)
+	}
+
+	if response.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf(`prometheus returned "%s" result instead of "matrix"`, response.Data.ResultType)
+	}
+
+	return response, nil
+}
+
+// aggregateSelector sums metrics of all resources described by the selector. Resources without data are
+// skipped, but their UIDs are still part of the label.
+func aggregateSelector(selector prometheusSelector, series map[string]metricapi.Metric,
+	metricName string) metricapi.Metric {
+	metrics := make([]metricapi.Metric, 0, len(selector.Resources))
+	for i, name := range selector.Resources {
+		metric := series[name]
+		metric.MetricName = metricName
+		metric.Label = metricapi.Label{
+			selector.TargetResourceType: []apimachinery.UID{selector.Label[selector.TargetResourceType][i]},
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return common.AggregateData(metrics, metricName, metricapi.SumAggregation)
+}
+
+// samplesToPoints converts range vector samples to data points used by charts and metric points
+// used by sparklines. Samples without a finite value are skipped.
+func samplesToPoints(samples []samplePair) (metricapi.DataPoints, []metricapi.MetricPoint) {
+	dataPoints := metricapi.DataPoints{}
+	metricPoints := []metricapi.MetricPoint{}
+	for _, sample := range samples {
+		value, err := strconv.ParseFloat(sample.Value, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+
+		timestamp := int64(sample.Timestamp)
+		y := int64(math.Round(value))
+		dataPoints = append(dataPoints, metricapi.DataPoint{X: timestamp, Y: y})
+		if y >= 0 {
+			metricPoints = append(metricPoints, metricapi.MetricPoint{
+				Timestamp: time.Unix(timestamp, 0).UTC(),
+				Value:     uint64(y),
+			})
+		}
+	}
+
+	return dataPoints, metricPoints
+}
+
+// CreatePrometheusClient creates new Prometheus client. Host param is in the format of
+// protocol://address:port, e.g., http://prometheus-operated.monitoring:9090, and can contain
+// a path prefix if Prometheus is served behind one. Metrics are downloaded for the last queryRange
+// with the resolution of step.
+func CreatePrometheusClient(host string, queryRange, step time.Duration) (metricapi.MetricClient, error) {
+	if host == "" {
+		return prometheusClient{}, errors.New("prometheus host has to be provided")
+	}
+
+	if step <= 0 || queryRange < step {
+		return prometheusClient{}, fmt.Errorf("invalid prometheus query range %s and step %s", queryRange, step)
+	}
+
+	klog.V(args.LogLevelInfo).InfoS("Creating Prometheus client", "host", host)
+	restClient, err := client.RestClientForHost(host)
+	if err != nil {
+		return prometheusClient{}, err
+	}
+
+	return prometheusClient{client: restClient, queryRange: queryRange, step: step}, nil
+}
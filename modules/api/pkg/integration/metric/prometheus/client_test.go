@@ -0,0 +1,231 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+
+	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+	"k8s.io/dashboard/types"
+)
+
+type fakePrometheus struct {
+	mu      sync.Mutex
+	ready   bool
+	queries []string
+	// series maps resource names to their values at timestamps 60 and 120.
+	series map[string][2]string
+}
+
+func (self *fakePrometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/-/ready":
+		if !self.ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	case "/api/v1/query_range":
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		query := r.PostForm.Get("query")
+		self.mu.Lock()
+		self.queries = append(self.queries, query)
+		self.mu.Unlock()
+
+		label := "pod"
+		if strings.Contains(query, "node=~") {
+			label = "node"
+		}
+
+		result := []string{}
+		for name, values := range self.series {
+			if strings.Contains(query, name) {
+				result = append(result, fmt.Sprintf(`{"metric":{%q:%q},"values":[[60,%q],[120.5,%q]]}`,
+					label, name, values[0], values[1]))
+			}
+		}
+
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[%s]}}`,
+			strings.Join(result, ","))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, prometheus *fakePrometheus) metricapi.MetricClient {
+	server := httptest.NewServer(prometheus)
+	t.Cleanup(server.Close)
+
+	metricClient, err := CreatePrometheusClient(server.URL, 15*time.Minute, time.Minute)
+	if err != nil {
+		t.Fatalf("CreatePrometheusClient() returned error: %s", err)
+	}
+
+	return metricClient
+}
+
+func TestHealthCheck(t *testing.T) {
+	for _, ready := range []bool{true, false} {
+		err := newTestClient(t, &fakePrometheus{ready: ready}).HealthCheck()
+		if (err == nil) != ready {
+			t.Errorf("HealthCheck() for ready == %t returned error %v", ready, err)
+		}
+	}
+
+	if err := (prometheusClient{}).HealthCheck(); err == nil {
+		t.Error("HealthCheck() of not configured client should return error")
+	}
+}
+
+func TestDownloadMetric(t *testing.T) {
+	controller := true
+	pods := []v1.Pod{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "a", Namespace: "default", UID: "uid-a", Labels: map[string]string{"app": "web"}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "b", Namespace: "default", UID: "uid-b", Labels: map[string]string{"app": "web"}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "c", Namespace: "default", UID: "uid-c", OwnerReferences: []metaV1.OwnerReference{
+			{UID: "uid-rs", Controller: &controller},
+		}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "d", Namespace: "other", UID: "uid-d", Labels: map[string]string{"app": "web"}}},
+	}
+
+	selectors := []metricapi.ResourceSelector{
+		{Namespace: "default", ResourceType: types.ResourceKindDeployment, ResourceName: "web", UID: "uid-deploy",
+			Selector: map[string]string{"app": "web"}},
+		{Namespace: "default", ResourceType: types.ResourceKindReplicaSet, ResourceName: "rs", UID: "uid-rs"},
+		{ResourceType: types.ResourceKindNode, ResourceName: "node-1", UID: "uid-node-1"},
+	}
+
+	prometheus := &fakePrometheus{series: map[string][2]string{
+		"a":      {"100", "200.4"},
+		"b":      {"50", "NaN"},
+		"c":      {"10", "20"},
+		"d":      {"1000", "1000"},
+		"node-1": {"500", "600"},
+	}}
+	metricClient := newTestClient(t, prometheus)
+
+	metrics, err := metricClient.DownloadMetric(selectors, metricapi.CpuUsage,
+		&metricapi.CachedResources{Pods: pods}).GetMetrics()
+	if err != nil {
+		t.Fatalf("DownloadMetric() returned error: %s", err)
+	}
+
+	expected := []metricapi.Metric{
+		{
+			DataPoints:   metricapi.DataPoints{{X: 60, Y: 150}, {X: 120, Y: 200}},
+			MetricPoints: []metricapi.MetricPoint{},
+			MetricName:   metricapi.CpuUsage,
+			Label:        metricapi.Label{types.ResourceKindPod: []apimachinery.UID{"uid-a", "uid-b"}},
+			Aggregate:    metricapi.SumAggregation,
+		},
+		{
+			DataPoints: metricapi.DataPoints{{X: 60, Y: 10}, {X: 120, Y: 20}},
+			MetricPoints: []metricapi.MetricPoint{
+				{Timestamp: time.Unix(60, 0).UTC(), Value: 10},
+				{Timestamp: time.Unix(120, 0).UTC(), Value: 20},
+			},
+			MetricName: metricapi.CpuUsage,
+			Label:      metricapi.Label{types.ResourceKindPod: []apimachinery.UID{"uid-c"}},
+			Aggregate:  metricapi.SumAggregation,
+		},
+		{
+			DataPoints: metricapi.DataPoints{{X: 60, Y: 500}, {X: 120, Y: 600}},
+			MetricPoints: []metricapi.MetricPoint{
+				{Timestamp: time.Unix(60, 0).UTC(), Value: 500},
+				{Timestamp: time.Unix(120, 0).UTC(), Value: 600},
+			},
+			MetricName: metricapi.CpuUsage,
+			Label:      metricapi.Label{types.ResourceKindNode: []apimachinery.UID{"uid-node-1"}},
+			Aggregate:  metricapi.SumAggregation,
+		},
+	}
+
+	if !reflect.DeepEqual(metrics, expected) {
+		t.Errorf("DownloadMetric() == \n%#v\nexpected \n%#v", metrics, expected)
+	}
+
+	// Pods from the same namespace are downloaded with a single query.
+	if len(prometheus.queries) != 2 {
+		t.Errorf("DownloadMetric() sent %d queries, expected 2: %v", len(prometheus.queries), prometheus.queries)
+	}
+}
+
+func TestDownloadMetricError(t *testing.T) {
+	metricClient := newTestClient(t, &fakePrometheus{})
+	selectors := []metricapi.ResourceSelector{
+		{ResourceType: types.ResourceKindNode, ResourceName: "node-1", UID: "uid-node-1"},
+	}
+
+	metric, err := metricClient.DownloadMetric(selectors, "network/tx_rate", nil)[0].GetMetric()
+	if metric != nil || err == nil {
+		t.Error("DownloadMetric() of unsupported metric should return error")
+	}
+}
+
+func TestDownloadMetricSelectorError(t *testing.T) {
+	metricClient := newTestClient(t, &fakePrometheus{series: map[string][2]string{"node-1": {"500", "600"}}})
+	selectors := []metricapi.ResourceSelector{
+		{Namespace: "default", ResourceType: types.ResourceKindService, ResourceName: "web", UID: "uid-web"},
+		{ResourceType: types.ResourceKindNode, ResourceName: "node-1", UID: "uid-node-1"},
+	}
+
+	promises := metricClient.DownloadMetric(selectors, metricapi.CpuUsage, nil)
+	if metric, err := promises[0].GetMetric(); metric != nil || err == nil {
+		t.Error("DownloadMetric() of unsupported resource should return error")
+	}
+
+	if metric, err := promises[1].GetMetric(); metric == nil || err != nil {
+		t.Errorf("DownloadMetric() of supported resource returned error: %v", err)
+	}
+}
+
+func TestCreatePrometheusClient(t *testing.T) {
+	cases := []struct {
+		host       string
+		queryRange time.Duration
+		step       time.Duration
+		expectErr  bool
+	}{
+		{"http://localhost:9090", 15 * time.Minute, time.Minute, false},
+		{"", 15 * time.Minute, time.Minute, true},
+		{"http://localhost:9090", time.Minute, 0, true},
+		{"http://localhost:9090", time.Second, time.Minute, true},
+	}
+
+	for _, c := range cases {
+		_, err := CreatePrometheusClient(c.host, c.queryRange, c.step)
+		if (err != nil) != c.expectErr {
+			t.Errorf("CreatePrometheusClient(%s, %s, %s) returned error %v", c.host, c.queryRange, c.step, err)
+		}
+	}
+}
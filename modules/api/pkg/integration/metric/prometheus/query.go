@@ -0,0 +1,174 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	apimachinery "k8s.io/apimachinery/pkg/types"
+
+	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+	"k8s.io/dashboard/api/pkg/integration/metric/common"
+	"k8s.io/dashboard/types"
+)
+
+// minRateWindow is the shortest window used by rate queries. It has to cover at least a couple of
+// scrapes, otherwise rate returns no data for the default scrape interval.
+const minRateWindow = 5 * time.Minute
+
+// queries maps supported metric names to PromQL templates for every native resource type. Pod
+// templates take the namespace, a regular expression matching pod names and the rate window. Node
+// templates take a regular expression matching node names and the rate window. CPU usage is
+// reported in millicores and memory usage in bytes to match the sidecar provider.
+var queries = map[string]map[types.ResourceKind]string{
+	metricapi.CpuUsage: {
+		types.ResourceKindPod:  `sum by (pod) (rate(container_cpu_usage_seconds_total{namespace=%q,pod=~%q,container!="",container!="POD"}[%s])) * 1000`,
+		types.ResourceKindNode: `sum by (node) (rate(container_cpu_usage_seconds_total{id="/",node=~%q}[%s])) * 1000`,
+	},
+	metricapi.MemoryUsage: {
+		types.ResourceKindPod:  `sum by (pod) (container_memory_working_set_bytes{namespace=%q,pod=~%q,container!="",container!="POD"})`,
+		types.ResourceKindNode: `sum by (node) (container_memory_working_set_bytes{id="/",node=~%q})`,
+	},
+}
+
+// resultLabels maps native resource types to the label that identifies them in query results.
+var resultLabels = map[types.ResourceKind]string{
+	types.ResourceKindPod:  "pod",
+	types.ResourceKindNode: "node",
+}
+
+type prometheusSelector struct {
+	TargetResourceType types.ResourceKind
+	Namespace          string
+	Resources          []string
+	metricapi.Label
+}
+
+// key identifies selectors that can be downloaded with a single query.
+func (self prometheusSelector) key() string {
+	return string(self.TargetResourceType) + "/" + self.Namespace
+}
+
+func getPrometheusSelector(selector metricapi.ResourceSelector,
+	cachedResources *metricapi.CachedResources) (prometheusSelector, error) {
+	summingResource, isDerivedResource := metricapi.DerivedResources[selector.ResourceType]
+	if !isDerivedResource {
+		return newPrometheusSelectorFromNativeResource(selector.ResourceType, selector.Namespace,
+			[]string{selector.ResourceName}, []apimachinery.UID{selector.UID})
+	}
+
+	if summingResource != types.ResourceKindPod {
+		return prometheusSelector{}, fmt.Errorf(`Internal Error: Requested summing resources not supported. Requested "%s"`, summingResource)
+	}
+
+	var pods []string
+	var uids []apimachinery.UID
+	if cachedResources != nil {
+		myPods, err := common.GetPodsFromCache(selector, cachedResources.Pods)
+		if err != nil {
+			return prometheusSelector{}, err
+		}
+
+		for _, pod := range myPods {
+			pods = append(pods, pod.Name)
+			uids = append(uids, pod.UID)
+		}
+	}
+
+	return newPrometheusSelectorFromNativeResource(types.ResourceKindPod, selector.Namespace, pods, uids)
+}
+
+func newPrometheusSelectorFromNativeResource(resourceType types.ResourceKind, namespace string,
+	resourceNames []string, resourceUIDs []apimachinery.UID) (prometheusSelector, error) {
+	switch resourceType {
+	case types.ResourceKindPod:
+		return prometheusSelector{
+			TargetResourceType: resourceType,
+			Namespace:          namespace,
+			Resources:          resourceNames,
+			Label:              metricapi.Label{resourceType: resourceUIDs},
+		}, nil
+	case types.ResourceKindNode:
+		return prometheusSelector{
+			TargetResourceType: resourceType,
+			Resources:          resourceNames,
+			Label:              metricapi.Label{resourceType: resourceUIDs},
+		}, nil
+	default:
+		return prometheusSelector{}, fmt.Errorf(`Resource "%s" is not a native prometheus resource type or is not supported`, resourceType)
+	}
+}
+
+// buildQuery returns PromQL query that downloads given metric for all named resources of the given type.
+func buildQuery(metricName string, resourceType types.ResourceKind, namespace string, names []string,
+	window time.Duration) (string, error) {
+	templates, exists := queries[metricName]
+	if !exists {
+		return "", fmt.Errorf(`Metric "%s" is not supported by prometheus`, metricName)
+	}
+
+	template, exists := templates[resourceType]
+	if !exists {
+		return "", fmt.Errorf(`Resource "%s" is not a native prometheus resource type or is not supported`, resourceType)
+	}
+
+	matcher := namesToRegexp(names)
+	args := []interface{}{matcher}
+	if resourceType == types.ResourceKindPod {
+		args = []interface{}{namespace, matcher}
+	}
+
+	if strings.Contains(template, "rate(") {
+		args = append(args, formatDuration(window))
+	}
+
+	return fmt.Sprintf(template, args...), nil
+}
+
+// namesToRegexp returns a fully anchored regular expression matching only given names. Prometheus
+// anchors label matchers on its own.
+func namesToRegexp(names []string) string {
+	unique := make(map[string]struct{}, len(names))
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, exists := unique[name]; exists {
+			continue
+		}
+
+		unique[name] = struct{}{}
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+
+	sort.Strings(quoted)
+	return strings.Join(quoted, "|")
+}
+
+// rateWindow returns the window used by rate queries for given resolution.
+func rateWindow(step time.Duration) time.Duration {
+	if window := 2 * step; window > minRateWindow {
+		return window
+	}
+
+	return minRateWindow
+}
+
+// formatDuration formats duration using the PromQL syntax, i.e. 90s.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64(d/time.Second))
+}
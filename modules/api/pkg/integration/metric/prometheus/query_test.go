@@ -0,0 +1,74 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+	"k8s.io/dashboard/types"
+)
+
+func TestBuildQuery(t *testing.T) {
+	cases := []struct {
+		metricName   string
+		resourceType types.ResourceKind
+		namespace    string
+		names        []string
+		expected     string
+		expectErr    bool
+	}{
+		{
+			metricapi.CpuUsage, types.ResourceKindPod, "default", []string{"web-b", "web.a", "web-b"},
+			`sum by (pod) (rate(container_cpu_usage_seconds_total{namespace="default",pod=~"web-b|web\\.a",container!="",container!="POD"}[300s])) * 1000`,
+			false,
+		},
+		{
+			metricapi.MemoryUsage, types.ResourceKindNode, "", []string{"node-1"},
+			`sum by (node) (container_memory_working_set_bytes{id="/",node=~"node-1"})`,
+			false,
+		},
+		{"network/tx_rate", types.ResourceKindPod, "default", []string{"a"}, "", true},
+		{metricapi.CpuUsage, types.ResourceKindDeployment, "default", []string{"a"}, "", true},
+	}
+
+	for _, c := range cases {
+		actual, err := buildQuery(c.metricName, c.resourceType, c.namespace, c.names, 5*time.Minute)
+		if (err != nil) != c.expectErr {
+			t.Errorf("buildQuery(%s, %s) returned error %v", c.metricName, c.resourceType, err)
+		}
+
+		if actual != c.expected {
+			t.Errorf("buildQuery(%s, %s) == \n%s\nexpected \n%s", c.metricName, c.resourceType, actual, c.expected)
+		}
+	}
+}
+
+func TestRateWindow(t *testing.T) {
+	cases := []struct {
+		step     time.Duration
+		expected time.Duration
+	}{
+		{time.Minute, 5 * time.Minute},
+		{10 * time.Minute, 20 * time.Minute},
+	}
+
+	for _, c := range cases {
+		if actual := rateWindow(c.step); actual != c.expected {
+			t.Errorf("rateWindow(%s) == %s, expected %s", c.step, actual, c.expected)
+		}
+	}
+}
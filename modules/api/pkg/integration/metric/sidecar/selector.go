@@ -22,7 +22,7 @@ import (
 	"k8s.io/klog/v2"
 
 	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
-	"k8s.io/dashboard/helpers"
+	"k8s.io/dashboard/api/pkg/integration/metric/common"
 	"k8s.io/dashboard/types"
 )
 
@@ -59,7 +59,7 @@ func getSidecarSelector(selector metricapi.ResourceSelector,
 	// We are dealing with derived resource. Convert derived resource to its native resources.
 	// For example, convert deployment to the list of pod names that belong to this deployment
 	if summingResource == types.ResourceKindPod {
-		myPods, err := getMyPodsFromCache(selector, cachedResources.Pods)
+		myPods, err := common.GetPodsFromCache(selector, cachedResources.Pods)
 		if err != nil {
 			return sidecarSelector{}, err
 		}
@@ -70,35 +70,6 @@ func getSidecarSelector(selector metricapi.ResourceSelector,
 	return sidecarSelector{}, fmt.Errorf(`Internal Error: Requested summing resources not supported. Requested "%s"`, summingResource)
 }
 
-// getMyPodsFromCache returns a full list of pods that belong to this resource.
-// It is important that cachedPods include ALL pods from the namespace of this resource (but they
-// can also include pods from other namespaces).
-func getMyPodsFromCache(selector metricapi.ResourceSelector, cachedPods []v1.Pod) (matchingPods []v1.Pod, err error) {
-	switch {
-	case cachedPods == nil:
-		err = fmt.Errorf(`Pods were not available in cache. Required for resource type: "%s"`,
-			selector.ResourceType)
-	case selector.ResourceType == types.ResourceKindDeployment:
-		for _, pod := range cachedPods {
-			if pod.ObjectMeta.Namespace == selector.Namespace && helpers.IsSelectorMatching(selector.Selector, pod.Labels) {
-				matchingPods = append(matchingPods, pod)
-			}
-		}
-	default:
-		for _, pod := range cachedPods {
-			if pod.Namespace == selector.Namespace {
-				for _, ownerRef := range pod.OwnerReferences {
-					if ownerRef.Controller != nil && *ownerRef.Controller &&
-						ownerRef.UID == selector.UID {
-						matchingPods = append(matchingPods, pod)
-					}
-				}
-			}
-		}
-	}
-	return
-}
-
 // NewSidecarSelectorFromNativeResource returns new sidecar selector for native resources specified in arguments.
 // returns error if requested resource is not native or is not supported.
 func newSidecarSelectorFromNativeResource(resourceType types.ResourceKind, namespace string,
@@ -0,0 +1,176 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata edits labels and annotations of resources of any kind using JSON merge patches, so the changes do
+// not overwrite concurrent updates of the other fields.
+package metadata
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/retry"
+
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
+)
+
+// MetadataPatchSpec describes changes of labels or annotations.
+type MetadataPatchSpec struct {
+	// Set adds the keys or replaces their values.
+	Set map[string]string `json:"set,omitempty"`
+
+	// Remove removes the keys. Keys that do not exist are ignored.
+	Remove []string `json:"remove,omitempty"`
+
+	// Replace removes all existing keys that are not in Set, so Set becomes the complete new content.
+	Replace bool `json:"replace,omitempty"`
+}
+
+// Metadata contains labels and annotations of the resource after the change.
+type Metadata struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// PatchLabels changes labels of the resource of the given kind in the given namespace with the given name.
+func PatchLabels(verber client.ResourceVerber, kind, namespace, name string, spec *MetadataPatchSpec) (*Metadata,
+	error) {
+	if err := ValidateLabels(spec); err != nil {
+		return nil, err
+	}
+
+	return patch(verber, kind, namespace, name, "labels", spec)
+}
+
+// PatchAnnotations changes annotations of the resource of the given kind in the given namespace with the given name.
+func PatchAnnotations(verber client.ResourceVerber, kind, namespace, name string, spec *MetadataPatchSpec) (*Metadata,
+	error) {
+	if err := ValidateAnnotations(spec); err != nil {
+		return nil, err
+	}
+
+	return patch(verber, kind, namespace, name, "annotations", spec)
+}
+
+// ValidateLabels checks syntax of label keys and values in the same way as the API server does.
+func ValidateLabels(spec *MetadataPatchSpec) error {
+	path := field.NewPath("metadata", "labels")
+	errs := metav1validation.ValidateLabels(spec.Set, path)
+	for _, key := range spec.Remove {
+		errs = append(errs, metav1validation.ValidateLabelName(key, path)...)
+	}
+
+	return toBadRequest(errs)
+}
+
+// ValidateAnnotations checks syntax of annotation keys and the total size of the annotations in the same way as
+// the API server does.
+func ValidateAnnotations(spec *MetadataPatchSpec) error {
+	path := field.NewPath("metadata", "annotations")
+	errs := apimachineryvalidation.ValidateAnnotations(spec.Set, path)
+	for _, key := range spec.Remove {
+		for _, msg := range validation.IsQualifiedName(strings.ToLower(key)) {
+			errs = append(errs, field.Invalid(path, key, msg))
+		}
+	}
+
+	return toBadRequest(errs)
+}
+
+func toBadRequest(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errors.NewBadRequest(errs.ToAggregate().Error())
+}
+
+func patch(verber client.ResourceVerber, kind, namespace, name, fieldName string, spec *MetadataPatchSpec) (*Metadata,
+	error) {
+	if !spec.Replace {
+		return patchOnce(verber, kind, namespace, name, toMergePatch(fieldName, nil, spec))
+	}
+
+	// Keys to remove depend on the current object, so the patch is guarded by the resource version and retried when
+	// the object changes in the meantime.
+	var result *Metadata
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		object, err := verber.Get(kind, namespace, name)
+		if err != nil {
+			return err
+		}
+
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			return err
+		}
+
+		current := accessor.GetLabels()
+		if fieldName == "annotations" {
+			current = accessor.GetAnnotations()
+		}
+
+		patch := toMergePatch(fieldName, current, spec)
+		patch["metadata"].(map[string]interface{})["resourceVersion"] = accessor.GetResourceVersion()
+		result, err = patchOnce(verber, kind, namespace, name, patch)
+		return err
+	})
+
+	return result, err
+}
+
+// toMergePatch creates a merge patch of the field. Current keys are only needed to replace the field, in which case
+// the keys that are not set are removed.
+func toMergePatch(fieldName string, current map[string]string, spec *MetadataPatchSpec) map[string]interface{} {
+	values := make(map[string]interface{})
+	if spec.Replace {
+		for key := range current {
+			values[key] = nil
+		}
+	}
+
+	for _, key := range spec.Remove {
+		values[key] = nil
+	}
+
+	for key, value := range spec.Set {
+		values[key] = value
+	}
+
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{fieldName: values},
+	}
+}
+
+func patchOnce(verber client.ResourceVerber, kind, namespace, name string, patch map[string]interface{}) (*Metadata,
+	error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := verber.Patch(kind, namespace, name, k8stypes.MergePatchType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metadata{Labels: object.GetLabels(), Annotations: object.GetAnnotations()}, nil
+}
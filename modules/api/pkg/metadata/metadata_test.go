@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/dashboard/errors"
+)
+
+func TestToMergePatch(t *testing.T) {
+	current := map[string]string{"app": "web", "tier": "frontend", "stale": "true"}
+	cases := []struct {
+		spec     *MetadataPatchSpec
+		expected map[string]interface{}
+	}{
+		{
+			&MetadataPatchSpec{Set: map[string]string{"app": "api"}, Remove: []string{"stale"}},
+			map[string]interface{}{"app": "api", "stale": nil},
+		},
+		{
+			&MetadataPatchSpec{Set: map[string]string{"app": "api"}, Replace: true},
+			map[string]interface{}{"app": "api", "tier": nil, "stale": nil},
+		},
+		{
+			&MetadataPatchSpec{Replace: true},
+			map[string]interface{}{"app": nil, "tier": nil, "stale": nil},
+		},
+	}
+
+	for _, c := range cases {
+		actual := toMergePatch("labels", current, c.spec)
+		expected := map[string]interface{}{"metadata": map[string]interface{}{"labels": c.expected}}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("toMergePatch(%#v) == \n%#v\nexpected \n%#v", c.spec, actual, expected)
+		}
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	cases := []struct {
+		spec  *MetadataPatchSpec
+		valid bool
+	}{
+		{&MetadataPatchSpec{Set: map[string]string{"app.kubernetes.io/name": "web"}, Remove: []string{"tier"}}, true},
+		{&MetadataPatchSpec{Set: map[string]string{"invalid key": "web"}}, false},
+		{&MetadataPatchSpec{Set: map[string]string{"app": "invalid value!"}}, false},
+		{&MetadataPatchSpec{Set: map[string]string{"app": strings.Repeat("a", 64)}}, false},
+		{&MetadataPatchSpec{Remove: []string{"-invalid"}}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateLabels(c.spec)
+		if c.valid && err != nil {
+			t.Errorf("ValidateLabels(%#v) returned error: %v", c.spec, err)
+		}
+
+		if !c.valid && !errors.IsBadRequest(err) {
+			t.Errorf("ValidateLabels(%#v) should return bad request error, got %v", c.spec, err)
+		}
+	}
+}
+
+func TestValidateAnnotations(t *testing.T) {
+	cases := []struct {
+		spec  *MetadataPatchSpec
+		valid bool
+	}{
+		{&MetadataPatchSpec{Set: map[string]string{"description": "Any value is valid!"}}, true},
+		{&MetadataPatchSpec{Set: map[string]string{"invalid key": "web"}}, false},
+		{&MetadataPatchSpec{Set: map[string]string{"description": strings.Repeat("a", 256*1024)}}, false},
+		{&MetadataPatchSpec{Remove: []string{"invalid/key/"}}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateAnnotations(c.spec)
+		if c.valid && err != nil {
+			t.Errorf("ValidateAnnotations(%#v) returned error: %v", c.spec, err)
+		}
+
+		if !c.valid && !errors.IsBadRequest(err) {
+			t.Errorf("ValidateAnnotations(%#v) should return bad request error, got %v", c.spec, err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// RecordingLabel marks config maps that contain terminal recordings.
+	RecordingLabel = "dashboard.kubernetes.io/terminal-recording"
+
+	// metadataAnnotation contains recording metadata in the JSON format.
+	metadataAnnotation = "dashboard.kubernetes.io/terminal-recording-metadata"
+
+	// castKey is the config map key containing the recording.
+	castKey = "recording.cast"
+
+	// maxConfigMapRecordingSize keeps the recording below the 1 MiB object size limit, with room for the metadata.
+	maxConfigMapRecordingSize = 900 * 1024
+
+	configMapPrefix = "terminal-recording-"
+)
+
+// configMapFlushInterval is the interval in which the recording is stored while the session is running. It is
+// the longest part of the session that is lost when the API is restarted.
+var configMapFlushInterval = 10 * time.Second
+
+// configMapSink stores each recording in a config map. Config maps are created using the dashboard service account,
+// so users cannot change their own recordings. Recording is buffered and stored every configMapFlushInterval and once
+// it is finished, recordings exceeding the config map size limit are truncated.
+type configMapSink struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+func newConfigMapSink(client kubernetes.Interface, namespace string) *configMapSink {
+	return &configMapSink{client: client, namespace: namespace}
+}
+
+func (self *configMapSink) Create(recording *Recording) (io.WriteCloser, error) {
+	// Config map is created upfront, so the session is not started when the recording cannot be stored.
+	configMap, err := self.toConfigMap(recording, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = self.client.CoreV1().ConfigMaps(self.namespace).Create(context.TODO(), configMap,
+		metaV1.CreateOptions{}); err != nil {
+		return nil, err
+	}
+
+	return newConfigMapWriter(self, recording), nil
+}
+
+func (self *configMapSink) List() ([]Recording, error) {
+	list, err := self.client.CoreV1().ConfigMaps(self.namespace).List(context.TODO(),
+		metaV1.ListOptions{LabelSelector: RecordingLabel})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Recording, 0, len(list.Items))
+	for _, configMap := range list.Items {
+		recording, err := toRecording(&configMap)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *recording)
+	}
+
+	return result, nil
+}
+
+func (self *configMapSink) Open(id string) (*Recording, io.ReadCloser, error) {
+	if !recordingIdPattern.MatchString(id) {
+		return nil, nil, notFound(id)
+	}
+
+	configMap, err := self.client.CoreV1().ConfigMaps(self.namespace).Get(context.TODO(), configMapPrefix+id,
+		metaV1.GetOptions{})
+	if k8serrors.IsNotFound(err) || (err == nil && configMap.Labels[RecordingLabel] == "") {
+		return nil, nil, notFound(id)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recording, err := toRecording(configMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return recording, io.NopCloser(bytes.NewBufferString(configMap.Data[castKey])), nil
+}
+
+func (self *configMapSink) toConfigMap(recording *Recording, data []byte) (*v1.ConfigMap, error) {
+	metadata, err := json.Marshal(recording)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        configMapPrefix + recording.ID,
+			Namespace:   self.namespace,
+			Labels:      map[string]string{RecordingLabel: "true"},
+			Annotations: map[string]string{metadataAnnotation: string(metadata)},
+		},
+		Data: map[string]string{castKey: string(data)},
+	}, nil
+}
+
+func toRecording(configMap *v1.ConfigMap) (*Recording, error) {
+	recording := new(Recording)
+	if err := json.Unmarshal([]byte(configMap.Annotations[metadataAnnotation]), recording); err != nil {
+		return nil, err
+	}
+
+	return recording, nil
+}
+
+// configMapWriter buffers the recording and stores it periodically. Metadata is copied, as the recording is changed
+// by the recorder while the flush is running.
+type configMapWriter struct {
+	sink      *configMapSink
+	recording *Recording
+
+	lock     sync.Mutex
+	metadata Recording
+	buffer   bytes.Buffer
+	// dirty is true when the recording has changed since the last flush.
+	dirty bool
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+func newConfigMapWriter(sink *configMapSink, recording *Recording) *configMapWriter {
+	writer := &configMapWriter{
+		sink:      sink,
+		recording: recording,
+		metadata:  *recording,
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+
+	go writer.flushPeriodically()
+	return writer
+}
+
+func (self *configMapWriter) Write(p []byte) (int, error) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	self.dirty = true
+	if self.metadata.Truncated || self.buffer.Len()+len(p) > maxConfigMapRecordingSize {
+		// Only whole events are stored, so the truncated recording can still be replayed.
+		self.metadata.Truncated = true
+		self.recording.Truncated = true
+		return len(p), nil
+	}
+
+	return self.buffer.Write(p)
+}
+
+func (self *configMapWriter) Close() error {
+	close(self.stop)
+	<-self.stopped
+
+	self.lock.Lock()
+	self.metadata.EndTime = self.recording.EndTime
+	self.dirty = true
+	self.lock.Unlock()
+
+	return self.flush()
+}
+
+func (self *configMapWriter) flushPeriodically() {
+	defer close(self.stopped)
+
+	ticker := time.NewTicker(configMapFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-self.stop:
+			return
+		case <-ticker.C:
+			if err := self.flush(); err != nil {
+				klog.ErrorS(err, "Could not store terminal recording", "id", self.recording.ID)
+			}
+		}
+	}
+}
+
+// flush stores the recording in the config map when it has changed. Failed flush is retried by the next one.
+func (self *configMapWriter) flush() error {
+	self.lock.Lock()
+	if !self.dirty {
+		self.lock.Unlock()
+		return nil
+	}
+
+	metadata := self.metadata
+	data := append([]byte(nil), self.buffer.Bytes()...)
+	self.dirty = false
+	self.lock.Unlock()
+
+	configMap, err := self.sink.toConfigMap(&metadata, data)
+	if err == nil {
+		_, err = self.sink.client.CoreV1().ConfigMaps(self.sink.namespace).Update(context.TODO(), configMap,
+			metaV1.UpdateOptions{})
+	}
+
+	if err != nil {
+		self.lock.Lock()
+		self.dirty = true
+		self.lock.Unlock()
+	}
+
+	return err
+}
@@ -0,0 +1,335 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recording records terminal sessions in the asciicast v2 format, see
+// https://docs.asciinema.org/manual/asciicast/v2/, so they can be audited and replayed later.
+package recording
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Initial terminal size written to the header. Actual size is recorded by the first resize event.
+	defaultWidth  = 80
+	defaultHeight = 24
+
+	eventInput  = "i"
+	eventOutput = "o"
+	eventResize = "r"
+)
+
+// sink stores recordings. Nil sink means that the recording is disabled.
+var sink Sink
+
+// Init sets the sink used to store recordings. Recording is disabled when the sink is nil.
+func Init(s Sink) {
+	sink = s
+}
+
+// Enabled returns whether terminal sessions are recorded.
+func Enabled() bool {
+	return sink != nil
+}
+
+// Recording contains metadata of the recorded terminal session.
+type Recording struct {
+	ID        string `json:"id"`
+	User      string `json:"user"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	// Command is the command executed in the container. It is empty when the terminal was attached to the container.
+	Command   []string     `json:"command,omitempty"`
+	StartTime metaV1.Time  `json:"startTime"`
+	EndTime   *metaV1.Time `json:"endTime,omitempty"`
+	// Truncated is set when the sink could not store the whole recording.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// RecordingList contains metadata of all recordings, the most recent first.
+type RecordingList struct {
+	Items []Recording `json:"items"`
+}
+
+// header is the first line of the asciicast v2 file.
+type header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder writes events of a single terminal session. All methods can be called on the nil recorder, which does
+// nothing, so callers do not have to check whether the recording is enabled.
+type Recorder struct {
+	lock      sync.Mutex
+	recording *Recording
+	writer    io.WriteCloser
+	start     time.Time
+	// pending contains the end of the output that is not a complete UTF-8 sequence yet.
+	pending []byte
+	closed  bool
+}
+
+// Start starts recording of the terminal session in the container. The user is identified using the client of
+// the user. Returns nil recorder when the recording is disabled.
+func Start(client kubernetes.Interface, namespace, pod, container string, command []string) (*Recorder, error) {
+	if !Enabled() {
+		return nil, nil
+	}
+
+	id, err := genRecordingId()
+	if err != nil {
+		return nil, err
+	}
+
+	return newRecorder(sink, &Recording{
+		ID:        id,
+		User:      userName(client),
+		Namespace: namespace,
+		Pod:       pod,
+		Container: container,
+		Command:   command,
+		StartTime: metaV1.Now(),
+	})
+}
+
+func newRecorder(s Sink, recording *Recording) (*Recorder, error) {
+	writer, err := s.Create(recording)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := &Recorder{recording: recording, writer: writer, start: recording.StartTime.Time}
+	data, err := json.Marshal(header{
+		Version:   2,
+		Width:     defaultWidth,
+		Height:    defaultHeight,
+		Timestamp: recording.StartTime.Unix(),
+		Title:     fmt.Sprintf("%s/%s/%s", recording.Namespace, recording.Pod, recording.Container),
+		Env:       map[string]string{"TERM": "xterm"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = writer.Write(append(data, '\n')); err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+
+	return recorder, nil
+}
+
+// Input records data sent to the process.
+func (self *Recorder) Input(data string) {
+	if self == nil {
+		return
+	}
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.write(eventInput, data)
+}
+
+// Output records data received from the process. Multibyte characters split between writes are recorded once they are
+// complete, because the events have to be valid UTF-8 strings.
+func (self *Recorder) Output(data []byte) {
+	if self == nil {
+		return
+	}
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	data = append(self.pending, data...)
+	end := completeUTF8Length(data)
+	self.pending = append([]byte(nil), data[end:]...)
+	if end > 0 {
+		self.write(eventOutput, string(data[:end]))
+	}
+}
+
+// Resize records the new size of the terminal.
+func (self *Recorder) Resize(width, height uint16) {
+	if self == nil {
+		return
+	}
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.write(eventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Close finishes the recording. It is safe to call it multiple times.
+func (self *Recorder) Close() {
+	if self == nil {
+		return
+	}
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.closed {
+		return
+	}
+
+	if len(self.pending) > 0 {
+		self.write(eventOutput, string(self.pending))
+	}
+
+	self.closed = true
+	now := metaV1.Now()
+	self.recording.EndTime = &now
+	if err := self.writer.Close(); err != nil {
+		klog.ErrorS(err, "Could not finish terminal recording", "id", self.recording.ID)
+	}
+}
+
+func (self *Recorder) write(code, data string) {
+	if self.closed {
+		return
+	}
+
+	event, err := json.Marshal([]interface{}{time.Since(self.start).Seconds(), code, data})
+	if err != nil {
+		klog.ErrorS(err, "Could not encode terminal recording event", "id", self.recording.ID)
+		return
+	}
+
+	if _, err = self.writer.Write(append(event, '\n')); err != nil {
+		klog.ErrorS(err, "Could not write terminal recording event", "id", self.recording.ID)
+	}
+}
+
+// completeUTF8Length returns the length of the data without the incomplete UTF-8 sequence at the end.
+func completeUTF8Length(data []byte) int {
+	// UTF-8 sequence is at most 4 bytes long, so only the last 3 bytes can be an incomplete one.
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax+1; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+
+		if utf8.FullRune(data[i:]) {
+			return len(data)
+		}
+		return i
+	}
+
+	return len(data)
+}
+
+// GetRecordingList returns metadata of the recordings, the most recent first. Only recordings accepted by the filter
+// are returned.
+func GetRecordingList(filter func(recording Recording) bool) (*RecordingList, error) {
+	if !Enabled() {
+		return &RecordingList{Items: []Recording{}}, nil
+	}
+
+	recordings, err := sink.List()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RecordingList{Items: make([]Recording, 0, len(recordings))}
+	for _, recording := range recordings {
+		if filter(recording) {
+			result.Items = append(result.Items, recording)
+		}
+	}
+
+	sort.SliceStable(result.Items, func(i, j int) bool {
+		return result.Items[j].StartTime.Before(&result.Items[i].StartTime)
+	})
+	return result, nil
+}
+
+// GetRecording returns metadata of the recording and its data in the asciicast v2 format. Data has to be closed by
+// the caller.
+func GetRecording(id string) (*Recording, io.ReadCloser, error) {
+	if !Enabled() {
+		return nil, nil, notFound(id)
+	}
+
+	return sink.Open(id)
+}
+
+// userName returns the name of the user authenticated by the client. Recording should not be prevented by a cluster
+// that does not support self subject reviews, so the user is unknown in such case.
+func userName(client kubernetes.Interface) string {
+	review, err := client.AuthenticationV1().SelfSubjectReviews().Create(context.TODO(),
+		&authenticationv1.SelfSubjectReview{}, metaV1.CreateOptions{})
+	if err != nil {
+		klog.ErrorS(err, "Could not identify user of the terminal session")
+		return "unknown"
+	}
+
+	return review.Status.UserInfo.Username
+}
+
+// genRecordingId generates a random recording ID, which is also safe to use as a file name.
+func genRecordingId() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+// NewAccessFilter returns a filter of the recordings the user is allowed to replay. Replaying a recording reveals as
+// much as the session itself, so it requires permission to exec into pods in the namespace of the recording.
+func NewAccessFilter(client kubernetes.Interface) func(recording Recording) bool {
+	allowed := make(map[string]bool)
+	return func(recording Recording) bool {
+		if result, cached := allowed[recording.Namespace]; cached {
+			return result
+		}
+
+		review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(),
+			&authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace:   recording.Namespace,
+						Verb:        "create",
+						Resource:    "pods",
+						Subresource: "exec",
+					},
+				},
+			}, metaV1.CreateOptions{})
+		if err != nil {
+			klog.ErrorS(err, "Could not check access to terminal recordings", "namespace", recording.Namespace)
+			return false
+		}
+
+		allowed[recording.Namespace] = review.Status.Allowed
+		return review.Status.Allowed
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/dashboard/errors"
+)
+
+func newTestRecording(id string, start time.Time) *Recording {
+	return &Recording{
+		ID:        id,
+		User:      "jane",
+		Namespace: "default",
+		Pod:       "web",
+		Container: "app",
+		Command:   []string{"sh"},
+		StartTime: metaV1.NewTime(start),
+	}
+}
+
+// readEvents returns codes and data of the recorded events, after checking the header.
+func readEvents(t *testing.T, data io.Reader) [][2]string {
+	scanner := bufio.NewScanner(data)
+	if !scanner.Scan() {
+		t.Fatalf("recording has no header")
+	}
+
+	h := header{}
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil || h.Version != 2 || h.Title != "default/web/app" {
+		t.Fatalf("recording has invalid header %s: %v", scanner.Text(), err)
+	}
+
+	result := make([][2]string, 0)
+	for scanner.Scan() {
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || len(event) != 3 {
+			t.Fatalf("recording has invalid event %s: %v", scanner.Text(), err)
+		}
+		result = append(result, [2]string{event[1].(string), event[2].(string)})
+	}
+
+	return result
+}
+
+func record(t *testing.T, s Sink, recording *Recording) {
+	recorder, err := newRecorder(s, recording)
+	if err != nil {
+		t.Fatalf("newRecorder() returned error: %v", err)
+	}
+
+	euro := []byte("€")
+	recorder.Resize(120, 40)
+	recorder.Input("ls\r")
+	recorder.Output(append([]byte("price: "), euro[:2]...))
+	recorder.Output(euro[2:])
+	recorder.Close()
+	recorder.Close()
+	recorder.Output([]byte("after close"))
+}
+
+func TestDirectorySink(t *testing.T) {
+	s, err := newDirectorySink(t.TempDir())
+	if err != nil {
+		t.Fatalf("newDirectorySink() returned error: %v", err)
+	}
+
+	record(t, s, newTestRecording("0a1b", time.Now()))
+
+	recording, data, err := s.Open("0a1b")
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	defer data.Close()
+
+	if recording.EndTime == nil || recording.User != "jane" {
+		t.Errorf("Open() returned metadata %#v, expected finished recording of jane", recording)
+	}
+
+	expected := [][2]string{{"r", "120x40"}, {"i", "ls\r"}, {"o", "price: "}, {"o", "€"}}
+	if actual := readEvents(t, data); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("recorded events == \n%#v\nexpected \n%#v", actual, expected)
+	}
+
+	for _, id := range []string{"missing", "../0a1b"} {
+		if _, _, err := s.Open(id); !errors.IsNotFound(err) {
+			t.Errorf("Open(%s) should return not found error, got %v", id, err)
+		}
+	}
+}
+
+func TestConfigMapSink(t *testing.T) {
+	s := newConfigMapSink(fake.NewSimpleClientset(), "kubernetes-dashboard")
+	record(t, s, newTestRecording("0a1b", time.Now()))
+
+	recordings, err := s.List()
+	if err != nil || len(recordings) != 1 || recordings[0].EndTime == nil {
+		t.Fatalf("List() == %#v, %v, expected single finished recording", recordings, err)
+	}
+
+	_, data, err := s.Open("0a1b")
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+
+	expected := [][2]string{{"r", "120x40"}, {"i", "ls\r"}, {"o", "price: "}, {"o", "€"}}
+	if actual := readEvents(t, data); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("recorded events == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
+
+func TestConfigMapSinkTruncation(t *testing.T) {
+	s := newConfigMapSink(fake.NewSimpleClientset(), "kubernetes-dashboard")
+	recording := newTestRecording("0a1b", time.Now())
+	recorder, err := newRecorder(s, recording)
+	if err != nil {
+		t.Fatalf("newRecorder() returned error: %v", err)
+	}
+
+	recorder.Output([]byte(strings.Repeat("a", maxConfigMapRecordingSize)))
+	recorder.Output([]byte("b"))
+	recorder.Close()
+
+	recording, data, err := s.Open("0a1b")
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+
+	if events := readEvents(t, data); !recording.Truncated || len(events) != 0 {
+		t.Errorf("recording should be truncated without events, got %#v with %d events", recording, len(events))
+	}
+}
+
+func TestConfigMapSinkFlush(t *testing.T) {
+	defer func(interval time.Duration) { configMapFlushInterval = interval }(configMapFlushInterval)
+	configMapFlushInterval = 10 * time.Millisecond
+
+	s := newConfigMapSink(fake.NewSimpleClientset(), "kubernetes-dashboard")
+	recorder, err := newRecorder(s, newTestRecording("0a1b", time.Now()))
+	if err != nil {
+		t.Fatalf("newRecorder() returned error: %v", err)
+	}
+	defer recorder.Close()
+
+	recorder.Input("ls\r")
+
+	// Session is not closed, i.e. the API has crashed, but the recording is stored already.
+	expected := [][2]string{{"i", "ls\r"}}
+	var actual [][2]string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		recording, data, err := s.Open("0a1b")
+		if err != nil {
+			t.Fatalf("Open() returned error: %v", err)
+		}
+
+		if recording.EndTime != nil {
+			t.Fatalf("recording should not be finished before Close(), got %#v", recording)
+		}
+
+		content, _ := io.ReadAll(data)
+		if len(content) == 0 {
+			// Not flushed yet
+			continue
+		}
+
+		if actual = readEvents(t, bytes.NewReader(content)); reflect.DeepEqual(actual, expected) {
+			return
+		}
+	}
+
+	t.Errorf("stored events == \n%#v\nexpected \n%#v", actual, expected)
+}
+
+func TestGetRecordingList(t *testing.T) {
+	s, err := newDirectorySink(t.TempDir())
+	if err != nil {
+		t.Fatalf("newDirectorySink() returned error: %v", err)
+	}
+	Init(s)
+	defer Init(nil)
+
+	start := time.Now()
+	for i, id := range []string{"01", "02", "03"} {
+		recording := newTestRecording(id, start.Add(time.Duration(i)*time.Minute))
+		if id == "02" {
+			recording.Namespace = "kube-system"
+		}
+		record(t, s, recording)
+	}
+
+	list, err := GetRecordingList(func(recording Recording) bool { return recording.Namespace == "default" })
+	if err != nil {
+		t.Fatalf("GetRecordingList() returned error: %v", err)
+	}
+
+	if len(list.Items) != 2 || list.Items[0].ID != "03" || list.Items[1].ID != "01" {
+		t.Errorf("GetRecordingList() == %#v, expected recordings 03 and 01", list)
+	}
+}
+
+func TestCompleteUTF8Length(t *testing.T) {
+	euro := []byte("€")
+	cases := []struct {
+		data     []byte
+		expected int
+	}{
+		{[]byte(""), 0},
+		{[]byte("abc"), 3},
+		{append([]byte("ab"), euro...), 5},
+		{append([]byte("ab"), euro[:1]...), 2},
+		{append([]byte("ab"), euro[:2]...), 2},
+		// Invalid data is recorded as it is, so it does not block the recording.
+		{[]byte{'a', euro[1], euro[2]}, 3},
+	}
+
+	for _, c := range cases {
+		if actual := completeUTF8Length(c.data); actual != c.expected {
+			t.Errorf("completeUTF8Length(%v) == %d, expected %d", c.data, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
+)
+
+const (
+	SinkDirectory = "directory"
+	SinkConfigMap = "configmap"
+
+	castExtension     = ".cast"
+	metadataExtension = ".json"
+)
+
+var recordingIdPattern = regexp.MustCompile(`^[0-9a-f]+$`)
+
+// Sink stores recordings and their metadata.
+type Sink interface {
+	// Create starts a new recording. The recording is written to the returned writer and finished by closing it.
+	// Metadata is stored again on close, so it includes changes made during the recording, i.e. the end time.
+	Create(recording *Recording) (io.WriteCloser, error)
+
+	// List returns metadata of all recordings, including the ones that are not finished yet.
+	List() ([]Recording, error)
+
+	// Open returns metadata and data of the recording.
+	Open(id string) (*Recording, io.ReadCloser, error)
+}
+
+// NewSink creates the sink of the given type. Empty type disables the recording, so nil sink is returned.
+func NewSink(sinkType, directory, namespace string) (Sink, error) {
+	switch sinkType {
+	case "":
+		return nil, nil
+	case SinkDirectory:
+		return newDirectorySink(directory)
+	case SinkConfigMap:
+		return newConfigMapSink(client.InClusterClient(), namespace), nil
+	}
+
+	return nil, fmt.Errorf("unsupported terminal recording sink %q", sinkType)
+}
+
+func notFound(id string) error {
+	return errors.NewNotFound(fmt.Sprintf("terminal recording %s not found", id))
+}
+
+// directorySink stores each recording in a file with the metadata in a separate file next to it. The directory can be
+// a mounted persistent volume, so recordings survive restarts.
+type directorySink struct {
+	directory string
+}
+
+func newDirectorySink(directory string) (*directorySink, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &directorySink{directory: directory}, nil
+}
+
+func (self *directorySink) Create(recording *Recording) (io.WriteCloser, error) {
+	if err := self.writeMetadata(recording); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(self.path(recording.ID, castExtension), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &directoryWriter{File: file, sink: self, recording: recording}, nil
+}
+
+func (self *directorySink) List() ([]Recording, error) {
+	entries, err := os.ReadDir(self.directory)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Recording, 0)
+	for _, entry := range entries {
+		id, found := strings.CutSuffix(entry.Name(), metadataExtension)
+		if !found || entry.IsDir() {
+			continue
+		}
+
+		recording, err := self.readMetadata(id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *recording)
+	}
+
+	return result, nil
+}
+
+func (self *directorySink) Open(id string) (*Recording, io.ReadCloser, error) {
+	if !recordingIdPattern.MatchString(id) {
+		return nil, nil, notFound(id)
+	}
+
+	recording, err := self.readMetadata(id)
+	if os.IsNotExist(err) {
+		return nil, nil, notFound(id)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(self.path(id, castExtension))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return recording, file, nil
+}
+
+func (self *directorySink) path(id, extension string) string {
+	return filepath.Join(self.directory, id+extension)
+}
+
+func (self *directorySink) readMetadata(id string) (*Recording, error) {
+	data, err := os.ReadFile(self.path(id, metadataExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	recording := new(Recording)
+	if err = json.Unmarshal(data, recording); err != nil {
+		return nil, err
+	}
+
+	return recording, nil
+}
+
+func (self *directorySink) writeMetadata(recording *Recording) error {
+	data, err := json.Marshal(recording)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(self.path(recording.ID, metadataExtension), data, 0o600)
+}
+
+type directoryWriter struct {
+	*os.File
+	sink      *directorySink
+	recording *Recording
+}
+
+func (self *directoryWriter) Close() error {
+	if err := self.File.Close(); err != nil {
+		return err
+	}
+
+	return self.sink.writeMetadata(self.recording)
+}
@@ -41,7 +41,7 @@ type ClusterRole struct {
 func GetClusterRoleList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery) (*ClusterRoleList, error) {
 	klog.V(4).Info("Getting list of RBAC roles")
 	channels := &common.ResourceChannels{
-		ClusterRoleList: common.GetClusterRoleListChannel(client, 1),
+		ClusterRoleList: common.GetClusterRoleListChannelWithOptions(client, dsQuery.ListOptions(), 1),
 	}
 
 	return GetClusterRoleListFromChannels(channels, dsQuery)
@@ -56,6 +56,7 @@ func GetClusterRoleListFromChannels(channels *common.ResourceChannels, dsQuery *
 	}
 
 	result := toClusterRoleLists(clusterRoles.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(clusterRoles.ListMeta)
 	return result, nil
 }
 
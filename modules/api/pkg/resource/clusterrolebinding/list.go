@@ -45,7 +45,7 @@ type ClusterRoleBinding struct {
 func GetClusterRoleBindingList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery) (*ClusterRoleBindingList, error) {
 	klog.V(4).Infof("Getting list of all clusterRoleBindings in the cluster")
 	channels := &common.ResourceChannels{
-		ClusterRoleBindingList: common.GetClusterRoleBindingListChannel(client, 1),
+		ClusterRoleBindingList: common.GetClusterRoleBindingListChannelWithOptions(client, dsQuery.ListOptions(), 1),
 	}
 
 	return GetClusterRoleBindingListFromChannels(channels, dsQuery)
@@ -61,6 +61,7 @@ func GetClusterRoleBindingListFromChannels(channels *common.ResourceChannels, ds
 		return nil, criticalError
 	}
 	clusterRoleBindingList := toClusterRoleBindingList(clusterRoleBindings.Items, nonCriticalErrors, dsQuery)
+	clusterRoleBindingList.ListMeta.SetChunk(clusterRoleBindings.ListMeta)
 	return clusterRoleBindingList, nil
 }
 
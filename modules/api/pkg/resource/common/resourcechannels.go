@@ -22,6 +22,7 @@ import (
 	batch "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policy "k8s.io/api/policy/v1"
 	rbac "k8s.io/api/rbac/v1"
 	storage "k8s.io/api/storage/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -107,6 +108,9 @@ type ResourceChannels struct {
 	// List and error channels to HorizontalPodAutoscalers
 	HorizontalPodAutoscalerList HorizontalPodAutoscalerListChannel
 
+	// List and error channels to PodDisruptionBudgets
+	PodDisruptionBudgetList PodDisruptionBudgetListChannel
+
 	// List and error channels to StorageClasses
 	StorageClassList StorageClassListChannel
 
@@ -136,13 +140,19 @@ type ServiceListChannel struct {
 // must be read numReads times.
 func GetServiceListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) ServiceListChannel {
+	return GetServiceListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetServiceListChannelWithOptions is GetServiceListChannel plus listing options.
+func GetServiceListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) ServiceListChannel {
 
 	channel := ServiceListChannel{
 		List:  make(chan *v1.ServiceList, numReads),
 		Error: make(chan error, numReads),
 	}
 	go func() {
-		list, err := client.CoreV1().Services(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().Services(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		var filteredItems []v1.Service
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -169,13 +179,19 @@ type IngressListChannel struct {
 // must be read numReads times.
 func GetIngressListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) IngressListChannel {
+	return GetIngressListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetIngressListChannelWithOptions is GetIngressListChannel plus listing options.
+func GetIngressListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) IngressListChannel {
 
 	channel := IngressListChannel{
 		List:  make(chan *networkingv1.IngressList, numReads),
 		Error: make(chan error, numReads),
 	}
 	go func() {
-		list, err := client.NetworkingV1().Ingresses(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.NetworkingV1().Ingresses(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		var filteredItems []networkingv1.Ingress
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -202,6 +218,12 @@ type LimitRangeListChannel struct {
 // both must be read numReads times.
 func GetLimitRangeListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) LimitRangeListChannel {
+	return GetLimitRangeListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetLimitRangeListChannelWithOptions is GetLimitRangeListChannel plus listing options.
+func GetLimitRangeListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) LimitRangeListChannel {
 
 	channel := LimitRangeListChannel{
 		List:  make(chan *v1.LimitRangeList, numReads),
@@ -209,7 +231,7 @@ func GetLimitRangeListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().LimitRanges(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().LimitRanges(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -228,13 +250,18 @@ type NodeListChannel struct {
 // GetNodeListChannel returns a pair of channels to a Node list and errors that both must be read
 // numReads times.
 func GetNodeListChannel(client client.Interface, numReads int) NodeListChannel {
+	return GetNodeListChannelWithOptions(client, helpers.ListEverything, numReads)
+}
+
+// GetNodeListChannelWithOptions is GetNodeListChannel plus listing options.
+func GetNodeListChannelWithOptions(client client.Interface, options metaV1.ListOptions, numReads int) NodeListChannel {
 	channel := NodeListChannel{
 		List:  make(chan *v1.NodeList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.CoreV1().Nodes().List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().Nodes().List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -254,13 +281,19 @@ type NamespaceListChannel struct {
 // be read
 // numReads times.
 func GetNamespaceListChannel(client client.Interface, numReads int) NamespaceListChannel {
+	return GetNamespaceListChannelWithOptions(client, helpers.ListEverything, numReads)
+}
+
+// GetNamespaceListChannelWithOptions is GetNamespaceListChannel plus listing options.
+func GetNamespaceListChannelWithOptions(client client.Interface,
+	options metaV1.ListOptions, numReads int) NamespaceListChannel {
 	channel := NamespaceListChannel{
 		List:  make(chan *v1.NamespaceList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.CoreV1().Namespaces().List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().Namespaces().List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -390,6 +423,12 @@ type ReplicationControllerListChannel struct {
 // numReads times.
 func GetReplicationControllerListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) ReplicationControllerListChannel {
+	return GetReplicationControllerListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetReplicationControllerListChannelWithOptions is GetReplicationControllerListChannel plus listing options.
+func GetReplicationControllerListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) ReplicationControllerListChannel {
 
 	channel := ReplicationControllerListChannel{
 		List:  make(chan *v1.ReplicationControllerList, numReads),
@@ -398,7 +437,7 @@ func GetReplicationControllerListChannel(client client.Interface,
 
 	go func() {
 		list, err := client.CoreV1().ReplicationControllers(nsQuery.ToRequestParam()).
-			List(context.TODO(), helpers.ListEverything)
+			List(context.TODO(), options)
 		var filteredItems []v1.ReplicationController
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -425,6 +464,12 @@ type DeploymentListChannel struct {
 // that both must be read numReads times.
 func GetDeploymentListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) DeploymentListChannel {
+	return GetDeploymentListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetDeploymentListChannelWithOptions is GetDeploymentListChannel plus listing options.
+func GetDeploymentListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) DeploymentListChannel {
 
 	channel := DeploymentListChannel{
 		List:  make(chan *apps.DeploymentList, numReads),
@@ -433,7 +478,7 @@ func GetDeploymentListChannel(client client.Interface,
 
 	go func() {
 		list, err := client.AppsV1().Deployments(nsQuery.ToRequestParam()).
-			List(context.TODO(), helpers.ListEverything)
+			List(context.TODO(), options)
 		var filteredItems []apps.Deployment
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -500,13 +545,19 @@ type DaemonSetListChannel struct {
 // GetDaemonSetListChannel returns a pair of channels to a DaemonSet list and errors that both must be read
 // numReads times.
 func GetDaemonSetListChannel(client client.Interface, nsQuery *NamespaceQuery, numReads int) DaemonSetListChannel {
+	return GetDaemonSetListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetDaemonSetListChannelWithOptions is GetDaemonSetListChannel plus listing options.
+func GetDaemonSetListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) DaemonSetListChannel {
 	channel := DaemonSetListChannel{
 		List:  make(chan *apps.DaemonSetList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.AppsV1().DaemonSets(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.AppsV1().DaemonSets(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		var filteredItems []apps.DaemonSet
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -532,13 +583,19 @@ type JobListChannel struct {
 // GetJobListChannel returns a pair of channels to a Job list and errors that both must be read numReads times.
 func GetJobListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) JobListChannel {
+	return GetJobListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetJobListChannelWithOptions is GetJobListChannel plus listing options.
+func GetJobListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) JobListChannel {
 	channel := JobListChannel{
 		List:  make(chan *batch.JobList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.BatchV1().Jobs(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.BatchV1().Jobs(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		var filteredItems []batch.Job
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -563,13 +620,19 @@ type CronJobListChannel struct {
 
 // GetCronJobListChannel returns a pair of channels to a Cron Job list and errors that both must be read numReads times.
 func GetCronJobListChannel(client client.Interface, nsQuery *NamespaceQuery, numReads int) CronJobListChannel {
+	return GetCronJobListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetCronJobListChannelWithOptions is GetCronJobListChannel plus listing options.
+func GetCronJobListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) CronJobListChannel {
 	channel := CronJobListChannel{
 		List:  make(chan *batch.CronJobList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.BatchV1().CronJobs(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.BatchV1().CronJobs(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		var filteredItems []batch.CronJob
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -596,13 +659,19 @@ type StatefulSetListChannel struct {
 // numReads times.
 func GetStatefulSetListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) StatefulSetListChannel {
+	return GetStatefulSetListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetStatefulSetListChannelWithOptions is GetStatefulSetListChannel plus listing options.
+func GetStatefulSetListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) StatefulSetListChannel {
 	channel := StatefulSetListChannel{
 		List:  make(chan *apps.StatefulSetList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		statefulSets, err := client.AppsV1().StatefulSets(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		statefulSets, err := client.AppsV1().StatefulSets(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		var filteredItems []apps.StatefulSet
 		for _, item := range statefulSets.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -629,6 +698,12 @@ type ConfigMapListChannel struct {
 // numReads times.
 func GetConfigMapListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) ConfigMapListChannel {
+	return GetConfigMapListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetConfigMapListChannelWithOptions is GetConfigMapListChannel plus listing options.
+func GetConfigMapListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) ConfigMapListChannel {
 
 	channel := ConfigMapListChannel{
 		List:  make(chan *v1.ConfigMapList, numReads),
@@ -636,7 +711,7 @@ func GetConfigMapListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().ConfigMaps(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().ConfigMaps(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		var filteredItems []v1.ConfigMap
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -663,6 +738,12 @@ type SecretListChannel struct {
 // both must be read numReads times.
 func GetSecretListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) SecretListChannel {
+	return GetSecretListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetSecretListChannelWithOptions is GetSecretListChannel plus listing options.
+func GetSecretListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) SecretListChannel {
 
 	channel := SecretListChannel{
 		List:  make(chan *v1.SecretList, numReads),
@@ -670,7 +751,7 @@ func GetSecretListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().Secrets(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().Secrets(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		var filteredItems []v1.Secret
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -696,13 +777,19 @@ type RoleListChannel struct {
 // GetRoleListChannel returns a pair of channels to a Role list for a namespace and errors that
 // both must be read numReads times.
 func GetRoleListChannel(client client.Interface, nsQuery *NamespaceQuery, numReads int) RoleListChannel {
+	return GetRoleListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetRoleListChannelWithOptions is GetRoleListChannel plus listing options.
+func GetRoleListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) RoleListChannel {
 	channel := RoleListChannel{
 		List:  make(chan *rbac.RoleList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.RbacV1().Roles(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.RbacV1().Roles(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -721,13 +808,19 @@ type ClusterRoleListChannel struct {
 // GetClusterRoleListChannel returns a pair of channels to a ClusterRole list and errors that
 // both must be read numReads times.
 func GetClusterRoleListChannel(client client.Interface, numReads int) ClusterRoleListChannel {
+	return GetClusterRoleListChannelWithOptions(client, helpers.ListEverything, numReads)
+}
+
+// GetClusterRoleListChannelWithOptions is GetClusterRoleListChannel plus listing options.
+func GetClusterRoleListChannelWithOptions(client client.Interface,
+	options metaV1.ListOptions, numReads int) ClusterRoleListChannel {
 	channel := ClusterRoleListChannel{
 		List:  make(chan *rbac.ClusterRoleList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.RbacV1().ClusterRoles().List(context.TODO(), helpers.ListEverything)
+		list, err := client.RbacV1().ClusterRoles().List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -746,13 +839,19 @@ type RoleBindingListChannel struct {
 // GetRoleBindingListChannel returns a pair of channels to a RoleBinding list for a namespace and errors that
 // both must be read numReads times.
 func GetRoleBindingListChannel(client client.Interface, nsQuery *NamespaceQuery, numReads int) RoleBindingListChannel {
+	return GetRoleBindingListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetRoleBindingListChannelWithOptions is GetRoleBindingListChannel plus listing options.
+func GetRoleBindingListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) RoleBindingListChannel {
 	channel := RoleBindingListChannel{
 		List:  make(chan *rbac.RoleBindingList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.RbacV1().RoleBindings(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.RbacV1().RoleBindings(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -772,13 +871,19 @@ type ClusterRoleBindingListChannel struct {
 // errors that both must be read numReads times.
 func GetClusterRoleBindingListChannel(client client.Interface,
 	numReads int) ClusterRoleBindingListChannel {
+	return GetClusterRoleBindingListChannelWithOptions(client, helpers.ListEverything, numReads)
+}
+
+// GetClusterRoleBindingListChannelWithOptions is GetClusterRoleBindingListChannel plus listing options.
+func GetClusterRoleBindingListChannelWithOptions(client client.Interface,
+	options metaV1.ListOptions, numReads int) ClusterRoleBindingListChannel {
 	channel := ClusterRoleBindingListChannel{
 		List:  make(chan *rbac.ClusterRoleBindingList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.RbacV1().ClusterRoleBindings().List(context.TODO(), helpers.ListEverything)
+		list, err := client.RbacV1().ClusterRoleBindings().List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -798,13 +903,19 @@ type PersistentVolumeListChannel struct {
 // that both must be read numReads times.
 func GetPersistentVolumeListChannel(client client.Interface,
 	numReads int) PersistentVolumeListChannel {
+	return GetPersistentVolumeListChannelWithOptions(client, helpers.ListEverything, numReads)
+}
+
+// GetPersistentVolumeListChannelWithOptions is GetPersistentVolumeListChannel plus listing options.
+func GetPersistentVolumeListChannelWithOptions(client client.Interface,
+	options metaV1.ListOptions, numReads int) PersistentVolumeListChannel {
 	channel := PersistentVolumeListChannel{
 		List:  make(chan *v1.PersistentVolumeList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.CoreV1().PersistentVolumes().List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().PersistentVolumes().List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -824,6 +935,12 @@ type PersistentVolumeClaimListChannel struct {
 // and errors that both must be read numReads times.
 func GetPersistentVolumeClaimListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) PersistentVolumeClaimListChannel {
+	return GetPersistentVolumeClaimListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetPersistentVolumeClaimListChannelWithOptions is GetPersistentVolumeClaimListChannel plus listing options.
+func GetPersistentVolumeClaimListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) PersistentVolumeClaimListChannel {
 
 	channel := PersistentVolumeClaimListChannel{
 		List:  make(chan *v1.PersistentVolumeClaimList, numReads),
@@ -831,7 +948,7 @@ func GetPersistentVolumeClaimListChannel(client client.Interface, nsQuery *Names
 	}
 
 	go func() {
-		list, err := client.CoreV1().PersistentVolumeClaims(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().PersistentVolumeClaims(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -850,13 +967,19 @@ type CustomResourceDefinitionChannelV1 struct {
 // GetCustomResourceDefinitionChannelV1 returns a pair of channels to a CustomResourceDefinition list and errors
 // that both must be read numReads times.
 func GetCustomResourceDefinitionChannelV1(client apiextensionsclientset.Interface, numReads int) CustomResourceDefinitionChannelV1 {
+	return GetCustomResourceDefinitionChannelV1WithOptions(client, helpers.ListEverything, numReads)
+}
+
+// GetCustomResourceDefinitionChannelV1WithOptions is GetCustomResourceDefinitionChannelV1 plus listing options.
+func GetCustomResourceDefinitionChannelV1WithOptions(client apiextensionsclientset.Interface,
+	options metaV1.ListOptions, numReads int) CustomResourceDefinitionChannelV1 {
 	channel := CustomResourceDefinitionChannelV1{
 		List:  make(chan *apiextensions.CustomResourceDefinitionList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.ApiextensionsV1().CustomResourceDefinitions().List(context.TODO(), helpers.ListEverything)
+		list, err := client.ApiextensionsV1().CustomResourceDefinitions().List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -876,6 +999,12 @@ type ResourceQuotaListChannel struct {
 // both must be read numReads times.
 func GetResourceQuotaListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) ResourceQuotaListChannel {
+	return GetResourceQuotaListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetResourceQuotaListChannelWithOptions is GetResourceQuotaListChannel plus listing options.
+func GetResourceQuotaListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) ResourceQuotaListChannel {
 
 	channel := ResourceQuotaListChannel{
 		List:  make(chan *v1.ResourceQuotaList, numReads),
@@ -883,7 +1012,7 @@ func GetResourceQuotaListChannel(client client.Interface, nsQuery *NamespaceQuer
 	}
 
 	go func() {
-		list, err := client.CoreV1().ResourceQuotas(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+		list, err := client.CoreV1().ResourceQuotas(nsQuery.ToRequestParam()).List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -903,6 +1032,12 @@ type HorizontalPodAutoscalerListChannel struct {
 // both must be read numReads times.
 func GetHorizontalPodAutoscalerListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) HorizontalPodAutoscalerListChannel {
+	return GetHorizontalPodAutoscalerListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetHorizontalPodAutoscalerListChannelWithOptions is GetHorizontalPodAutoscalerListChannel plus listing options.
+func GetHorizontalPodAutoscalerListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) HorizontalPodAutoscalerListChannel {
 	channel := HorizontalPodAutoscalerListChannel{
 		List:  make(chan *autoscaling.HorizontalPodAutoscalerList, numReads),
 		Error: make(chan error, numReads),
@@ -910,7 +1045,40 @@ func GetHorizontalPodAutoscalerListChannel(client client.Interface, nsQuery *Nam
 
 	go func() {
 		list, err := client.AutoscalingV1().HorizontalPodAutoscalers(nsQuery.ToRequestParam()).
-			List(context.TODO(), helpers.ListEverything)
+			List(context.TODO(), options)
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
+// PodDisruptionBudgetListChannel is a list and error channels to pod disruption budgets.
+type PodDisruptionBudgetListChannel struct {
+	List  chan *policy.PodDisruptionBudgetList
+	Error chan error
+}
+
+// GetPodDisruptionBudgetListChannel returns a pair of channels to a pod disruption budget list and
+// errors that both must be read numReads times.
+func GetPodDisruptionBudgetListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	numReads int) PodDisruptionBudgetListChannel {
+	return GetPodDisruptionBudgetListChannelWithOptions(client, nsQuery, helpers.ListEverything, numReads)
+}
+
+// GetPodDisruptionBudgetListChannelWithOptions is GetPodDisruptionBudgetListChannel plus listing options.
+func GetPodDisruptionBudgetListChannelWithOptions(client client.Interface,
+	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) PodDisruptionBudgetListChannel {
+	channel := PodDisruptionBudgetListChannel{
+		List:  make(chan *policy.PodDisruptionBudgetList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := client.PolicyV1().PodDisruptionBudgets(nsQuery.ToRequestParam()).
+			List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -929,13 +1097,19 @@ type StorageClassListChannel struct {
 // GetStorageClassListChannel returns a pair of channels to a storage class list and
 // errors that both must be read numReads times.
 func GetStorageClassListChannel(client client.Interface, numReads int) StorageClassListChannel {
+	return GetStorageClassListChannelWithOptions(client, helpers.ListEverything, numReads)
+}
+
+// GetStorageClassListChannelWithOptions is GetStorageClassListChannel plus listing options.
+func GetStorageClassListChannelWithOptions(client client.Interface,
+	options metaV1.ListOptions, numReads int) StorageClassListChannel {
 	channel := StorageClassListChannel{
 		List:  make(chan *storage.StorageClassList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.StorageV1().StorageClasses().List(context.TODO(), helpers.ListEverything)
+		list, err := client.StorageV1().StorageClasses().List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -954,13 +1128,19 @@ type IngressClassListChannel struct {
 // GetIngressClassListChannel returns a pair of channels to a ingress class list and
 // errors that both must be read numReads times.
 func GetIngressClassListChannel(client client.Interface, numReads int) IngressClassListChannel {
+	return GetIngressClassListChannelWithOptions(client, helpers.ListEverything, numReads)
+}
+
+// GetIngressClassListChannelWithOptions is GetIngressClassListChannel plus listing options.
+func GetIngressClassListChannelWithOptions(client client.Interface,
+	options metaV1.ListOptions, numReads int) IngressClassListChannel {
 	channel := IngressClassListChannel{
 		List:  make(chan *networkingv1.IngressClassList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.NetworkingV1().IngressClasses().List(context.TODO(), helpers.ListEverything)
+		list, err := client.NetworkingV1().IngressClasses().List(context.TODO(), options)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
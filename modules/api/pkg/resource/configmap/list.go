@@ -48,7 +48,7 @@ type ConfigMap struct {
 func GetConfigMapList(client kubernetes.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*ConfigMapList, error) {
 	klog.V(4).Infof("Getting list config maps in the namespace %s", nsQuery.ToRequestParam())
 	channels := &common.ResourceChannels{
-		ConfigMapList: common.GetConfigMapListChannel(client, nsQuery, 1),
+		ConfigMapList: common.GetConfigMapListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 	}
 
 	return GetConfigMapListFromChannels(channels, dsQuery)
@@ -64,6 +64,7 @@ func GetConfigMapListFromChannels(channels *common.ResourceChannels, dsQuery *da
 	}
 
 	result := toConfigMapList(configMaps.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(configMaps.ListMeta)
 
 	return result, nil
 }
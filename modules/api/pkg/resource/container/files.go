@@ -0,0 +1,389 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"k8s.io/dashboard/api/pkg/terminal"
+	derrors "k8s.io/dashboard/errors"
+)
+
+// FileType is the type of the file in the container.
+type FileType string
+
+const (
+	FileTypeFile      FileType = "file"
+	FileTypeDirectory FileType = "directory"
+	FileTypeSymlink   FileType = "symlink"
+	FileTypeOther     FileType = "other"
+)
+
+// File is a single entry of the directory in the container.
+type File struct {
+	Name             string      `json:"name"`
+	Type             FileType    `json:"type"`
+	Size             int64       `json:"size"`
+	ModificationTime metaV1.Time `json:"modificationTime"`
+}
+
+// FileList is a list of files in the directory of the container.
+type FileList struct {
+	Path  string `json:"path"`
+	Files []File `json:"files"`
+}
+
+// Download is the content of the downloaded file. Directories are downloaded as tar archives.
+type Download struct {
+	io.ReadCloser
+	// Name of the downloaded file, ending with .tar for archives.
+	Name    string
+	Archive bool
+}
+
+// Executor runs the command in the container, copying stdin to the command and its output to stdout. Commands that
+// exit with non-zero code return bad request errors with the error output of the command.
+type Executor interface {
+	Exec(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error
+}
+
+type podExecutor struct {
+	client    kubernetes.Interface
+	config    *rest.Config
+	namespace string
+	pod       string
+	container string
+}
+
+// NewExecutor returns executor running commands in the container of the pod, the same way as kubectl exec does.
+func NewExecutor(client kubernetes.Interface, config *rest.Config, namespace, pod, container string) Executor {
+	return &podExecutor{client: client, config: config, namespace: namespace, pod: pod, container: container}
+}
+
+func (self *podExecutor) Exec(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error {
+	req := self.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(self.pod).
+		Namespace(self.namespace).
+		SubResource("exec")
+
+	req.VersionedParams(&v1.PodExecOptions{
+		Container: self.container,
+		Command:   command,
+		Stdin:     stdin != nil,
+		Stdout:    true,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
+
+	exec, err := terminal.NewExecutor(self.config, req.URL())
+	if err != nil {
+		return err
+	}
+
+	stderr := new(bytes.Buffer)
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{Stdin: stdin, Stdout: stdout, Stderr: stderr})
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && stderr.Len() > 0 {
+		return derrors.NewBadRequest(strings.TrimSpace(stderr.String()))
+	}
+
+	return err
+}
+
+// ListFiles lists the directory in the container. It requires find and stat commands in the container.
+func ListFiles(ctx context.Context, exec Executor, dir string) (*FileList, error) {
+	dir, err := cleanPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trailing slash makes find follow the directory when it is a symlink
+	output := new(bytes.Buffer)
+	err = exec.Exec(ctx, []string{"find", strings.TrimSuffix(dir, "/") + "/", "-mindepth", "1",
+		"-maxdepth", "1", "-exec", "stat", "-c", statFormat, "{}", "+"}, nil, output)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := parseStat(output.String())
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if (files[i].Type == FileTypeDirectory) != (files[j].Type == FileTypeDirectory) {
+			return files[i].Type == FileTypeDirectory
+		}
+
+		return files[i].Name < files[j].Name
+	})
+
+	return &FileList{Path: dir, Files: files}, nil
+}
+
+// DownloadFile streams the file from the container. Regular files are returned as they are and directories as tar
+// archives. Symlinks are followed, so the target is downloaded under the name of the link. Files larger than the
+// limit are rejected and the download is stopped when it grows over the limit.
+func DownloadFile(ctx context.Context, exec Executor, filePath string, limit int64) (*Download, error) {
+	filePath, err := cleanPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := statFile(ctx, exec, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	size := file.Size
+	if file.Type == FileTypeDirectory {
+		if size, err = directorySize(ctx, exec, filePath); err != nil {
+			return nil, err
+		}
+	}
+
+	if size > limit {
+		return nil, derrors.NewBadRequest(fmt.Sprintf("%s has %d bytes, which exceeds the limit of %d bytes",
+			filePath, size, limit))
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		err := exec.Exec(ctx, []string{"tar", "chf", "-", "-C", path.Dir(filePath), "--", path.Base(filePath)}, nil,
+			&limitedWriter{writer: writer, remaining: limit + tarOverhead})
+		_ = writer.CloseWithError(err)
+	}()
+
+	if file.Type == FileTypeDirectory {
+		return &Download{ReadCloser: reader, Name: file.Name + ".tar", Archive: true}, nil
+	}
+
+	// The archive has just the single file, so its content can be streamed without the tar headers
+	archive := tar.NewReader(reader)
+	if _, err := archive.Next(); err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+
+	return &Download{ReadCloser: readCloser{Reader: archive, Closer: reader}, Name: file.Name}, nil
+}
+
+// UploadFile writes the content to the file in the container. The size has to be known in advance, because it is a
+// part of the tar header.
+func UploadFile(ctx context.Context, exec Executor, filePath string, content io.Reader, size, limit int64) error {
+	filePath, err := cleanPath(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := checkUploadSize(size, limit); err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		archive := tar.NewWriter(writer)
+		err := archive.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Base(filePath),
+			Size:     size,
+			Mode:     0644,
+			ModTime:  time.Now(),
+		})
+		if err == nil {
+			_, err = io.Copy(archive, io.LimitReader(content, size))
+		}
+		if err == nil {
+			err = archive.Close()
+		}
+		_ = writer.CloseWithError(err)
+	}()
+	defer reader.Close()
+
+	return exec.Exec(ctx, []string{"tar", "xf", "-", "-C", path.Dir(filePath), "--"}, reader, io.Discard)
+}
+
+// UploadArchive extracts the tar archive to the directory in the container.
+func UploadArchive(ctx context.Context, exec Executor, dir string, archive io.Reader, size, limit int64) error {
+	dir, err := cleanPath(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := checkUploadSize(size, limit); err != nil {
+		return err
+	}
+
+	return exec.Exec(ctx, []string{"tar", "xf", "-", "-C", dir, "--"}, io.LimitReader(archive, size), io.Discard)
+}
+
+const (
+	// statFormat prints raw mode in hex, size, modification time and name separated by '|'.
+	statFormat = "%f|%s|%Y|%n"
+
+	// tarOverhead is the space used by tar headers and padding on top of the file sizes.
+	tarOverhead = 1 << 20
+)
+
+// statFile returns the file that the path points to, following symlinks.
+func statFile(ctx context.Context, exec Executor, filePath string) (*File, error) {
+	output := new(bytes.Buffer)
+	if err := exec.Exec(ctx, []string{"stat", "-L", "-c", statFormat, "--", filePath}, nil, output); err != nil {
+		return nil, err
+	}
+
+	files, err := parseStat(output.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) != 1 {
+		return nil, fmt.Errorf("unexpected output of stat: %q", output.String())
+	}
+
+	return &files[0], nil
+}
+
+// directorySize returns disk usage of the directory, rounded up to kilobytes. Symlinks are followed the same way
+// as by the download.
+func directorySize(ctx context.Context, exec Executor, dir string) (int64, error) {
+	output := new(bytes.Buffer)
+	if err := exec.Exec(ctx, []string{"du", "-skL", "--", dir}, nil, output); err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(output.String())
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected output of du: %q", output.String())
+	}
+
+	kilobytes, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected output of du: %q", output.String())
+	}
+
+	return kilobytes * 1024, nil
+}
+
+func parseStat(output string) ([]File, error) {
+	files := make([]File, 0)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+
+		fields := strings.SplitN(line, "|", 4)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected output of stat: %q", line)
+		}
+
+		mode, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected mode %q of %s", fields[0], fields[3])
+		}
+
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected size %q of %s", fields[1], fields[3])
+		}
+
+		modified, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected modification time %q of %s", fields[2], fields[3])
+		}
+
+		files = append(files, File{
+			Name:             path.Base(fields[3]),
+			Type:             fileType(uint32(mode)),
+			Size:             size,
+			ModificationTime: metaV1.Unix(modified, 0),
+		})
+	}
+
+	return files, nil
+}
+
+// fileType returns type of the file from its raw mode, as defined by the S_IFMT bits.
+func fileType(mode uint32) FileType {
+	switch mode & 0170000 {
+	case 0100000:
+		return FileTypeFile
+	case 0040000:
+		return FileTypeDirectory
+	case 0120000:
+		return FileTypeSymlink
+	default:
+		return FileTypeOther
+	}
+}
+
+func cleanPath(filePath string) (string, error) {
+	if !path.IsAbs(filePath) {
+		return "", derrors.NewBadRequest(fmt.Sprintf("path %q has to be absolute", filePath))
+	}
+
+	return path.Clean(filePath), nil
+}
+
+func checkUploadSize(size, limit int64) error {
+	if size < 0 {
+		return derrors.NewBadRequest("size of the uploaded content has to be known in advance")
+	}
+
+	if size > limit {
+		return derrors.NewBadRequest(fmt.Sprintf("uploaded content has %d bytes, which exceeds the limit "+
+			"of %d bytes", size, limit))
+	}
+
+	return nil
+}
+
+// limitedWriter fails once more than the remaining bytes are written, which stops the command streaming the output.
+type limitedWriter struct {
+	writer    io.Writer
+	remaining int64
+}
+
+func (self *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > self.remaining {
+		return 0, derrors.NewBadRequest("downloaded content exceeds the size limit")
+	}
+
+	self.remaining -= int64(len(p))
+	return self.writer.Write(p)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/dashboard/errors"
+)
+
+// fakeExecutor returns the output of commands by their name. Archives passed to tar are read, so tests can check
+// the extracted files.
+type fakeExecutor struct {
+	outputs   map[string]string
+	commands  [][]string
+	extracted map[string]string
+}
+
+func (self *fakeExecutor) Exec(_ context.Context, command []string, stdin io.Reader, stdout io.Writer) error {
+	self.commands = append(self.commands, command)
+	if command[0] == "tar" && command[1] == "xf" {
+		archive := tar.NewReader(stdin)
+		for {
+			header, err := archive.Next()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			content, err := io.ReadAll(archive)
+			if err != nil {
+				return err
+			}
+			self.extracted[header.Name] = string(content)
+		}
+	}
+
+	output, ok := self.outputs[command[0]]
+	if !ok {
+		return errors.NewBadRequest(command[0] + ": not found")
+	}
+
+	_, err := io.WriteString(stdout, output)
+	return err
+}
+
+func newArchive(name, content string) string {
+	buf := new(bytes.Buffer)
+	archive := tar.NewWriter(buf)
+	_ = archive.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(len(content)), Mode: 0644})
+	_, _ = archive.Write([]byte(content))
+	_ = archive.Close()
+	return buf.String()
+}
+
+func TestListFiles(t *testing.T) {
+	exec := &fakeExecutor{outputs: map[string]string{
+		"find": "81a4|1024|1700000000|/tmp/dump.hprof\n41ed|4096|1700000100|/tmp/logs\na1ff|7|1700000200|/tmp/link\n",
+	}}
+
+	actual, err := ListFiles(context.TODO(), exec, "/tmp/")
+	if err != nil {
+		t.Fatalf("ListFiles() returned error: %v", err)
+	}
+
+	expected := &FileList{Path: "/tmp", Files: []File{
+		{Name: "logs", Type: FileTypeDirectory, Size: 4096, ModificationTime: metaV1.Unix(1700000100, 0)},
+		{Name: "dump.hprof", Type: FileTypeFile, Size: 1024, ModificationTime: metaV1.Unix(1700000000, 0)},
+		{Name: "link", Type: FileTypeSymlink, Size: 7, ModificationTime: metaV1.Unix(1700000200, 0)},
+	}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ListFiles() == \n%#v\nexpected \n%#v", actual, expected)
+	}
+
+	if _, err := ListFiles(context.TODO(), exec, "tmp"); !errors.IsBadRequest(err) {
+		t.Errorf("ListFiles() of relative path should return bad request error, got %v", err)
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	cases := []struct {
+		info            string
+		outputs         map[string]string
+		limit           int64
+		expected        string
+		expectedName    string
+		expectedArchive bool
+		expectedError   bool
+	}{
+		{
+			"regular file is downloaded without tar headers",
+			map[string]string{"stat": "81a4|4|1700000000|/tmp/dump.hprof\n", "tar": newArchive("dump.hprof", "heap")},
+			1024, "heap", "dump.hprof", false, false,
+		},
+		{
+			"directory is downloaded as archive",
+			map[string]string{"stat": "41ed|4096|1700000000|/tmp/logs\n", "du": "4\t/tmp/logs\n",
+				"tar": newArchive("logs/app.log", "line")},
+			1 << 20, newArchive("logs/app.log", "line"), "logs.tar", true, false,
+		},
+		{
+			"file over the limit",
+			map[string]string{"stat": "81a4|2048|1700000000|/tmp/dump.hprof\n"},
+			1024, "", "", false, true,
+		},
+		{
+			"directory over the limit",
+			map[string]string{"stat": "41ed|4096|1700000000|/tmp/logs\n", "du": "2048\t/tmp/logs\n"},
+			1 << 20, "", "", false, true,
+		},
+	}
+
+	for _, c := range cases {
+		download, err := DownloadFile(context.TODO(), &fakeExecutor{outputs: c.outputs}, "/tmp/file", c.limit)
+		if c.expectedError {
+			if !errors.IsBadRequest(err) {
+				t.Errorf("DownloadFile() of %s should return bad request error, got %v", c.info, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("DownloadFile() of %s returned error: %v", c.info, err)
+		}
+
+		content, err := io.ReadAll(download)
+		_ = download.Close()
+		if err != nil {
+			t.Fatalf("reading download of %s returned error: %v", c.info, err)
+		}
+
+		if string(content) != c.expected || download.Name != c.expectedName || download.Archive != c.expectedArchive {
+			t.Errorf("DownloadFile() of %s == %q (%s, archive %t), expected %q (%s, archive %t)", c.info, content,
+				download.Name, download.Archive, c.expected, c.expectedName, c.expectedArchive)
+		}
+	}
+}
+
+func TestDownloadFileFollowsSymlinks(t *testing.T) {
+	exec := &fakeExecutor{outputs: map[string]string{"stat": "41ed|4096|1700000000|/tmp/current\n",
+		"du": "4\t/tmp/current\n", "tar": newArchive("current/app.log", "line")}}
+	download, err := DownloadFile(context.TODO(), exec, "/tmp/current", 1<<20)
+	if err != nil {
+		t.Fatalf("DownloadFile() returned error: %v", err)
+	}
+
+	_, _ = io.ReadAll(download)
+	_ = download.Close()
+
+	expectedCommands := [][]string{
+		{"stat", "-L", "-c", statFormat, "--", "/tmp/current"},
+		{"du", "-skL", "--", "/tmp/current"},
+		{"tar", "chf", "-", "-C", "/tmp", "--", "current"},
+	}
+	if !reflect.DeepEqual(exec.commands, expectedCommands) {
+		t.Errorf("DownloadFile() executed \n%#v\nexpected \n%#v", exec.commands, expectedCommands)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	exec := &fakeExecutor{extracted: map[string]string{}}
+	if err := UploadFile(context.TODO(), exec, "/tmp/config.yaml", strings.NewReader("key: value"), 10, 1024); err != nil {
+		t.Fatalf("UploadFile() returned error: %v", err)
+	}
+
+	expectedCommands := [][]string{{"tar", "xf", "-", "-C", "/tmp", "--"}}
+	if !reflect.DeepEqual(exec.commands, expectedCommands) {
+		t.Errorf("UploadFile() executed \n%#v\nexpected \n%#v", exec.commands, expectedCommands)
+	}
+
+	expected := map[string]string{"config.yaml": "key: value"}
+	if !reflect.DeepEqual(exec.extracted, expected) {
+		t.Errorf("UploadFile() extracted \n%#v\nexpected \n%#v", exec.extracted, expected)
+	}
+
+	for _, size := range []int64{-1, 2048} {
+		err := UploadFile(context.TODO(), exec, "/tmp/config.yaml", strings.NewReader("key: value"), size, 1024)
+		if !errors.IsBadRequest(err) {
+			t.Errorf("UploadFile() of %d bytes should return bad request error, got %v", size, err)
+		}
+	}
+}
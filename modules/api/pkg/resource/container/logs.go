@@ -53,9 +53,11 @@ func GetPodContainers(client kubernetes.Interface, namespace, podID string) (*Po
 }
 
 // GetLogDetails returns logs for particular pod and container. When container is null, logs for the first one
-// are returned. Previous indicates to read archived logs created by log rotation or container crash
+// are returned. Previous indicates to read archived logs created by log rotation or container crash. When field
+// filter is set, only structured lines matching it are returned. Structured indicates to return fields of
+// structured lines.
 func GetLogDetails(client kubernetes.Interface, namespace, podID string, container string,
-	logSelector *logs.Selection, usePreviousLogs bool) (*logs.LogDetails, error) {
+	logSelector *logs.Selection, fieldFilter *logs.FieldFilter, structured, usePreviousLogs bool) (*logs.LogDetails, error) {
 	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podID, metaV1.GetOptions{})
 	if err != nil {
 		return nil, err
@@ -70,10 +72,46 @@ func GetLogDetails(client kubernetes.Interface, namespace, podID string, contain
 	if err != nil {
 		return nil, err
 	}
-	details := ConstructLogDetails(podID, rawLogs, container, logSelector)
+	details := ConstructFilteredLogDetails(podID, rawLogs, container, logSelector, fieldFilter, structured)
 	return details, nil
 }
 
+// SearchLogs returns lines of particular pod and container that match the search query. When container is null,
+// logs of the first one are searched. Previous indicates to search archived logs created by log rotation or
+// container crash. Log file position decides whether the beginning or the end of large log files is searched.
+func SearchLogs(client kubernetes.Interface, namespace, podID string, container string, query *logs.SearchQuery,
+	logFilePosition string, usePreviousLogs bool) (*logs.LogSearchResult, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podID, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(container) == 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	logOptions := mapToLogOptions(container, &logs.Selection{LogFilePosition: logFilePosition}, usePreviousLogs)
+	if query.SinceTime != nil {
+		// Skip older lines already on the apiserver side.
+		logOptions.SinceTime = &metaV1.Time{Time: *query.SinceTime}
+	}
+
+	rawLogs, err := readRawLogs(client, namespace, podID, logOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, total := logs.ToLogLines(rawLogs).Search(query)
+	return &logs.LogSearchResult{
+		Info: logs.LogInfo{
+			PodName:       podID,
+			ContainerName: container,
+		},
+		Matches:      matches,
+		TotalMatches: total,
+	}, nil
+}
+
 // Maps the log selection to the corresponding api object
 // Read limits are set to avoid out of memory issues
 func mapToLogOptions(container string, logSelector *logs.Selection, previous bool) *v1.PodLogOptions {
@@ -125,18 +163,34 @@ func GetLogFile(client kubernetes.Interface, namespace, podID string, container
 }
 
 func openStream(client kubernetes.Interface, namespace, podID string, logOptions *v1.PodLogOptions) (io.ReadCloser, error) {
+	return openStreamWithContext(context.TODO(), client, namespace, podID, logOptions)
+}
+
+func openStreamWithContext(ctx context.Context, client kubernetes.Interface, namespace, podID string,
+	logOptions *v1.PodLogOptions) (io.ReadCloser, error) {
 	return client.CoreV1().RESTClient().Get().
 		Namespace(namespace).
 		Name(podID).
 		Resource("pods").
 		SubResource("log").
-		VersionedParams(logOptions, scheme.ParameterCodec).Stream(context.TODO())
+		VersionedParams(logOptions, scheme.ParameterCodec).Stream(ctx)
 }
 
 // ConstructLogDetails creates a new log details structure for given parameters.
 func ConstructLogDetails(podID string, rawLogs string, container string, logSelector *logs.Selection) *logs.LogDetails {
+	return ConstructFilteredLogDetails(podID, rawLogs, container, logSelector, nil, false)
+}
+
+// ConstructFilteredLogDetails creates a new log details structure for given parameters. Selection is applied
+// to the lines that match the field filter, see logs.LogLines.SelectFilteredLogs. Structured content is parsed only
+// when the field filter is set or structured is true.
+func ConstructFilteredLogDetails(podID string, rawLogs string, container string, logSelector *logs.Selection,
+	fieldFilter *logs.FieldFilter, structured bool) *logs.LogDetails {
 	parsedLines := logs.ToLogLines(rawLogs)
-	logLines, fromDate, toDate, logSelection, lastPage := parsedLines.SelectLogs(logSelector)
+	if fieldFilter != nil || structured {
+		parsedLines = parsedLines.WithFields()
+	}
+	logLines, fromDate, toDate, logSelection, lastPage := parsedLines.SelectFilteredLogs(fieldFilter, logSelector)
 
 	readLimitReached := isReadLimitReached(int64(len(rawLogs)), int64(len(parsedLines)), logSelector.LogFilePosition)
 	truncated := readLimitReached && lastPage
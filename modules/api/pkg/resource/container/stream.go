@@ -0,0 +1,333 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/resource/logs"
+	derrors "k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// DefaultStreamTailLines is the number of lines loaded from every container when the stream starts.
+var DefaultStreamTailLines int64 = 100
+
+// maximum number of containers followed by a single stream
+var maxStreamedContainers = 100
+
+// interval in which collected lines are sorted and sent to the client
+var streamFlushInterval = 500 * time.Millisecond
+
+// maximum size of a single log line read from the apiserver
+const maxLogLineSize = 1024 * 1024
+
+// StreamedLogLine is a single log line of a merged log stream tagged with its source.
+type StreamedLogLine struct {
+	PodName       string `json:"podName"`
+	ContainerName string `json:"containerName"`
+	logs.LogLine
+
+	// time is the parsed timestamp of the line. Timestamps cannot be compared as strings, as the kubelet trims
+	// trailing zeros of fractional seconds.
+	time time.Time
+}
+
+// StreamOptions configures merged log stream.
+type StreamOptions struct {
+	// TailLines is the number of lines loaded from every container when it is added to the stream.
+	TailLines int64
+}
+
+// logStreamer follows logs of all containers of all pods selected by a controller.
+type logStreamer struct {
+	client    kubernetes.Interface
+	namespace string
+	opts      StreamOptions
+	lines     chan StreamedLogLine
+
+	mu sync.Mutex
+	// followed maps pod UID and container name to the time of the last received line.
+	followed map[string]time.Time
+	// active holds containers that currently have an open log stream.
+	active map[string]struct{}
+}
+
+// StreamLogs follows logs of all containers of all pods selected by list options, see PodListOptions, until the
+// context is done. Pods created after the stream has started, i.e. during a rollout, are followed as well. Lines are
+// interleaved by timestamp and sent in batches. The batches channel is closed when StreamLogs returns. When pods can
+// not be listed or watched, the stream is stopped and the error is returned.
+func StreamLogs(ctx context.Context, client kubernetes.Interface, namespace string, listOptions metaV1.ListOptions,
+	opts StreamOptions, batches chan<- []StreamedLogLine) error {
+	defer close(batches)
+
+	streamer := &logStreamer{
+		client:    client,
+		namespace: namespace,
+		opts:      opts,
+		lines:     make(chan StreamedLogLine),
+		followed:  make(map[string]time.Time),
+		active:    make(map[string]struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- streamer.watchPods(ctx, listOptions)
+		cancel()
+	}()
+
+	streamer.merge(ctx, batches)
+	cancel()
+	return <-watchErr
+}
+
+// PodListOptions returns options selecting pods that belong to the given resource. It returns bad request error when
+// streaming logs of the resource type is not supported.
+func PodListOptions(ctx context.Context, client kubernetes.Interface, namespace, resourceName, resourceType string) (
+	metaV1.ListOptions, error) {
+	var selector *metaV1.LabelSelector
+	switch strings.ToLower(resourceType) {
+	case types.ResourceKindPod:
+		return metaV1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", resourceName).String()}, nil
+	case types.ResourceKindDeployment:
+		deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, resourceName, metaV1.GetOptions{})
+		if err != nil {
+			return metaV1.ListOptions{}, err
+		}
+		selector = deployment.Spec.Selector
+	case types.ResourceKindReplicaSet:
+		replicaSet, err := client.AppsV1().ReplicaSets(namespace).Get(ctx, resourceName, metaV1.GetOptions{})
+		if err != nil {
+			return metaV1.ListOptions{}, err
+		}
+		selector = replicaSet.Spec.Selector
+	case types.ResourceKindStatefulSet:
+		statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(ctx, resourceName, metaV1.GetOptions{})
+		if err != nil {
+			return metaV1.ListOptions{}, err
+		}
+		selector = statefulSet.Spec.Selector
+	case types.ResourceKindDaemonSet:
+		daemonSet, err := client.AppsV1().DaemonSets(namespace).Get(ctx, resourceName, metaV1.GetOptions{})
+		if err != nil {
+			return metaV1.ListOptions{}, err
+		}
+		selector = daemonSet.Spec.Selector
+	case types.ResourceKindJob:
+		job, err := client.BatchV1().Jobs(namespace).Get(ctx, resourceName, metaV1.GetOptions{})
+		if err != nil {
+			return metaV1.ListOptions{}, err
+		}
+		selector = job.Spec.Selector
+	default:
+		return metaV1.ListOptions{}, derrors.NewBadRequest(fmt.Sprintf("streaming logs of %s is not supported", resourceType))
+	}
+
+	labelSelector, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return metaV1.ListOptions{}, err
+	}
+
+	return metaV1.ListOptions{LabelSelector: labelSelector.String()}, nil
+}
+
+// watchPods lists and watches selected pods and starts following logs of their containers. It returns nil once
+// the context is done.
+func (self *logStreamer) watchPods(ctx context.Context, listOptions metaV1.ListOptions) error {
+	for ctx.Err() == nil {
+		podList, err := self.client.CoreV1().Pods(self.namespace).List(ctx, listOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("could not list pods for log stream: %w", err)
+		}
+
+		for i := range podList.Items {
+			self.follow(ctx, &podList.Items[i])
+		}
+
+		opts := listOptions
+		opts.ResourceVersion = podList.ResourceVersion
+		watcher, err := self.client.CoreV1().Pods(self.namespace).Watch(ctx, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("could not watch pods for log stream: %w", err)
+		}
+
+		for event := range watcher.ResultChan() {
+			if pod, ok := event.Object.(*v1.Pod); ok && (event.Type == watch.Added || event.Type == watch.Modified) {
+				self.follow(ctx, pod)
+			}
+		}
+
+		// Watch has expired, start again from the fresh list.
+		watcher.Stop()
+	}
+
+	return nil
+}
+
+// follow starts following logs of all pod containers that have started and are not followed yet.
+func (self *logStreamer) follow(ctx context.Context, pod *v1.Pod) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running == nil && status.State.Terminated == nil {
+			continue
+		}
+
+		key := string(pod.UID) + "/" + status.Name
+		if _, isActive := self.active[key]; isActive {
+			continue
+		}
+
+		since, isFollowed := self.followed[key]
+		if !isFollowed && len(self.followed) >= maxStreamedContainers {
+			klog.V(4).InfoS("Too many containers in log stream, skipping", "pod", pod.Name, "container", status.Name)
+			continue
+		}
+
+		// Terminated containers that have been read already will not produce any new lines.
+		if isFollowed && status.State.Terminated != nil {
+			continue
+		}
+
+		self.followed[key] = since
+		self.active[key] = struct{}{}
+		go self.followContainer(ctx, key, pod.Name, status.Name, since)
+	}
+}
+
+// followContainer reads the container log until it ends or the context is done. When the log is opened again,
+// i.e. after container restart, only lines newer than the last received one are read.
+func (self *logStreamer) followContainer(ctx context.Context, key, podName, containerName string, since time.Time) {
+	defer func() {
+		self.mu.Lock()
+		delete(self.active, key)
+		self.mu.Unlock()
+	}()
+
+	logOptions := &v1.PodLogOptions{
+		Container:  containerName,
+		Follow:     true,
+		Timestamps: true,
+	}
+
+	if !since.IsZero() {
+		logOptions.SinceTime = &metaV1.Time{Time: since}
+	} else {
+		logOptions.TailLines = &self.opts.TailLines
+	}
+
+	readCloser, err := openStreamWithContext(ctx, self.client, self.namespace, podName, logOptions)
+	if err != nil {
+		klog.V(4).InfoS("Could not open log stream", "pod", podName, "container", containerName, "error", err)
+		return
+	}
+	defer readCloser.Close()
+
+	scanner := bufio.NewScanner(readCloser)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		line, lineTime := toLogLine(scanner.Text())
+		if !since.IsZero() && !lineTime.After(since) {
+			// Lines from the same second may be returned again when sinceTime is used.
+			continue
+		}
+
+		select {
+		case self.lines <- StreamedLogLine{PodName: podName, ContainerName: containerName, LogLine: line, time: lineTime}:
+		case <-ctx.Done():
+			return
+		}
+
+		if !lineTime.IsZero() {
+			self.mu.Lock()
+			self.followed[key] = lineTime
+			self.mu.Unlock()
+		}
+	}
+}
+
+// merge collects lines from all followed containers and periodically sends them sorted by timestamp.
+func (self *logStreamer) merge(ctx context.Context, batches chan<- []StreamedLogLine) {
+	ticker := time.NewTicker(streamFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]StreamedLogLine, 0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line := <-self.lines:
+			batch = append(batch, line)
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+
+			sortStreamedLogLines(batch)
+			select {
+			case batches <- batch:
+			case <-ctx.Done():
+				return
+			}
+			batch = make([]StreamedLogLine, 0)
+		}
+	}
+}
+
+// sortStreamedLogLines sorts lines by timestamp keeping the order of lines from the same container.
+func sortStreamedLogLines(lines []StreamedLogLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].time.Before(lines[j].time)
+	})
+}
+
+// toLogLine splits a single raw log line the same way as logs.ToLogLines does and parses its timestamp. Lines
+// without valid timestamp have zero time.
+func toLogLine(rawLine string) (logs.LogLine, time.Time) {
+	parsed := logs.ToLogLines(rawLine)
+	if len(parsed) == 0 {
+		return logs.LogLine{Timestamp: logs.LogTimestamp("0")}, time.Time{}
+	}
+
+	lineTime, err := time.Parse(time.RFC3339Nano, string(parsed[0].Timestamp))
+	if err != nil {
+		return parsed[0], time.Time{}
+	}
+
+	return parsed[0], lineTime
+}
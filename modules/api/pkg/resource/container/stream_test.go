@@ -0,0 +1,162 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/dashboard/api/pkg/resource/logs"
+)
+
+func TestSortStreamedLogLines(t *testing.T) {
+	streamedLine := func(podName, rawLine string) StreamedLogLine {
+		line, lineTime := toLogLine(rawLine)
+		return StreamedLogLine{PodName: podName, ContainerName: "c", LogLine: line, time: lineTime}
+	}
+
+	// The kubelet trims trailing zeros of fractional seconds, so timestamps do not sort as strings.
+	lines := []StreamedLogLine{
+		streamedLine("a", "2024-01-01T00:00:05.15Z log3"),
+		streamedLine("b", "2024-01-01T00:00:05Z log1"),
+		streamedLine("a", "2024-01-01T00:00:05.15Z log3-2"),
+		streamedLine("b", "2024-01-01T00:00:05.1Z log2"),
+		streamedLine("b", "2024-01-01T00:00:04.999999999Z log0"),
+	}
+	expected := []string{"log0", "log1", "log2", "log3", "log3-2"}
+
+	sortStreamedLogLines(lines)
+	actual := make([]string, 0, len(lines))
+	for _, line := range lines {
+		actual = append(actual, line.Content)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("sortStreamedLogLines() == %v, expected %v", actual, expected)
+	}
+}
+
+func TestToLogLine(t *testing.T) {
+	cases := []struct {
+		raw          string
+		expected     logs.LogLine
+		expectedTime time.Time
+	}{
+		{
+			"2017-01-01T00:00:00.000000000Z hello world",
+			logs.LogLine{Timestamp: "2017-01-01T00:00:00.000000000Z", Content: "hello world"},
+			time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"2017-01-01T00:00:05.1Z hello world",
+			logs.LogLine{Timestamp: "2017-01-01T00:00:05.1Z", Content: "hello world"},
+			time.Date(2017, 1, 1, 0, 0, 5, 100000000, time.UTC),
+		},
+		{"no timestamp", logs.LogLine{Timestamp: "0", Content: "no timestamp"}, time.Time{}},
+		{"", logs.LogLine{Timestamp: "0"}, time.Time{}},
+	}
+
+	for _, c := range cases {
+		actual, actualTime := toLogLine(c.raw)
+		if !reflect.DeepEqual(actual, c.expected) || !actualTime.Equal(c.expectedTime) {
+			t.Errorf("toLogLine(%#v) == %#v, %v, expected %#v, %v", c.raw, actual, actualTime, c.expected,
+				c.expectedTime)
+		}
+	}
+}
+
+func TestPodListOptions(t *testing.T) {
+	client := fake.NewSimpleClientset(&apps.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Name: "deployment", Namespace: "ns"},
+		Spec: apps.DeploymentSpec{
+			Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+		},
+	})
+
+	cases := []struct {
+		resourceName, resourceType string
+		expected                   metaV1.ListOptions
+		expectedErr                func(error) bool
+	}{
+		{"deployment", "deployment", metaV1.ListOptions{LabelSelector: "app=test"}, nil},
+		{"pod", "pod", metaV1.ListOptions{FieldSelector: "metadata.name=pod"}, nil},
+		{"missing", "deployment", metaV1.ListOptions{}, errors.IsNotFound},
+		{"service", "service", metaV1.ListOptions{}, errors.IsBadRequest},
+	}
+
+	for _, c := range cases {
+		actual, err := PodListOptions(context.TODO(), client, "ns", c.resourceName, c.resourceType)
+		if (err != nil) != (c.expectedErr != nil) || (err != nil && !c.expectedErr(err)) {
+			t.Errorf("PodListOptions(%s, %s) returned error %v", c.resourceName, c.resourceType, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("PodListOptions(%s, %s) == %#v, expected %#v", c.resourceName, c.resourceType, actual, c.expected)
+		}
+	}
+}
+
+func TestStreamLogsWatchError(t *testing.T) {
+	cases := []struct {
+		verb string
+	}{
+		{"list"},
+		{"watch"},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset()
+		forbidden := errors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil)
+		if c.verb == "watch" {
+			client.PrependWatchReactor("pods", func(clienttesting.Action) (bool, watch.Interface, error) {
+				return true, nil, forbidden
+			})
+		} else {
+			client.PrependReactor(c.verb, "pods", func(clienttesting.Action) (bool, runtime.Object, error) {
+				return true, nil, forbidden
+			})
+		}
+
+		batches := make(chan []StreamedLogLine)
+		result := make(chan error, 1)
+		go func() {
+			result <- StreamLogs(context.TODO(), client, "ns", metaV1.ListOptions{}, StreamOptions{}, batches)
+		}()
+
+		select {
+		case err := <-result:
+			if !errors.IsForbidden(err) {
+				t.Errorf("StreamLogs() with failing %s returned %v, expected forbidden error", c.verb, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("StreamLogs() with failing %s should stop the stream", c.verb)
+		}
+
+		if _, ok := <-batches; ok {
+			t.Errorf("StreamLogs() with failing %s should close the batches channel", c.verb)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllerrevision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// ChangeCauseAnnotationKey is an annotation key used to record the cause of the change, i.e. by `kubectl annotate`.
+const ChangeCauseAnnotationKey = "kubernetes.io/change-cause"
+
+// ContainerImage is an image used by the container of the pod template.
+type ContainerImage struct {
+	Container string `json:"container"`
+	Image     string `json:"image"`
+}
+
+// ImageChange is a change of the container image between two revisions. From is empty for added containers and To
+// is empty for removed ones.
+type ImageChange struct {
+	Container string `json:"container"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+// Revision is a single revision of a StatefulSet or DaemonSet pod template stored in the ControllerRevision.
+type Revision struct {
+	ObjectMeta types.ObjectMeta `json:"objectMeta"`
+	TypeMeta   types.TypeMeta   `json:"typeMeta"`
+
+	// Revision number.
+	Revision int64 `json:"revision"`
+
+	// ChangeCause is the value of the change-cause annotation.
+	ChangeCause string `json:"changeCause"`
+
+	// Current is true for the revision that the pods are being updated to.
+	Current bool `json:"current"`
+
+	// Images used by the pod template containers, including init containers.
+	Images []ContainerImage `json:"images"`
+
+	// ImageChanges compared to the previous revision.
+	ImageChanges []ImageChange `json:"imageChanges"`
+}
+
+// RevisionHistory contains revisions of a StatefulSet or DaemonSet sorted from the oldest to the newest one.
+type RevisionHistory struct {
+	ListMeta  types.ListMeta `json:"listMeta"`
+	Revisions []Revision     `json:"revisions"`
+}
+
+// RevisionDiff is a difference of the pod templates between two revisions.
+type RevisionDiff struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+
+	// ImageChanges between the revisions.
+	ImageChanges []ImageChange `json:"imageChanges"`
+
+	// TemplateDiff is a unified diff of the pod templates in the YAML format.
+	TemplateDiff string `json:"templateDiff"`
+}
+
+// revisionData is a part of the ControllerRevision data that is common for StatefulSets and DaemonSets.
+type revisionData struct {
+	Spec struct {
+		Template v1.PodTemplateSpec `json:"template"`
+	} `json:"spec"`
+}
+
+// GetRevisionHistory returns revisions of the StatefulSet or DaemonSet with the given name.
+func GetRevisionHistory(client kubernetes.Interface, kind, namespace, name string) (*RevisionHistory, error) {
+	owner, err := getOwner(client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := getControllerRevisions(client, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RevisionHistory{
+		ListMeta:  types.ListMeta{TotalItems: len(revisions)},
+		Revisions: make([]Revision, 0, len(revisions)),
+	}
+
+	var previous []ContainerImage
+	for i := range revisions {
+		template, err := toPodTemplate(&revisions[i])
+		if err != nil {
+			return nil, err
+		}
+
+		images := getContainerImages(template)
+		result.Revisions = append(result.Revisions, Revision{
+			ObjectMeta:   types.NewObjectMeta(revisions[i].ObjectMeta),
+			TypeMeta:     types.NewTypeMeta(types.ResourceKindControllerRevision),
+			Revision:     revisions[i].Revision,
+			ChangeCause:  revisions[i].Annotations[ChangeCauseAnnotationKey],
+			Current:      owner.isCurrent(revisions, i),
+			Images:       images,
+			ImageChanges: getImageChanges(previous, images),
+		})
+		previous = images
+	}
+
+	return result, nil
+}
+
+// GetRevisionDiff returns the difference between pod templates of two revisions of the StatefulSet or DaemonSet.
+func GetRevisionDiff(client kubernetes.Interface, kind, namespace, name string, from, to int64) (*RevisionDiff, error) {
+	owner, err := getOwner(client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := getControllerRevisions(client, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	fromTemplate, err := findPodTemplate(revisions, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toTemplate, err := findPodTemplate(revisions, to)
+	if err != nil {
+		return nil, err
+	}
+
+	templateDiff, err := diffPodTemplates(fromTemplate, toTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevisionDiff{
+		From:         from,
+		To:           to,
+		ImageChanges: getImageChanges(getContainerImages(fromTemplate), getContainerImages(toTemplate)),
+		TemplateDiff: templateDiff,
+	}, nil
+}
+
+// getControllerRevisions returns revisions controlled by the owner sorted by the revision number.
+func getControllerRevisions(client kubernetes.Interface, owner *revisionOwner) ([]apps.ControllerRevision, error) {
+	selector, err := metaV1.LabelSelectorAsSelector(owner.selector)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.AppsV1().ControllerRevisions(owner.object.GetNamespace()).List(context.TODO(),
+		metaV1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]apps.ControllerRevision, 0)
+	for _, revision := range list.Items {
+		if metaV1.IsControlledBy(&revision, owner.object) {
+			result = append(result, revision)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Revision < result[j].Revision
+	})
+
+	return result, nil
+}
+
+func findRevision(revisions []apps.ControllerRevision, revision int64) (*apps.ControllerRevision, error) {
+	for i := range revisions {
+		if revisions[i].Revision == revision {
+			return &revisions[i], nil
+		}
+	}
+
+	return nil, errors.NewNotFound(fmt.Sprintf("revision %d not found", revision))
+}
+
+func findPodTemplate(revisions []apps.ControllerRevision, revision int64) (*v1.PodTemplateSpec, error) {
+	controllerRevision, err := findRevision(revisions, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPodTemplate(controllerRevision)
+}
+
+func toPodTemplate(revision *apps.ControllerRevision) (*v1.PodTemplateSpec, error) {
+	data := new(revisionData)
+	if err := json.Unmarshal(revision.Data.Raw, data); err != nil {
+		return nil, fmt.Errorf("failed to parse data of revision %d: %w", revision.Revision, err)
+	}
+
+	return &data.Spec.Template, nil
+}
+
+func getContainerImages(template *v1.PodTemplateSpec) []ContainerImage {
+	result := make([]ContainerImage, 0)
+	for _, container := range append(template.Spec.InitContainers, template.Spec.Containers...) {
+		result = append(result, ContainerImage{Container: container.Name, Image: container.Image})
+	}
+
+	return result
+}
+
+// getImageChanges returns changed, added and removed container images in the order of the containers.
+func getImageChanges(from, to []ContainerImage) []ImageChange {
+	fromImages := make(map[string]string)
+	for _, image := range from {
+		fromImages[image.Container] = image.Image
+	}
+
+	result := make([]ImageChange, 0)
+	for _, image := range to {
+		fromImage, exists := fromImages[image.Container]
+		if fromImage != image.Image && (exists || from != nil) {
+			result = append(result, ImageChange{Container: image.Container, From: fromImage, To: image.Image})
+		}
+		delete(fromImages, image.Container)
+	}
+
+	for _, image := range from {
+		if _, removed := fromImages[image.Container]; removed {
+			result = append(result, ImageChange{Container: image.Container, From: image.Image})
+		}
+	}
+
+	return result
+}
+
+func diffPodTemplates(from, to *v1.PodTemplateSpec) (string, error) {
+	fromYAML, err := yaml.Marshal(from)
+	if err != nil {
+		return "", err
+	}
+
+	toYAML, err := yaml.Marshal(to)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(string(fromYAML), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(toYAML), "\n")),
+		FromFile: "from",
+		ToFile:   "to",
+		Context:  3,
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllerrevision
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+var labels = map[string]string{"app": "db"}
+
+func newStatefulSet() *apps.StatefulSet {
+	return &apps.StatefulSet{
+		ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "ns", UID: "uid"},
+		Spec: apps.StatefulSetSpec{
+			Selector: &metaV1.LabelSelector{MatchLabels: labels},
+		},
+		Status: apps.StatefulSetStatus{UpdateRevision: "db-2"},
+	}
+}
+
+func newRevision(revision int64, image string) *apps.ControllerRevision {
+	controller := true
+	return &apps.ControllerRevision{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        fmt.Sprintf("db-%d", revision),
+			Namespace:   "ns",
+			Labels:      labels,
+			Annotations: map[string]string{ChangeCauseAnnotationKey: "set image " + image},
+			OwnerReferences: []metaV1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", UID: "uid", Controller: &controller},
+			},
+		},
+		Revision: revision,
+		Data: runtime.RawExtension{Raw: []byte(fmt.Sprintf(
+			`{"spec":{"template":{"$patch":"replace","spec":{"containers":[{"name":"db","image":"%s"}]}}}}`, image))},
+	}
+}
+
+func TestGetRevisionHistory(t *testing.T) {
+	client := fake.NewSimpleClientset(newStatefulSet(), newRevision(2, "db:2"), newRevision(1, "db:1"),
+		newRevision(3, "db:3"))
+
+	actual, err := GetRevisionHistory(client, types.ResourceKindStatefulSet, "ns", "db")
+	if err != nil {
+		t.Fatalf("GetRevisionHistory() returned error: %v", err)
+	}
+
+	type summary struct {
+		Revision     int64
+		ChangeCause  string
+		Current      bool
+		Images       []ContainerImage
+		ImageChanges []ImageChange
+	}
+
+	expected := []summary{
+		{1, "set image db:1", false, []ContainerImage{{"db", "db:1"}}, []ImageChange{}},
+		{2, "set image db:2", true, []ContainerImage{{"db", "db:2"}}, []ImageChange{{"db", "db:1", "db:2"}}},
+		{3, "set image db:3", false, []ContainerImage{{"db", "db:3"}}, []ImageChange{{"db", "db:2", "db:3"}}},
+	}
+
+	summaries := make([]summary, 0)
+	for _, revision := range actual.Revisions {
+		summaries = append(summaries, summary{revision.Revision, revision.ChangeCause, revision.Current,
+			revision.Images, revision.ImageChanges})
+	}
+
+	if !reflect.DeepEqual(summaries, expected) {
+		t.Errorf("GetRevisionHistory() == \n%#v\nexpected \n%#v", summaries, expected)
+	}
+}
+
+func TestGetRevisionDiff(t *testing.T) {
+	client := fake.NewSimpleClientset(newStatefulSet(), newRevision(1, "db:1"), newRevision(2, "db:2"))
+
+	actual, err := GetRevisionDiff(client, types.ResourceKindStatefulSet, "ns", "db", 1, 2)
+	if err != nil {
+		t.Fatalf("GetRevisionDiff() returned error: %v", err)
+	}
+
+	expectedChanges := []ImageChange{{Container: "db", From: "db:1", To: "db:2"}}
+	if !reflect.DeepEqual(actual.ImageChanges, expectedChanges) {
+		t.Errorf("GetRevisionDiff() image changes == %#v, expected %#v", actual.ImageChanges, expectedChanges)
+	}
+
+	if !strings.Contains(actual.TemplateDiff, "-  - image: db:1") ||
+		!strings.Contains(actual.TemplateDiff, "+  - image: db:2") {
+		t.Errorf("GetRevisionDiff() template diff does not contain image change:\n%s", actual.TemplateDiff)
+	}
+
+	if _, err := GetRevisionDiff(client, types.ResourceKindStatefulSet, "ns", "db", 1, 5); err == nil {
+		t.Error("GetRevisionDiff() should return error for missing revision")
+	}
+}
+
+func TestGetImageChanges(t *testing.T) {
+	cases := []struct {
+		from, to []ContainerImage
+		expected []ImageChange
+	}{
+		{nil, []ContainerImage{{"a", "a:1"}}, []ImageChange{}},
+		{
+			[]ContainerImage{{"a", "a:1"}, {"b", "b:1"}},
+			[]ContainerImage{{"a", "a:1"}, {"c", "c:1"}},
+			[]ImageChange{{"c", "", "c:1"}, {"b", "b:1", ""}},
+		},
+	}
+
+	for _, c := range cases {
+		actual := getImageChanges(c.from, c.to)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("getImageChanges(%#v, %#v) == %#v, expected %#v", c.from, c.to, actual, c.expected)
+		}
+	}
+}
+
+func TestRollback(t *testing.T) {
+	client := fake.NewSimpleClientset(newStatefulSet(), newRevision(1, "db:1"), newRevision(2, "db:2"))
+
+	if err := Rollback(client, types.ResourceKindStatefulSet, "ns", "db", 2); !errors.IsBadRequest(err) {
+		t.Errorf("Rollback() to the current revision should return bad request error, got %v", err)
+	}
+
+	if err := Rollback(client, types.ResourceKindDaemonSet, "ns", "db", 1); err == nil {
+		t.Error("Rollback() of missing DaemonSet should return error")
+	}
+
+	if err := Rollback(client, types.ResourceKindStatefulSet, "ns", "db", 1); err != nil {
+		t.Fatalf("Rollback() returned error: %v", err)
+	}
+
+	statefulSet, _ := client.AppsV1().StatefulSets("ns").Get(context.TODO(), "db", metaV1.GetOptions{})
+	containers := statefulSet.Spec.Template.Spec.Containers
+	if len(containers) != 1 || containers[0].Image != "db:1" {
+		t.Errorf("Rollback() should restore pod template of revision 1, got %#v", containers)
+	}
+}
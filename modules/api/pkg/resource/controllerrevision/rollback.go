@@ -0,0 +1,115 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllerrevision
+
+import (
+	"context"
+	"fmt"
+
+	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// revisionOwner is a StatefulSet or DaemonSet that stores its pod template history in ControllerRevisions.
+type revisionOwner struct {
+	object   metaV1.Object
+	selector *metaV1.LabelSelector
+	// updateRevision is the name of the revision that pods are being updated to. Set only for StatefulSets.
+	updateRevision string
+	// patch applies the ControllerRevision data to the owner.
+	patch func(data []byte) error
+}
+
+// isCurrent checks if the revision at the given index of the sorted revisions is the current one. When the owner
+// does not report its update revision, the newest revision is the current one.
+func (self *revisionOwner) isCurrent(revisions []apps.ControllerRevision, i int) bool {
+	if len(self.updateRevision) > 0 {
+		return revisions[i].Name == self.updateRevision
+	}
+
+	return i == len(revisions)-1
+}
+
+func getOwner(client kubernetes.Interface, kind, namespace, name string) (*revisionOwner, error) {
+	switch kind {
+	case types.ResourceKindStatefulSet:
+		statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		return &revisionOwner{
+			object:         statefulSet,
+			selector:       statefulSet.Spec.Selector,
+			updateRevision: statefulSet.Status.UpdateRevision,
+			patch: func(data []byte) error {
+				_, err := client.AppsV1().StatefulSets(namespace).Patch(context.TODO(), name,
+					k8stypes.StrategicMergePatchType, data, metaV1.PatchOptions{})
+				return err
+			},
+		}, nil
+	case types.ResourceKindDaemonSet:
+		daemonSet, err := client.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		return &revisionOwner{
+			object:   daemonSet,
+			selector: daemonSet.Spec.Selector,
+			patch: func(data []byte) error {
+				_, err := client.AppsV1().DaemonSets(namespace).Patch(context.TODO(), name,
+					k8stypes.StrategicMergePatchType, data, metaV1.PatchOptions{})
+				return err
+			},
+		}, nil
+	}
+
+	return nil, errors.NewBadRequest(fmt.Sprintf("revision history of %s is not supported", kind))
+}
+
+// Rollback rolls back the StatefulSet or DaemonSet pod template to the given revision, in the same way as
+// `kubectl rollout undo --to-revision` does. The controller then creates a new revision with the restored template.
+func Rollback(client kubernetes.Interface, kind, namespace, name string, revision int64) error {
+	owner, err := getOwner(client, kind, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := getControllerRevisions(client, owner)
+	if err != nil {
+		return err
+	}
+
+	for i := range revisions {
+		if revisions[i].Revision == revision && owner.isCurrent(revisions, i) {
+			return errors.NewBadRequest(fmt.Sprintf("revision %d is already the current revision", revision))
+		}
+	}
+
+	controllerRevision, err := findRevision(revisions, revision)
+	if err != nil {
+		return err
+	}
+
+	klog.V(2).InfoS("rolling back", "kind", kind, "namespace", namespace, "name", name, "revision", revision)
+	return owner.patch(controllerRevision.Data.Raw)
+}
@@ -57,7 +57,7 @@ func GetCronJobList(client client.Interface, nsQuery *common.NamespaceQuery,
 	klog.V(4).Infof("Getting list of all cron jobs in the cluster")
 
 	channels := &common.ResourceChannels{
-		CronJobList: common.GetCronJobListChannel(client, nsQuery, 1),
+		CronJobList: common.GetCronJobListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 	}
 
 	return GetCronJobListFromChannels(channels, dsQuery)
@@ -75,6 +75,7 @@ func GetCronJobListFromChannels(channels *common.ResourceChannels, dsQuery *data
 	}
 
 	cronJobList := toCronJobList(cronJobs.Items, nonCriticalErrors, dsQuery)
+	cronJobList.ListMeta.SetChunk(cronJobs.ListMeta)
 	cronJobList.Status = getStatus(cronJobs)
 	return cronJobList, nil
 }
@@ -28,7 +28,7 @@ import (
 
 // GetCustomResourceDefinitionList returns all the custom resource definitions in the cluster.
 func GetCustomResourceDefinitionList(client apiextensionsclientset.Interface, dsQuery *dataselect.DataSelectQuery) (*types.CustomResourceDefinitionList, error) {
-	channel := common.GetCustomResourceDefinitionChannelV1(client, 1)
+	channel := common.GetCustomResourceDefinitionChannelV1WithOptions(client, dsQuery.ListOptions(), 1)
 	crdList := <-channel.List
 	err := <-channel.Error
 
@@ -37,7 +37,9 @@ func GetCustomResourceDefinitionList(client apiextensionsclientset.Interface, ds
 		return nil, criticalError
 	}
 
-	return toCustomResourceDefinitionList(crdList.Items, nonCriticalErrors, dsQuery), nil
+	result := toCustomResourceDefinitionList(crdList.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(crdList.ListMeta)
+	return result, nil
 }
 
 func toCustomResourceDefinitionList(crds []apiextensionsv1.CustomResourceDefinition, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *types.CustomResourceDefinitionList {
@@ -56,9 +56,11 @@ func GetCustomResourceObjectList(client apiextensionsclientset.Interface, config
 		return nil, criticalError
 	}
 
+	options := dsQuery.ListOptions()
 	raw, err := restClient.Get().
 		NamespaceIfScoped(namespace.ToRequestParam(), customResourceDefinition.Spec.Scope == apiextensionsv1.NamespaceScoped).
 		Resource(customResourceDefinition.Spec.Names.Plural).
+		VersionedParams(&options, metav1.ParameterCodec).
 		Do(context.TODO()).Raw()
 	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
 	if criticalError != nil {
@@ -70,12 +72,23 @@ func GetCustomResourceObjectList(client apiextensionsclientset.Interface, config
 	if criticalError != nil {
 		return nil, criticalError
 	}
+
+	// List meta of the response is not a part of the list type, but it holds the continue token of the chunk.
+	var chunk struct {
+		Metadata metav1.ListMeta `json:"metadata"`
+	}
+	err = json.Unmarshal(raw, &chunk)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
 	list.Errors = nonCriticalErrors
 
 	// Return only slice of data, pagination is done here.
 	crdObjectCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toObjectCells(list.Items), dsQuery)
 	list.Items = fromObjectCells(crdObjectCells)
 	list.ListMeta = commontypes.ListMeta{TotalItems: filteredTotal}
+	list.ListMeta.SetChunk(chunk.Metadata)
 
 	for i := range list.Items {
 		toCRDObject(&list.Items[i], customResourceDefinition)
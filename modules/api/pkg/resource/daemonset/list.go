@@ -51,7 +51,7 @@ type DaemonSet struct {
 func GetDaemonSetList(client kubernetes.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery,
 	metricClient metricapi.MetricClient) (*DaemonSetList, error) {
 	channels := &common.ResourceChannels{
-		DaemonSetList: common.GetDaemonSetListChannel(client, nsQuery, 1),
+		DaemonSetList: common.GetDaemonSetListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 		ServiceList:   common.GetServiceListChannel(client, nsQuery, 1),
 		PodList:       common.GetPodListChannel(client, nsQuery, 1),
 		EventList:     common.GetEventListChannel(client, nsQuery, 1),
@@ -87,6 +87,7 @@ func GetDaemonSetListFromChannels(channels *common.ResourceChannels, dsQuery *da
 	}
 
 	dsList := toDaemonSetList(daemonSets.Items, pods.Items, events.Items, nonCriticalErrors, dsQuery, metricClient)
+	dsList.ListMeta.SetChunk(daemonSets.ListMeta)
 	dsList.Status = getStatus(daemonSets, pods.Items, events.Items)
 	return dsList, nil
 }
@@ -17,6 +17,7 @@ package dataselect
 import (
 	"sort"
 
+	apimachinery "k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 
 	"k8s.io/dashboard/api/pkg/args"
@@ -67,6 +68,9 @@ type DataSelector struct {
 	// The metrics will be calculated after calling GetMetrics method. Metric will not be
 	// aggregated and can are used to display sparklines on pod list.
 	MetricsPromises metricapi.MetricPromises
+	// MetricProperties holds latest values of properties backed by metrics by UID of the resource. The values will be
+	// downloaded after calling GetMetricProperties method and are used by sort and filter.
+	MetricProperties map[apimachinery.UID]map[PropertyName]ComparableValue
 }
 
 // Implementation of sort.Interface so that we can use built-in sort function (sort.Sort) for sorting SelectableData
@@ -82,8 +86,12 @@ func (self DataSelector) Swap(i, j int) {
 // Less compares 2 indices inside SelectableData and returns true if first index is larger.
 func (self DataSelector) Less(i, j int) bool {
 	for _, sortBy := range self.DataSelectQuery.SortQuery.SortByList {
-		a := self.GenericDataList[i].GetProperty(sortBy.Property)
-		b := self.GenericDataList[j].GetProperty(sortBy.Property)
+		a := self.getProperty(self.GenericDataList[i], sortBy.Property)
+		b := self.getProperty(self.GenericDataList[j], sortBy.Property)
+		// data cells without metrics go last, regardless of the order
+		if IsMetricProperty(sortBy.Property) && (a == nil) != (b == nil) {
+			return b == nil
+		}
 		// ignore sort completely if property name not found
 		if a == nil || b == nil {
 			break
@@ -106,16 +114,32 @@ func (self *DataSelector) Sort() *DataSelector {
 
 // Filter the data inside as instructed by DataSelectQuery and returns itself to allow method chaining.
 func (self *DataSelector) Filter() *DataSelector {
+	return self.filter(true)
+}
+
+// filter applies filter options of DataSelectQuery. Options using metric properties are skipped unless
+// includeMetrics is set, so the candidate set can be narrowed down before metrics are downloaded. Filter expression
+// is skipped as well if it uses metric properties.
+func (self *DataSelector) filter(includeMetrics bool) *DataSelector {
 	filteredList := []DataCell{}
+	filterQuery := self.DataSelectQuery.FilterQuery
+	expression := filterQuery.Expression
+	if expression != nil && !includeMetrics && usesMetricProperties(expression) {
+		expression = nil
+	}
 
 	for _, c := range self.GenericDataList {
-		matches := true
-		for _, filterBy := range self.DataSelectQuery.FilterQuery.FilterByList {
-			v := c.GetProperty(filterBy.Property)
-			if v == nil || !v.Contains(filterBy.Value) {
-				matches = false
+		matches := expression == nil || expression.matches(c, self)
+		for _, filterBy := range filterQuery.FilterByList {
+			if !matches {
 				break
 			}
+
+			if !includeMetrics && IsMetricProperty(filterBy.Property) {
+				continue
+			}
+
+			matches = filterBy.Matches(self.getProperty(c, filterBy.Property))
 		}
 		if matches {
 			filteredList = append(filteredList, c)
@@ -126,6 +150,73 @@ func (self *DataSelector) Filter() *DataSelector {
 	return self
 }
 
+// usesMetricProperties returns true if filter expression uses properties backed by metrics.
+func usesMetricProperties(expression FilterExpression) bool {
+	for _, property := range expression.properties() {
+		if IsMetricProperty(property) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getProperty returns the property of the data cell. Properties backed by metrics are taken from
+// self.MetricProperties and are nil if metrics are not available for the data cell.
+func (self *DataSelector) getProperty(cell DataCell, name PropertyName) ComparableValue {
+	if !IsMetricProperty(name) {
+		return cell.GetProperty(name)
+	}
+
+	metricDataCell, ok := cell.(MetricDataCell)
+	if !ok {
+		return nil
+	}
+
+	return self.MetricProperties[metricDataCell.GetResourceSelector().UID][name]
+}
+
+// GetMetricProperties downloads metrics used as properties by sort and filter options for data cells currently present
+// in self.GenericDataList and inserts their latest values to self.MetricProperties.
+func (self *DataSelector) GetMetricProperties(metricClient metricapi.MetricClient) *DataSelector {
+	properties := self.DataSelectQuery.metricPropertyNames()
+	if metricClient == nil || len(properties) == 0 {
+		return self
+	}
+
+	selectors := make([]metricapi.ResourceSelector, len(self.GenericDataList))
+	for i, dataCell := range self.GenericDataList {
+		if metricDataCell, ok := dataCell.(MetricDataCell); ok {
+			selectors[i] = *metricDataCell.GetResourceSelector()
+		}
+	}
+
+	promisesByProperty := make([]metricapi.MetricPromises, len(properties))
+	for i, property := range properties {
+		promisesByProperty[i] = metricClient.DownloadMetric(selectors, metricProperties[property], self.CachedResources)
+	}
+
+	self.MetricProperties = make(map[apimachinery.UID]map[PropertyName]ComparableValue)
+	for i, promises := range promisesByProperty {
+		for j, promise := range promises {
+			metric, err := promise.GetMetric()
+			if err != nil || metric == nil || len(metric.DataPoints) == 0 || len(selectors[j].UID) == 0 {
+				continue
+			}
+
+			values, exists := self.MetricProperties[selectors[j].UID]
+			if !exists {
+				values = make(map[PropertyName]ComparableValue)
+				self.MetricProperties[selectors[j].UID] = values
+			}
+
+			values[properties[i]] = StdComparableInt64(metric.DataPoints[len(metric.DataPoints)-1].Y)
+		}
+	}
+
+	return self
+}
+
 func (self *DataSelector) getMetrics(metricClient metricapi.MetricClient) (
 	[]metricapi.MetricPromises, error) {
 	metricPromises := make([]metricapi.MetricPromises, 0)
@@ -266,8 +357,8 @@ func GenericDataSelectWithMetrics(dataList []DataCell, dsQuery *DataSelectQuery,
 		DataSelectQuery: dsQuery,
 		CachedResources: cachedResources,
 	}
-	// Pipeline is Filter -> Sort -> CollectMetrics -> Paginate
-	processed := SelectableData.Sort().GetCumulativeMetrics(metricClient).Paginate()
+	// Pipeline is CollectMetricProperties -> Sort -> CollectMetrics -> Paginate
+	processed := SelectableData.GetMetricProperties(metricClient).Sort().GetCumulativeMetrics(metricClient).Paginate()
 	return processed.GenericDataList, processed.CumulativeMetricsPromises
 }
 
@@ -280,8 +371,8 @@ func GenericDataSelectWithFilterAndMetrics(dataList []DataCell, dsQuery *DataSel
 		DataSelectQuery: dsQuery,
 		CachedResources: cachedResources,
 	}
-	// Pipeline is Filter -> Sort -> CollectMetrics -> Paginate
-	filtered := SelectableData.Filter()
+	// Pipeline is Filter -> CollectMetricProperties -> FilterByMetrics -> Sort -> CollectMetrics -> Paginate
+	filtered := SelectableData.filter(false).GetMetricProperties(metricClient).Filter()
 	filteredTotal := len(filtered.GenericDataList)
 	processed := filtered.Sort().GetCumulativeMetrics(metricClient).Paginate()
 	return processed.GenericDataList, processed.CumulativeMetricsPromises, filteredTotal
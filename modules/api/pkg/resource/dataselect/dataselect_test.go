@@ -17,6 +17,12 @@ package dataselect
 import (
 	"reflect"
 	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+
+	integrationapi "k8s.io/dashboard/api/pkg/integration/api"
+	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
 )
 
 type PaginationTestCase struct {
@@ -222,3 +228,205 @@ func TestPagination(t *testing.T) {
 	}
 
 }
+
+type TestMetricDataCell struct {
+	TestDataCell
+}
+
+func (self TestMetricDataCell) GetResourceSelector() *metricapi.ResourceSelector {
+	return &metricapi.ResourceSelector{ResourceName: self.Name, UID: apimachinery.UID(self.Name)}
+}
+
+// fakeMetricClient returns latest values of metrics by resource UID.
+type fakeMetricClient struct {
+	values map[string]map[apimachinery.UID]int64
+}
+
+func (fakeMetricClient) ID() integrationapi.IntegrationID { return "fake" }
+
+func (fakeMetricClient) HealthCheck() error { return nil }
+
+func (self fakeMetricClient) DownloadMetric(selectors []metricapi.ResourceSelector, metricName string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.NewMetricPromises(len(selectors))
+	for i, selector := range selectors {
+		value, exists := self.values[metricName][selector.UID]
+		if !exists {
+			result[i].Metric <- nil
+			result[i].Error <- nil
+			continue
+		}
+
+		result[i].Metric <- &metricapi.Metric{
+			DataPoints: metricapi.DataPoints{{X: 1, Y: 0}, {X: 2, Y: value}},
+			MetricName: metricName,
+		}
+		result[i].Error <- nil
+	}
+	return result
+}
+
+func (self fakeMetricClient) DownloadMetrics(selectors []metricapi.ResourceSelector, metricNames []string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	return nil
+}
+
+func (self fakeMetricClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return nil
+}
+
+func getMetricDataCellList() []DataCell {
+	cells := []DataCell{}
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		cells = append(cells, TestMetricDataCell{TestDataCell{name, i + 1}})
+	}
+	return cells
+}
+
+func TestMetricProperties(t *testing.T) {
+	metricClient := fakeMetricClient{values: map[string]map[apimachinery.UID]int64{
+		metricapi.CpuUsage:    {"a": 100, "b": 1500, "c": 20, "e": 700},
+		metricapi.MemoryUsage: {"a": 2 << 30, "b": 1 << 20, "c": 4 << 30, "d": 512 << 20, "e": 1 << 30},
+	}}
+
+	testCases := []struct {
+		Info          string
+		SortQuery     *SortQuery
+		FilterQuery   *FilterQuery
+		ExpectedOrder []int
+	}{
+		{
+			"descending sort by cpu usage - cells without metrics go last",
+			NewSortQuery([]string{"d", "cpuUsage"}),
+			NoFilter,
+			[]int{2, 5, 1, 3, 4},
+		},
+		{
+			"ascending sort by cpu usage - cells without metrics go last",
+			NewSortQuery([]string{"a", "cpuUsage"}),
+			NoFilter,
+			[]int{3, 1, 5, 2, 4},
+		},
+		{
+			"filter by memory usage - only cells using at least 1Gi are returned",
+			NoSort,
+			NewFilterQuery([]string{"memoryUsage>=1Gi"}),
+			[]int{1, 3, 5},
+		},
+		{
+			"filter by memory and cpu usage and sort by memory usage",
+			NewSortQuery([]string{"d", "memoryUsage"}),
+			NewFilterQuery([]string{"memoryUsage>1Mi", "cpuUsage<1"}),
+			[]int{3, 1, 5},
+		},
+		{
+			"filter by name and cpu usage",
+			NoSort,
+			NewFilterQuery([]string{"name", "b", "cpuUsage>=1.5"}),
+			[]int{2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		selected, _, total := GenericDataSelectWithFilterAndMetrics(getMetricDataCellList(),
+			NewDataSelectQuery(NoPagination, testCase.SortQuery, testCase.FilterQuery, NoMetrics), nil, metricClient)
+		order := []int{}
+		for _, cell := range selected {
+			order = append(order, cell.(TestMetricDataCell).Id)
+		}
+
+		if !reflect.DeepEqual(order, testCase.ExpectedOrder) || total != len(testCase.ExpectedOrder) {
+			t.Errorf(`Metric properties: %s. Got %v (%d in total), expected %v.`,
+				testCase.Info, order, total, testCase.ExpectedOrder)
+		}
+	}
+}
+
+func TestNewFilterQuery(t *testing.T) {
+	testCases := []struct {
+		raw      []string
+		expected *FilterQuery
+	}{
+		{nil, NoFilter},
+		{[]string{"name"}, NoFilter},
+		{[]string{"name", "a"}, &FilterQuery{FilterByList: []FilterBy{{Property: "name", Value: StdComparableString("a")}}}},
+		{
+			[]string{"memoryUsage>1Gi", "name", "cpuUsage>x"},
+			&FilterQuery{FilterByList: []FilterBy{
+				{Property: "memoryUsage", Value: StdComparableInt64(1 << 30), Operator: GreaterThanOperator},
+				{Property: "name", Value: StdComparableString("cpuUsage>x")},
+			}},
+		},
+		{
+			[]string{"cpuUsage<=250m", "cpuUsage", "1"},
+			&FilterQuery{FilterByList: []FilterBy{
+				{Property: "cpuUsage", Value: StdComparableInt64(250), Operator: LessThanOrEqualOperator},
+				{Property: "cpuUsage", Value: StdComparableInt64(1000), Operator: EqualOperator},
+			}},
+		},
+		{[]string{"memoryUsage>1Gx"}, NoFilter},
+		{[]string{"memoryUsage", "abc"}, NoFilter},
+	}
+
+	for _, testCase := range testCases {
+		actual := NewFilterQuery(testCase.raw)
+		if !reflect.DeepEqual(actual, testCase.expected) {
+			t.Errorf("NewFilterQuery(%v) == \n%#v\nexpected \n%#v", testCase.raw, actual, testCase.expected)
+		}
+	}
+}
+
+func TestNewSelectorQuery(t *testing.T) {
+	testCases := []struct {
+		labelSelector string
+		fieldSelector string
+		expected      *SelectorQuery
+		expectedError bool
+	}{
+		{"", "", &SelectorQuery{}, false},
+		{"app=web, tier!=cache", "", &SelectorQuery{LabelSelector: "app=web,tier!=cache"}, false},
+		{"env in (prod)", "status.phase=Running", &SelectorQuery{LabelSelector: "env in (prod)",
+			FieldSelector: "status.phase=Running"}, false},
+		{"app in (web", "", nil, true},
+		{"", "status.phase", nil, true},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := NewSelectorQuery(testCase.labelSelector, testCase.fieldSelector)
+		if (err != nil) != testCase.expectedError {
+			t.Errorf("NewSelectorQuery(%q, %q) returned error %v", testCase.labelSelector, testCase.fieldSelector, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(actual, testCase.expected) {
+			t.Errorf("NewSelectorQuery(%q, %q) == %#v, expected %#v", testCase.labelSelector,
+				testCase.fieldSelector, actual, testCase.expected)
+		}
+	}
+}
+
+func TestListOptions(t *testing.T) {
+	testCases := []struct {
+		query    *DataSelectQuery
+		expected metaV1.ListOptions
+	}{
+		{nil, metaV1.ListOptions{}},
+		{NoDataSelect, metaV1.ListOptions{}},
+		{
+			&DataSelectQuery{SelectorQuery: &SelectorQuery{LabelSelector: "app=web", FieldSelector: "spec.nodeName=node-1"}},
+			metaV1.ListOptions{LabelSelector: "app=web", FieldSelector: "spec.nodeName=node-1"},
+		},
+		{
+			&DataSelectQuery{SelectorQuery: NoSelector, ChunkQuery: &ChunkQuery{Limit: 50, Continue: "token"}},
+			metaV1.ListOptions{Limit: 50, Continue: "token"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual := testCase.query.ListOptions()
+		if !reflect.DeepEqual(actual, testCase.expected) {
+			t.Errorf("ListOptions() == %#v, expected %#v", actual, testCase.expected)
+		}
+	}
+}
@@ -15,7 +15,17 @@
 package dataselect
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
 	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/helpers"
 )
 
 // DataSelectQuery is options for GenericDataSelect which takes []GenericDataCell and returns selected data.
@@ -26,6 +36,60 @@ type DataSelectQuery struct {
 	SortQuery       *SortQuery
 	FilterQuery     *FilterQuery
 	MetricQuery     *MetricQuery
+	// SelectorQuery is applied by the apiserver, before the rest of the query is applied in memory.
+	SelectorQuery *SelectorQuery
+	// ChunkQuery is applied by the apiserver as well. Only the listed chunk is sorted, filtered and paginated.
+	ChunkQuery *ChunkQuery
+}
+
+// SelectorQuery holds label and field selectors that are passed to the apiserver, so only matching resources
+// are listed. Query has this format labelSelector=app=web,tier!=cache&fieldSelector=status.phase=Running
+type SelectorQuery struct {
+	LabelSelector string
+	FieldSelector string
+}
+
+// NoSelector is an option to list all resources.
+var NoSelector = &SelectorQuery{}
+
+// NewSelectorQuery validates label and field selectors and returns a selector query. Returns bad request error
+// if any of the selectors is not valid.
+func NewSelectorQuery(labelSelector, fieldSelector string) (*SelectorQuery, error) {
+	parsedLabelSelector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid label selector: %s", err))
+	}
+
+	parsedFieldSelector, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid field selector: %s", err))
+	}
+
+	return &SelectorQuery{
+		LabelSelector: parsedLabelSelector.String(),
+		FieldSelector: parsedFieldSelector.String(),
+	}, nil
+}
+
+// ListOptions returns options used to list resources from the apiserver. Label and field selectors and the chunk
+// of the query are applied, if it has any.
+func (self *DataSelectQuery) ListOptions() metaV1.ListOptions {
+	options := helpers.ListEverything
+	if self == nil {
+		return options
+	}
+
+	if self.SelectorQuery != nil {
+		options.LabelSelector = self.SelectorQuery.LabelSelector
+		options.FieldSelector = self.SelectorQuery.FieldSelector
+	}
+
+	if self.ChunkQuery != nil {
+		options.Limit = self.ChunkQuery.Limit
+		options.Continue = self.ChunkQuery.Continue
+	}
+
+	return options
 }
 
 var NoMetrics = NewMetricQuery(nil, nil)
@@ -73,11 +137,61 @@ var NoSort = &SortQuery{
 
 type FilterQuery struct {
 	FilterByList []FilterBy
+	// Expression is a parsed filter expression. Data cells have to satisfy both the expression and FilterByList.
+	Expression FilterExpression
 }
 
 type FilterBy struct {
 	Property PropertyName
 	Value    ComparableValue
+	// Operator decides how property values are compared with Value. Empty operator means that property values
+	// have to contain Value.
+	Operator FilterOperator
+}
+
+// FilterOperator is an operator used to compare property values with the value of a filter.
+type FilterOperator string
+
+// List of all filter operators. Only the contains operator can be used without a comparison expression.
+const (
+	ContainsOperator           FilterOperator = ""
+	EqualOperator              FilterOperator = "="
+	GreaterThanOperator        FilterOperator = ">"
+	GreaterThanOrEqualOperator FilterOperator = ">="
+	LessThanOperator           FilterOperator = "<"
+	LessThanOrEqualOperator    FilterOperator = "<="
+)
+
+// comparisonOperators is a list of operators that can be used in comparison expressions. Longer operators go first,
+// so they are not mistaken for their prefixes.
+var comparisonOperators = []FilterOperator{
+	GreaterThanOrEqualOperator,
+	LessThanOrEqualOperator,
+	GreaterThanOperator,
+	LessThanOperator,
+	EqualOperator,
+}
+
+// Matches returns true if given property value satisfies the filter.
+func (self FilterBy) Matches(value ComparableValue) bool {
+	if value == nil {
+		return false
+	}
+
+	switch self.Operator {
+	case EqualOperator:
+		return value.Compare(self.Value) == 0
+	case GreaterThanOperator:
+		return value.Compare(self.Value) > 0
+	case GreaterThanOrEqualOperator:
+		return value.Compare(self.Value) >= 0
+	case LessThanOperator:
+		return value.Compare(self.Value) < 0
+	case LessThanOrEqualOperator:
+		return value.Compare(self.Value) <= 0
+	default:
+		return value.Contains(self.Value)
+	}
 }
 
 var NoFilter = &FilterQuery{
@@ -144,23 +258,175 @@ func NewSortQuery(sortByListRaw []string) *SortQuery {
 
 // NewFilterQuery takes raw filter options list and returns FilterQuery object. For example:
 // ["parameter1", "value1", "parameter2", "value2"] - means that the data should be filtered by
-// parameter1 equals value1 and parameter2 equals value2
+// parameter1 equals value1 and parameter2 equals value2. Metric properties can also be compared with
+// quantities using a single expression, i.e. ["memoryUsage>1Gi", "name", "value1"].
 func NewFilterQuery(filterByListRaw []string) *FilterQuery {
-	if filterByListRaw == nil || len(filterByListRaw)%2 == 1 {
+	if filterByListRaw == nil {
+		return NoFilter
+	}
+
+	filterQuery, err := parseFilterByList(filterByListRaw)
+	if err != nil {
 		return NoFilter
 	}
+
+	return filterQuery
+}
+
+// legacyPropertyNamePattern matches property names of the comma separated filter options.
+var legacyPropertyNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// parseFilterByList parses raw filter options list in the format described by NewFilterQuery. Options that are not
+// complete or have invalid quantities result in NoFilter. Returns bad request error if the property name is not
+// valid, as it is most likely a filter expression with a comma in the unquoted value.
+func parseFilterByList(filterByListRaw []string) (*FilterQuery, error) {
 	filterByList := []FilterBy{}
-	for i := 0; i+1 < len(filterByListRaw); i += 2 {
-		propertyName := filterByListRaw[i]
+	for i := 0; i < len(filterByListRaw); {
+		filterBy, ok, err := parseComparisonExpression(filterByListRaw[i])
+		if err != nil {
+			// Invalid quantity
+			return NoFilter, nil
+		}
+
+		if ok {
+			filterByList = append(filterByList, filterBy)
+			i++
+			continue
+		}
+
+		if !legacyPropertyNamePattern.MatchString(filterByListRaw[i]) {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid filter property %q, values of filter "+
+				"expressions that contain commas have to be quoted", filterByListRaw[i]))
+		}
+
+		if i+1 >= len(filterByListRaw) {
+			// Property without value
+			return NoFilter, nil
+		}
+
+		propertyName := PropertyName(filterByListRaw[i])
 		propertyValue := filterByListRaw[i+1]
-		filterBy := FilterBy{
-			Property: PropertyName(propertyName),
-			Value:    StdComparableString(propertyValue),
+		if IsMetricProperty(propertyName) {
+			value, err := parseMetricValue(propertyName, propertyValue)
+			if err != nil {
+				return NoFilter, nil
+			}
+
+			filterBy = FilterBy{Property: propertyName, Value: value, Operator: EqualOperator}
+		} else {
+			filterBy = FilterBy{Property: propertyName, Value: StdComparableString(propertyValue)}
 		}
+
 		// Add to the filter options.
 		filterByList = append(filterByList, filterBy)
+		i += 2
 	}
 	return &FilterQuery{
 		FilterByList: filterByList,
+	}, nil
+}
+
+// ParseFilterQuery parses raw filter option of the request and returns FilterQuery object. Comma separated
+// options are parsed as described by NewFilterQuery for compatibility, any other option is parsed as a filter
+// expression. For example: status!=Running AND (name~^api- OR label:team=payments) AND restarts>3. Values of filter
+// expressions that contain commas, i.e. name~"^a{1,3}", have to be quoted. Returns bad request error if comma
+// separated options or filter expression are not valid.
+func ParseFilterQuery(raw string) (*FilterQuery, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return NoFilter, nil
+	}
+
+	if hasUnquotedComma(raw) {
+		return parseFilterByList(strings.Split(raw, ","))
+	}
+
+	expression, err := ParseFilterExpression(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilterQuery{FilterByList: []FilterBy{}, Expression: expression}, nil
+}
+
+// hasUnquotedComma returns true if raw filter option contains a comma outside of quoted strings.
+func hasUnquotedComma(raw string) bool {
+	var quote rune
+	escaped := false
+	for _, r := range raw {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && r == '\\':
+			escaped = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == ',':
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseComparisonExpression parses expressions comparing metric properties with quantities, i.e. cpuUsage>=500m.
+// Returns false if raw filter option is not such an expression.
+func parseComparisonExpression(raw string) (FilterBy, bool, error) {
+	for _, operator := range comparisonOperators {
+		i := strings.Index(raw, string(operator))
+		if i <= 0 {
+			continue
+		}
+
+		propertyName := PropertyName(raw[:i])
+		if !IsMetricProperty(propertyName) {
+			return FilterBy{}, false, nil
+		}
+
+		value, err := parseMetricValue(propertyName, raw[i+len(operator):])
+		if err != nil {
+			return FilterBy{}, false, err
+		}
+
+		return FilterBy{Property: propertyName, Value: value, Operator: operator}, true, nil
 	}
+
+	return FilterBy{}, false, nil
+}
+
+// metricPropertyNames returns list of unique properties backed by metrics used by sort and filter options.
+func (self *DataSelectQuery) metricPropertyNames() []PropertyName {
+	properties := []PropertyName{}
+	if self == nil {
+		return properties
+	}
+
+	used := map[PropertyName]bool{}
+	add := func(name PropertyName) {
+		if IsMetricProperty(name) && !used[name] {
+			used[name] = true
+			properties = append(properties, name)
+		}
+	}
+
+	if self.SortQuery != nil {
+		for _, sortBy := range self.SortQuery.SortByList {
+			add(sortBy.Property)
+		}
+	}
+
+	if self.FilterQuery != nil {
+		for _, filterBy := range self.FilterQuery.FilterByList {
+			add(filterBy.Property)
+		}
+
+		if self.FilterQuery.Expression != nil {
+			for _, property := range self.FilterQuery.Expression.properties() {
+				add(property)
+			}
+		}
+	}
+
+	return properties
 }
@@ -0,0 +1,551 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"k8s.io/dashboard/errors"
+)
+
+// Additional operators supported by filter expressions.
+const (
+	NotEqualOperator   FilterOperator = "!="
+	MatchOperator      FilterOperator = "~"
+	NotMatchOperator   FilterOperator = "!~"
+	LabelExistOperator FilterOperator = "exists"
+)
+
+// labelPrefix marks fields of filter expressions that refer to labels instead of properties, i.e. label:team=payments.
+const labelPrefix = "label:"
+
+// FilterExpression is a node of the abstract syntax tree of a filter expression. Expressions have the following
+// grammar, where keywords are case-insensitive and values containing whitespace or special characters have to be
+// quoted:
+//
+//	expression := term { "OR" term }
+//	term       := factor { "AND" factor }
+//	factor     := "NOT" factor | "(" expression ")" | comparison
+//	comparison := property operator value | "label:" key [ operator value ]
+//	operator   := "=" | "==" | "!=" | "~" | "!~" | ">" | ">=" | "<" | "<="
+//
+// For example: status!=Running AND (name~^api- OR label:team=payments) AND restarts>3.
+type FilterExpression interface {
+	// String returns canonical representation of the expression.
+	String() string
+	// matches returns true if the data cell satisfies the expression.
+	matches(cell DataCell, selector *DataSelector) bool
+	// properties returns names of all properties used by the expression.
+	properties() []PropertyName
+}
+
+type andExpression struct {
+	left, right FilterExpression
+}
+
+func (self andExpression) String() string {
+	return fmt.Sprintf("(%s AND %s)", self.left, self.right)
+}
+
+func (self andExpression) matches(cell DataCell, selector *DataSelector) bool {
+	return self.left.matches(cell, selector) && self.right.matches(cell, selector)
+}
+
+func (self andExpression) properties() []PropertyName {
+	return append(self.left.properties(), self.right.properties()...)
+}
+
+type orExpression struct {
+	left, right FilterExpression
+}
+
+func (self orExpression) String() string {
+	return fmt.Sprintf("(%s OR %s)", self.left, self.right)
+}
+
+func (self orExpression) matches(cell DataCell, selector *DataSelector) bool {
+	return self.left.matches(cell, selector) || self.right.matches(cell, selector)
+}
+
+func (self orExpression) properties() []PropertyName {
+	return append(self.left.properties(), self.right.properties()...)
+}
+
+type notExpression struct {
+	expression FilterExpression
+}
+
+func (self notExpression) String() string {
+	return fmt.Sprintf("NOT %s", self.expression)
+}
+
+func (self notExpression) matches(cell DataCell, selector *DataSelector) bool {
+	return !self.expression.matches(cell, selector)
+}
+
+func (self notExpression) properties() []PropertyName {
+	return self.expression.properties()
+}
+
+// comparisonExpression compares a property of data cells with a value. The value is converted to the type of the
+// property during evaluation, because types of properties differ between resources. Values of metric properties
+// and regular expressions are parsed upfront.
+type comparisonExpression struct {
+	property PropertyName
+	operator FilterOperator
+	value    string
+	parsed   ComparableValue
+	regexp   *regexp.Regexp
+}
+
+func (self comparisonExpression) String() string {
+	return fmt.Sprintf("%s%s%q", self.property, self.operator, self.value)
+}
+
+func (self comparisonExpression) matches(cell DataCell, selector *DataSelector) bool {
+	value := selector.getProperty(cell, self.property)
+	if value == nil {
+		return false
+	}
+
+	switch self.operator {
+	case MatchOperator:
+		return self.regexp.MatchString(comparableToString(value))
+	case NotMatchOperator:
+		return !self.regexp.MatchString(comparableToString(value))
+	}
+
+	other := self.parsed
+	if other == nil {
+		var err error
+		if other, err = parseComparableValue(value, self.value); err != nil {
+			return false
+		}
+	}
+
+	cmp := value.Compare(other)
+	switch self.operator {
+	case EqualOperator:
+		return cmp == 0
+	case NotEqualOperator:
+		return cmp != 0
+	case GreaterThanOperator:
+		return cmp > 0
+	case GreaterThanOrEqualOperator:
+		return cmp >= 0
+	case LessThanOperator:
+		return cmp < 0
+	case LessThanOrEqualOperator:
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+func (self comparisonExpression) properties() []PropertyName {
+	return []PropertyName{self.property}
+}
+
+// labelExpression checks existence or value of a label of data cells.
+type labelExpression struct {
+	key      string
+	operator FilterOperator
+	value    string
+	regexp   *regexp.Regexp
+}
+
+func (self labelExpression) String() string {
+	if self.operator == LabelExistOperator {
+		return labelPrefix + self.key
+	}
+
+	return fmt.Sprintf("%s%s%s%q", labelPrefix, self.key, self.operator, self.value)
+}
+
+func (self labelExpression) matches(cell DataCell, _ *DataSelector) bool {
+	value, exists := getLabels(cell)[self.key]
+	switch self.operator {
+	case LabelExistOperator:
+		return exists
+	case EqualOperator:
+		return exists && value == self.value
+	case NotEqualOperator:
+		return !exists || value != self.value
+	case MatchOperator:
+		return exists && self.regexp.MatchString(value)
+	case NotMatchOperator:
+		return !exists || !self.regexp.MatchString(value)
+	default:
+		return false
+	}
+}
+
+func (self labelExpression) properties() []PropertyName {
+	return []PropertyName{}
+}
+
+// getLabels returns labels of the resource stored in the data cell. Data cells are Kubernetes objects converted to
+// cell types, which keep object metadata, but not its pointer receiver methods, so a pointer to a copy is used.
+func getLabels(cell DataCell) map[string]string {
+	if accessor, err := meta.Accessor(cell); err == nil {
+		return accessor.GetLabels()
+	}
+
+	value := reflect.ValueOf(cell)
+	object := reflect.New(value.Type())
+	object.Elem().Set(value)
+	if accessor, err := meta.Accessor(object.Interface()); err == nil {
+		return accessor.GetLabels()
+	}
+
+	return nil
+}
+
+// comparableToString returns value used by regular expression operators.
+func comparableToString(value ComparableValue) string {
+	switch v := value.(type) {
+	case StdComparableString:
+		return string(v)
+	case StdComparableRFC3339Timestamp:
+		return string(v)
+	case StdComparableTime:
+		return time.Time(v).Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseComparableValue converts raw value to the type of given property value, so they can be compared.
+func parseComparableValue(property ComparableValue, raw string) (ComparableValue, error) {
+	switch property.(type) {
+	case StdComparableString:
+		return StdComparableString(raw), nil
+	case StdComparableRFC3339Timestamp:
+		return StdComparableRFC3339Timestamp(raw), nil
+	case StdComparableInt:
+		value, err := strconv.Atoi(raw)
+		return StdComparableInt(value), err
+	case StdComparableInt64:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		return StdComparableInt64(value), err
+	case StdComparableTime:
+		value, err := time.Parse(time.RFC3339, raw)
+		return StdComparableTime(value), err
+	default:
+		return nil, fmt.Errorf("unsupported property type %T", property)
+	}
+}
+
+// ParseFilterExpression parses filter expression into its abstract syntax tree. Returns bad request error
+// describing the problem if expression is not valid.
+func ParseFilterExpression(raw string) (FilterExpression, error) {
+	tokens, err := tokenizeFilterExpression(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &filterParser{tokens: tokens}
+	expression, err := parser.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := parser.peek(); token.kind != filterTokenEnd {
+		return nil, newFilterError(token.position, "unexpected %s", token)
+	}
+
+	return expression, nil
+}
+
+func newFilterError(position int, format string, args ...interface{}) error {
+	return errors.NewBadRequest(fmt.Sprintf("invalid filter expression at position %d: %s", position+1,
+		fmt.Sprintf(format, args...)))
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenEnd filterTokenKind = iota
+	filterTokenWord
+	filterTokenString
+	filterTokenOperator
+	filterTokenOpen
+	filterTokenClose
+)
+
+type filterToken struct {
+	kind     filterTokenKind
+	value    string
+	position int
+}
+
+func (self filterToken) String() string {
+	switch self.kind {
+	case filterTokenEnd:
+		return "end of expression"
+	case filterTokenString:
+		return strconv.Quote(self.value)
+	default:
+		return fmt.Sprintf("'%s'", self.value)
+	}
+}
+
+// isKeyword returns true if token is given keyword. Keywords are case-insensitive.
+func (self filterToken) isKeyword(keyword string) bool {
+	return self.kind == filterTokenWord && strings.EqualFold(self.value, keyword)
+}
+
+// filterOperators lists operators that can be used in comparisons. Longer operators go first, so they are not
+// mistaken for their prefixes.
+var filterOperators = []FilterOperator{"==", NotEqualOperator, NotMatchOperator, GreaterThanOrEqualOperator,
+	LessThanOrEqualOperator, EqualOperator, MatchOperator, GreaterThanOperator, LessThanOperator}
+
+func isFilterOperatorChar(r rune) bool {
+	return strings.ContainsRune("=!~<>", r)
+}
+
+func isFilterWordChar(r rune) bool {
+	return !unicode.IsSpace(r) && !isFilterOperatorChar(r) && !strings.ContainsRune(`()"',`, r)
+}
+
+func tokenizeFilterExpression(raw string) ([]filterToken, error) {
+	tokens := []filterToken{}
+	runes := []rune(raw)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: filterTokenOpen, value: "(", position: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: filterTokenClose, value: ")", position: i})
+			i++
+		case r == '"' || r == '\'':
+			value, end, err := readFilterString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, filterToken{kind: filterTokenString, value: value, position: i})
+			i = end
+		case isFilterOperatorChar(r):
+			operator := ""
+			for _, candidate := range filterOperators {
+				if strings.HasPrefix(string(runes[i:]), string(candidate)) {
+					operator = string(candidate)
+					break
+				}
+			}
+
+			if operator == "" {
+				return nil, newFilterError(i, "unknown operator '%c'", r)
+			}
+
+			tokens = append(tokens, filterToken{kind: filterTokenOperator, value: operator, position: i})
+			i += len(operator)
+		case isFilterWordChar(r):
+			start := i
+			for i < len(runes) && isFilterWordChar(runes[i]) {
+				i++
+			}
+
+			tokens = append(tokens, filterToken{kind: filterTokenWord, value: string(runes[start:i]), position: start})
+		default:
+			return nil, newFilterError(i, "unexpected character '%c'", r)
+		}
+	}
+
+	return append(tokens, filterToken{kind: filterTokenEnd, position: len(runes)}), nil
+}
+
+// readFilterString reads string quoted with the quote found at the start position. Quotes and backslashes inside
+// can be escaped with a backslash. Returns unquoted value and position after the closing quote.
+func readFilterString(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var builder strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+			}
+			builder.WriteRune(runes[i])
+		case quote:
+			return builder.String(), i + 1, nil
+		default:
+			builder.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, newFilterError(start, "unterminated string")
+}
+
+// filterParser is a recursive descent parser of filter expressions.
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (self *filterParser) peek() filterToken {
+	return self.tokens[self.pos]
+}
+
+func (self *filterParser) next() filterToken {
+	token := self.tokens[self.pos]
+	if token.kind != filterTokenEnd {
+		self.pos++
+	}
+	return token
+}
+
+func (self *filterParser) parseExpression() (FilterExpression, error) {
+	left, err := self.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for self.peek().isKeyword("OR") {
+		self.next()
+		right, err := self.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		left = orExpression{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (self *filterParser) parseTerm() (FilterExpression, error) {
+	left, err := self.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	for self.peek().isKeyword("AND") {
+		self.next()
+		right, err := self.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+
+		left = andExpression{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (self *filterParser) parseFactor() (FilterExpression, error) {
+	token := self.peek()
+	switch {
+	case token.isKeyword("NOT"):
+		self.next()
+		expression, err := self.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpression{expression: expression}, nil
+	case token.kind == filterTokenOpen:
+		self.next()
+		expression, err := self.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := self.next(); closing.kind != filterTokenClose {
+			return nil, newFilterError(closing.position, "expected ')' but found %s", closing)
+		}
+
+		return expression, nil
+	default:
+		return self.parseComparison()
+	}
+}
+
+func (self *filterParser) parseComparison() (FilterExpression, error) {
+	field := self.next()
+	if field.kind != filterTokenWord || field.isKeyword("AND") || field.isKeyword("OR") {
+		return nil, newFilterError(field.position, "expected property name but found %s", field)
+	}
+
+	isLabel := strings.HasPrefix(field.value, labelPrefix)
+	if isLabel && len(field.value) == len(labelPrefix) {
+		return nil, newFilterError(field.position, "label key is missing")
+	}
+
+	if isLabel && self.peek().kind != filterTokenOperator {
+		return labelExpression{key: strings.TrimPrefix(field.value, labelPrefix), operator: LabelExistOperator}, nil
+	}
+
+	operatorToken := self.next()
+	if operatorToken.kind != filterTokenOperator {
+		return nil, newFilterError(operatorToken.position, "expected operator after %s but found %s", field,
+			operatorToken)
+	}
+
+	operator := FilterOperator(operatorToken.value)
+	if operator == "==" {
+		operator = EqualOperator
+	}
+
+	valueToken := self.next()
+	if valueToken.kind != filterTokenWord && valueToken.kind != filterTokenString {
+		return nil, newFilterError(valueToken.position, "expected value after %s but found %s", operatorToken,
+			valueToken)
+	}
+
+	var compiled *regexp.Regexp
+	if operator == MatchOperator || operator == NotMatchOperator {
+		var err error
+		if compiled, err = regexp.Compile(valueToken.value); err != nil {
+			return nil, newFilterError(valueToken.position, "invalid regular expression: %s", err)
+		}
+	}
+
+	if isLabel {
+		switch operator {
+		case EqualOperator, NotEqualOperator, MatchOperator, NotMatchOperator:
+			return labelExpression{key: strings.TrimPrefix(field.value, labelPrefix), operator: operator,
+				value: valueToken.value, regexp: compiled}, nil
+		default:
+			return nil, newFilterError(operatorToken.position, "operator %s is not supported for labels", operatorToken)
+		}
+	}
+
+	expression := comparisonExpression{property: PropertyName(field.value), operator: operator,
+		value: valueToken.value, regexp: compiled}
+	if IsMetricProperty(expression.property) && compiled == nil {
+		parsed, err := parseMetricValue(expression.property, valueToken.value)
+		if err != nil {
+			return nil, newFilterError(valueToken.position, "invalid quantity %s", valueToken)
+		}
+
+		expression.parsed = parsed
+	}
+
+	return expression, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/dashboard/errors"
+)
+
+type testObjectCell struct {
+	metaV1.ObjectMeta
+	Status   string
+	Restarts int
+}
+
+func (self testObjectCell) GetProperty(name PropertyName) ComparableValue {
+	switch name {
+	case NameProperty:
+		return StdComparableString(self.Name)
+	case StatusProperty:
+		return StdComparableString(self.Status)
+	case RestartsProperty:
+		return StdComparableInt(self.Restarts)
+	default:
+		return nil
+	}
+}
+
+func getObjectCellList() []DataCell {
+	return []DataCell{
+		testObjectCell{metaV1.ObjectMeta{Name: "api-1", Labels: map[string]string{"team": "payments"}}, "Running", 0},
+		testObjectCell{metaV1.ObjectMeta{Name: "api-2", Labels: map[string]string{"team": "search"}}, "Pending", 5},
+		testObjectCell{metaV1.ObjectMeta{Name: "web-1", Labels: map[string]string{"team": "payments"}}, "Failed", 4},
+		testObjectCell{metaV1.ObjectMeta{Name: "web-2"}, "Failed", 1},
+		testObjectCell{metaV1.ObjectMeta{Name: "db-1", Labels: map[string]string{"team": "data"}}, "Running", 10},
+	}
+}
+
+func TestFilterExpression(t *testing.T) {
+	cases := []struct {
+		expression string
+		expected   []string
+	}{
+		{`status!=Running AND (name~^api- OR label:team=payments) AND restarts>3`, []string{"api-2", "web-1"}},
+		{`status=Running`, []string{"api-1", "db-1"}},
+		{`status == "Running" or restarts >= 5`, []string{"api-1", "api-2", "db-1"}},
+		{`NOT label:team`, []string{"web-2"}},
+		{`label:team!=payments`, []string{"api-2", "web-2", "db-1"}},
+		{`label:team~'^(payments|data)$' AND NOT name!~1$`, []string{"api-1", "web-1", "db-1"}},
+		{`restarts<=1 OR restarts=10`, []string{"api-1", "web-2", "db-1"}},
+		{`restarts>abc`, []string{}},
+		{`unknown=value OR NOT unknown=value`, []string{"api-1", "api-2", "web-1", "web-2", "db-1"}},
+	}
+
+	for _, c := range cases {
+		filterQuery, err := ParseFilterQuery(c.expression)
+		if err != nil {
+			t.Fatalf("ParseFilterQuery(%s) returned error: %s", c.expression, err)
+		}
+
+		selector := DataSelector{
+			GenericDataList: getObjectCellList(),
+			DataSelectQuery: &DataSelectQuery{FilterQuery: filterQuery},
+		}
+
+		names := []string{}
+		for _, cell := range selector.Filter().GenericDataList {
+			names = append(names, cell.(testObjectCell).Name)
+		}
+
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("Filter(%s) == %v, expected %v", c.expression, names, c.expected)
+		}
+	}
+}
+
+func TestParseFilterExpression(t *testing.T) {
+	cases := []struct {
+		expression string
+		expected   string
+	}{
+		{`a=1 OR b=2 AND c=3`, `(a="1" OR (b="2" AND c="3"))`},
+		{`(a=1 OR b=2) and not c~"x y"`, `((a="1" OR b="2") AND NOT c~"x y")`},
+		{`label:app.kubernetes.io/name=web AND label:team`, `(label:app.kubernetes.io/name="web" AND label:team)`},
+		{`name='it\'s'`, `name="it's"`},
+		{`memoryUsage>1Gi`, `memoryUsage>"1Gi"`},
+	}
+
+	for _, c := range cases {
+		expression, err := ParseFilterExpression(c.expression)
+		if err != nil {
+			t.Errorf("ParseFilterExpression(%s) returned error: %s", c.expression, err)
+			continue
+		}
+
+		if expression.String() != c.expected {
+			t.Errorf("ParseFilterExpression(%s) == %s, expected %s", c.expression, expression, c.expected)
+		}
+	}
+}
+
+func TestParseFilterExpressionErrors(t *testing.T) {
+	cases := []struct {
+		expression string
+		expected   string
+	}{
+		{`status`, "position 7: expected operator after 'status' but found end of expression"},
+		{`status=`, "position 8: expected value after '=' but found end of expression"},
+		{`(status=Running`, "position 16: expected ')' but found end of expression"},
+		{`status=Running name=a`, "position 16: unexpected 'name'"},
+		{`status=Running AND`, "position 19: expected property name but found end of expression"},
+		{`name~(`, "position 6: expected value after '~' but found '('"},
+		{`name~"("`, "position 6: invalid regular expression"},
+		{`name="abc`, "position 6: unterminated string"},
+		{`name!abc`, "position 5: unknown operator '!'"},
+		{`label:team>1`, "position 11: operator '>' is not supported for labels"},
+		{`label:=a`, "position 1: label key is missing"},
+		{`cpuUsage>lots`, "position 10: invalid quantity 'lots'"},
+	}
+
+	for _, c := range cases {
+		_, err := ParseFilterExpression(c.expression)
+		if !errors.IsBadRequest(err) || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("ParseFilterExpression(%s) returned error %v, expected bad request containing %q",
+				c.expression, err, c.expected)
+		}
+	}
+}
+
+func TestParseFilterQuery(t *testing.T) {
+	cases := []struct {
+		raw      string
+		expected *FilterQuery
+	}{
+		{"", NoFilter},
+		{"name,abc", &FilterQuery{FilterByList: []FilterBy{{Property: "name", Value: StdComparableString("abc")}}}},
+		{`name="a,b"`, &FilterQuery{FilterByList: []FilterBy{}, Expression: comparisonExpression{
+			property: "name", operator: EqualOperator, value: "a,b"}}},
+		{"name,a,b", NoFilter},
+		{"cpuUsage,abc", NoFilter},
+	}
+
+	for _, c := range cases {
+		actual, err := ParseFilterQuery(c.raw)
+		if err != nil || !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("ParseFilterQuery(%s) == %#v, %v, expected %#v", c.raw, actual, err, c.expected)
+		}
+	}
+}
+
+func TestParseFilterQueryError(t *testing.T) {
+	for _, raw := range []string{"name~^a{1,3}", "name=a,b", "status,Running,name~^a{1,3}", "name"} {
+		if _, err := ParseFilterQuery(raw); !errors.IsBadRequest(err) {
+			t.Errorf("ParseFilterQuery(%s) should return bad request error, got %v", raw, err)
+		}
+	}
+}
@@ -14,6 +14,12 @@
 
 package dataselect
 
+import (
+	"fmt"
+
+	"k8s.io/dashboard/errors"
+)
+
 // By default backend pagination will not be applied.
 var NoPagination = NewPaginationQuery(-1, -1)
 
@@ -58,3 +64,26 @@ func (p *PaginationQuery) GetPaginationSettings(itemsCount int) (startIndex int,
 
 	return startIndex, endIndex
 }
+
+// NoChunk lists all resources from the apiserver at once.
+var NoChunk = &ChunkQuery{}
+
+// ChunkQuery structure represents cursor-based pagination settings. Unlike PaginationQuery it is applied by the
+// apiserver, so the whole list does not have to be loaded into memory. Query has this format
+// limit=100&continue=token, where token is taken from the list meta of the previous chunk.
+type ChunkQuery struct {
+	// Maximum number of items that should be listed from the apiserver. Zero means no limit.
+	Limit int64
+	// Token returned with the previous chunk of the list. Empty token means that the first chunk should be listed.
+	Continue string
+}
+
+// NewChunkQuery returns chunk query structure based on given parameters. Returns bad request error if the limit
+// is negative.
+func NewChunkQuery(limit int64, continueToken string) (*ChunkQuery, error) {
+	if limit < 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid limit: %d", limit))
+	}
+
+	return &ChunkQuery{Limit: limit, Continue: continueToken}, nil
+}
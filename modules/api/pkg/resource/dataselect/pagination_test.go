@@ -78,3 +78,29 @@ func TestGetPaginationSettings(t *testing.T) {
 		}
 	}
 }
+
+func TestNewChunkQuery(t *testing.T) {
+	cases := []struct {
+		limit         int64
+		continueToken string
+		expected      *ChunkQuery
+		expectedError bool
+	}{
+		{0, "", NoChunk, false},
+		{100, "", &ChunkQuery{Limit: 100}, false},
+		{100, "token", &ChunkQuery{Limit: 100, Continue: "token"}, false},
+		{-1, "", nil, true},
+	}
+
+	for _, c := range cases {
+		actual, err := NewChunkQuery(c.limit, c.continueToken)
+		if (err != nil) != c.expectedError {
+			t.Errorf("NewChunkQuery(%+v, %+v) returned error %v", c.limit, c.continueToken, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("NewChunkQuery(%+v, %+v) == %+v, expected %+v", c.limit, c.continueToken, actual, c.expected)
+		}
+	}
+}
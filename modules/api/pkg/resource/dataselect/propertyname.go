@@ -14,6 +14,12 @@
 
 package dataselect
 
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+)
+
 // PropertyName is used to get the value of certain property of data cell.
 // For example if we want to get the namespace of certain Deployment we can use DeploymentCell.GetProperty(NamespaceProperty)
 type PropertyName string
@@ -28,4 +34,39 @@ const (
 	FirstSeenProperty         = "firstSeen"
 	LastSeenProperty          = "lastSeen"
 	ReasonProperty            = "reason"
+	RestartsProperty          = "restarts"
+)
+
+// List of all property names backed by metrics. Values of these properties are not provided by data cells, but
+// downloaded for metric data cells before sorting and filtering.
+const (
+	CPUUsageProperty    = "cpuUsage"
+	MemoryUsageProperty = "memoryUsage"
 )
+
+// metricProperties maps properties backed by metrics to names of these metrics.
+var metricProperties = map[PropertyName]string{
+	CPUUsageProperty:    metricapi.CpuUsage,
+	MemoryUsageProperty: metricapi.MemoryUsage,
+}
+
+// IsMetricProperty returns true if the property value is taken from metrics.
+func IsMetricProperty(name PropertyName) bool {
+	_, exists := metricProperties[name]
+	return exists
+}
+
+// parseMetricValue parses quantity, i.e. 500m or 1Gi, to the value of the metric property. CPU usage is
+// expressed in millicores and memory usage in bytes.
+func parseMetricValue(name PropertyName, raw string) (ComparableValue, error) {
+	quantity, err := resource.ParseQuantity(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == CPUUsageProperty {
+		return StdComparableInt64(quantity.MilliValue()), nil
+	}
+
+	return StdComparableInt64(quantity.Value()), nil
+}
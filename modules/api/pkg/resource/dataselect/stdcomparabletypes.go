@@ -36,6 +36,17 @@ func (self StdComparableInt) Contains(otherV ComparableValue) bool {
 	return self.Compare(otherV) == 0
 }
 
+type StdComparableInt64 int64
+
+func (self StdComparableInt64) Compare(otherV ComparableValue) int {
+	other := otherV.(StdComparableInt64)
+	return ints64Compare(int64(self), int64(other))
+}
+
+func (self StdComparableInt64) Contains(otherV ComparableValue) bool {
+	return self.Compare(otherV) == 0
+}
+
 type StdComparableString string
 
 func (self StdComparableString) Compare(otherV ComparableValue) int {
@@ -23,6 +23,7 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	api "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -37,6 +38,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/dashboard/api/pkg/args"
+	dashboardclient "k8s.io/dashboard/client"
 	derrors "k8s.io/dashboard/errors"
 )
 
@@ -108,6 +110,18 @@ type AppDeploymentFromFileSpec struct {
 
 	// Whether validate content before creation or not
 	Validate bool `json:"validate"`
+
+	// Whether to use server-side apply instead of create
+	ServerSideApply bool `json:"serverSideApply"`
+
+	// Name of the field manager used by server-side apply
+	FieldManager string `json:"fieldManager"`
+
+	// Whether to take ownership of fields conflicting with other field managers
+	Force bool `json:"force"`
+
+	// Whether to only return the applied objects without persisting them
+	DryRun bool `json:"dryRun"`
 }
 
 // AppDeploymentFromFileResponse is a specification for deployment from file
@@ -120,6 +134,18 @@ type AppDeploymentFromFileResponse struct {
 
 	// Error after create resource
 	Error string `json:"error"`
+
+	// Results of the server-side apply of every object from the file
+	ApplyResults []dashboardclient.ApplyResult `json:"applyResults,omitempty"`
+}
+
+// AppDeploymentFromFileDiff contains differences between the live objects and the objects from the file
+type AppDeploymentFromFileDiff struct {
+	// Name of the file
+	Name string `json:"name"`
+
+	// Differences of every object from the file
+	Diffs []dashboardclient.ResourceDiff `json:"diffs"`
 }
 
 // PortMapping is a specification of port mapping for an application deployment.
@@ -304,6 +330,76 @@ func getLabelsMap(labels []Label) map[string]string {
 
 // DeployAppFromFile deploys an app based on the given yaml or json file.
 func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec) (bool, error) {
+	err := forEachFileObject(cfg, spec, func(resource dynamic.ResourceInterface, data *unstructured.Unstructured) error {
+		_, err := resource.Create(context.TODO(), data, metaV1.CreateOptions{})
+		return err
+	})
+
+	return err == nil, err
+}
+
+// ApplyAppFromFile applies all objects from the given yaml or json file using server-side apply. It stops at the first
+// object that could not be applied due to field ownership conflicts, unless in dry-run mode.
+func ApplyAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec, opts dashboardclient.ApplyOptions) (
+	[]dashboardclient.ApplyResult, error) {
+	results := make([]dashboardclient.ApplyResult, 0)
+	err := forEachFileObject(cfg, spec, func(resource dynamic.ResourceInterface, data *unstructured.Unstructured) error {
+		result, err := dashboardclient.Apply(context.TODO(), resource, data, opts)
+		if err != nil {
+			return err
+		}
+
+		results = append(results, *result)
+		if len(result.Conflicts) > 0 && !opts.DryRun {
+			return errApplyConflict
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errApplyConflict) {
+		return results, nil
+	}
+
+	return results, err
+}
+
+// DiffAppFromFile returns differences between the live objects and the objects from the given yaml or json file,
+// as they would be persisted by DeployAppFromFile, or by ApplyAppFromFile if apply options are given.
+func DiffAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec, applyOptions *dashboardclient.ApplyOptions) (
+	*AppDeploymentFromFileDiff, error) {
+	result := &AppDeploymentFromFileDiff{Name: spec.Name, Diffs: make([]dashboardclient.ResourceDiff, 0)}
+	err := forEachFileObject(cfg, spec, func(resource dynamic.ResourceInterface, data *unstructured.Unstructured) error {
+		diff, err := dashboardclient.Diff(context.TODO(), resource, data, applyOptions)
+		if err != nil {
+			return err
+		}
+
+		// Objects from the file are created, so deploying an existing object would fail.
+		if applyOptions == nil && diff.Exists {
+			gvk := data.GroupVersionKind()
+			return k8serrors.NewAlreadyExists(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind)},
+				data.GetName())
+		}
+
+		result.Diffs = append(result.Diffs, *diff)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// errApplyConflict stops processing of the file objects after the first apply conflict.
+var errApplyConflict = errors.New("apply conflict")
+
+// forEachFileObject decodes all objects from the given yaml or json file and calls fn with the matching resource
+// client for each of them.
+func forEachFileObject(cfg *rest.Config, spec *AppDeploymentFromFileSpec,
+	fn func(resource dynamic.ResourceInterface, data *unstructured.Unstructured) error) error {
 	reader := strings.NewReader(spec.Content)
 	klog.V(args.LogLevelVerbose).Infof("Namespace for deploy from file: %s\n", spec.Namespace)
 	d := yaml.NewYAMLOrJSONDecoder(reader, 4096)
@@ -311,9 +407,9 @@ func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec) (bool,
 		data := &unstructured.Unstructured{}
 		if err := d.Decode(data); err != nil {
 			if errors.Is(err, io.EOF) {
-				return true, nil
+				return nil
 			}
-			return false, err
+			return err
 		}
 
 		version := data.GetAPIVersion()
@@ -326,12 +422,12 @@ func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec) (bool,
 
 		discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
 		if err != nil {
-			return false, err
+			return err
 		}
 
 		apiResourceList, err := discoveryClient.ServerResourcesForGroupVersion(version)
 		if err != nil {
-			return false, err
+			return err
 		}
 		apiResources := apiResourceList.APIResources
 		var resource *metaV1.APIResource
@@ -342,12 +438,12 @@ func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec) (bool,
 			}
 		}
 		if resource == nil {
-			return false, fmt.Errorf("unknown resource kind: %s", kind)
+			return fmt.Errorf("unknown resource kind: %s", kind)
 		}
 
 		dynamicClient, err := dynamic.NewForConfig(cfg)
 		if err != nil {
-			return false, err
+			return err
 		}
 
 		groupVersionResource := schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: resource.Name}
@@ -357,14 +453,16 @@ func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec) (bool,
 			namespace = data.GetNamespace()
 		}
 
+		var resourceClient dynamic.ResourceInterface = dynamicClient.Resource(groupVersionResource)
 		if resource.Namespaced {
-			_, err = dynamicClient.Resource(groupVersionResource).Namespace(namespace).Create(context.TODO(), data, metaV1.CreateOptions{})
-		} else {
-			_, err = dynamicClient.Resource(groupVersionResource).Create(context.TODO(), data, metaV1.CreateOptions{})
+			resourceClient = dynamicClient.Resource(groupVersionResource).Namespace(namespace)
 		}
 
-		if err != nil {
-			return false, derrors.LocalizeError(err)
+		if err = fn(resourceClient, data); err != nil {
+			if errors.Is(err, errApplyConflict) {
+				return err
+			}
+			return derrors.LocalizeError(err)
 		}
 	}
 }
@@ -66,7 +66,7 @@ func GetDeploymentList(client client.Interface, nsQuery *common.NamespaceQuery,
 	klog.V(4).Infof("Getting list of all deployments in the cluster")
 
 	channels := &common.ResourceChannels{
-		DeploymentList: common.GetDeploymentListChannel(client, nsQuery, 1),
+		DeploymentList: common.GetDeploymentListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 		PodList:        common.GetPodListChannel(client, nsQuery, 1),
 		EventList:      common.GetEventListChannel(client, nsQuery, 1),
 		ReplicaSetList: common.GetReplicaSetListChannel(client, nsQuery, 1),
@@ -110,6 +110,7 @@ func GetDeploymentListFromChannels(channels *common.ResourceChannels, dsQuery *d
 
 	deploymentList := toDeploymentList(deployments.Items, pods.Items, events.Items, rs.Items, nonCriticalErrors,
 		dsQuery, metricClient)
+	deploymentList.ListMeta.SetChunk(deployments.ListMeta)
 	deploymentList.Status = getStatus(deployments, rs.Items, pods.Items, events.Items)
 	return deploymentList, nil
 }
@@ -28,7 +28,7 @@ func GetEventList(client k8sClient.Interface, nsQuery *common.NamespaceQuery,
 	klog.V(4).Infof("Getting list of events in namespace: %s", nsQuery.ToRequestParam())
 
 	channels := &common.ResourceChannels{
-		EventList: common.GetEventListChannel(client, nsQuery, 2),
+		EventList: common.GetEventListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 2),
 	}
 
 	return GetEventListFromChannels(channels, dsQuery)
@@ -49,6 +49,7 @@ func GetEventListFromChannels(channels *common.ResourceChannels, dsQuery *datase
 	}
 
 	result := CreateEventList(FillEventsType(eventList.Items), dsQuery)
+	result.ListMeta.SetChunk(eventList.ListMeta)
 	result.Errors = nonCriticalErrors
 
 	return &result, nil
@@ -0,0 +1,402 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/helpers"
+	"k8s.io/dashboard/types"
+)
+
+// timelineChildKinds defines the owner tree of the timeline. Objects of the child kinds are included when they are
+// owned by the object of the parent kind.
+var timelineChildKinds = map[types.ResourceKind][]types.ResourceKind{
+	types.ResourceKindDeployment:  {types.ResourceKindReplicaSet},
+	types.ResourceKindReplicaSet:  {types.ResourceKindPod},
+	types.ResourceKindStatefulSet: {types.ResourceKindPod},
+	types.ResourceKindDaemonSet:   {types.ResourceKindPod},
+	types.ResourceKindCronJob:     {types.ResourceKindJob},
+	types.ResourceKindJob:         {types.ResourceKindPod},
+	types.ResourceKindPod:         {},
+}
+
+// TimelineObject is an object of the owner tree.
+type TimelineObject struct {
+	Kind types.ResourceKind `json:"kind"`
+	Name string             `json:"name"`
+	UID  apimachinery.UID   `json:"uid"`
+}
+
+// TimelineEvent groups repeated events of the same object with the same type, reason and message.
+type TimelineEvent struct {
+	Object              TimelineObject `json:"object"`
+	Type                string         `json:"type"`
+	Reason              string         `json:"reason"`
+	Message             string         `json:"message"`
+	ReportingController string         `json:"reportingController,omitempty"`
+	// Count is the number of occurrences, including the ones aggregated into the event series.
+	Count     int32       `json:"count"`
+	FirstSeen metaV1.Time `json:"firstSeen"`
+	LastSeen  metaV1.Time `json:"lastSeen"`
+}
+
+// Timeline is a list of events of all objects in the owner tree, the most recent first.
+type Timeline struct {
+	Objects []TimelineObject `json:"objects"`
+	Events  []TimelineEvent  `json:"events"`
+}
+
+// TimelineFilter selects events of the timeline. Empty fields match all events.
+type TimelineFilter struct {
+	Types   []string
+	Reasons []string
+	// Events that were last seen before From or first seen after To are filtered out.
+	From *metaV1.Time
+	To   *metaV1.Time
+}
+
+// NewTimelineFilter parses the filter from comma separated lists of types and reasons, and either the duration of
+// the time window ending now or its RFC 3339 bounds.
+func NewTimelineFilter(eventTypes, reasons, since, from, to string) (*TimelineFilter, error) {
+	filter := &TimelineFilter{Types: splitList(eventTypes), Reasons: splitList(reasons)}
+
+	if len(since) > 0 {
+		duration, err := time.ParseDuration(since)
+		if err != nil || duration <= 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid since parameter: %s", since))
+		}
+
+		start := metaV1.NewTime(time.Now().Add(-duration))
+		filter.From = &start
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value string
+		into  **metaV1.Time
+	}{{"from", from, &filter.From}, {"to", to, &filter.To}} {
+		if len(bound.value) == 0 {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid %s parameter: %s", bound.name, bound.value))
+		}
+
+		value := metaV1.NewTime(parsed)
+		*bound.into = &value
+	}
+
+	return filter, nil
+}
+
+// GetEventTimeline returns the merged timeline of events of the object and all objects it owns, i.e. ReplicaSets and
+// Pods of the Deployment. Events are read from the events.k8s.io/v1 API, so the event series are included.
+func GetEventTimeline(client kubernetes.Interface, kind types.ResourceKind, namespace, name string,
+	filter *TimelineFilter) (*Timeline, error) {
+	if _, ok := timelineChildKinds[kind]; !ok {
+		return nil, errors.NewBadRequest(fmt.Sprintf("timeline is not supported for %s", kind))
+	}
+
+	objects, err := getOwnerTree(client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := client.EventsV1().Events(namespace).List(context.TODO(), helpers.ListEverything)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Timeline{Objects: objects, Events: toTimelineEvents(events.Items, objects, filter)}, nil
+}
+
+// getOwnerTree returns the object and all objects owned by it, level by level.
+func getOwnerTree(client kubernetes.Interface, kind types.ResourceKind, namespace, name string) (
+	[]TimelineObject, error) {
+	root, err := getTimelineObject(client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []TimelineObject{root}
+	level := []TimelineObject{root}
+	for len(level) > 0 {
+		owners := make(map[types.ResourceKind]map[apimachinery.UID]bool)
+		for _, object := range level {
+			for _, childKind := range timelineChildKinds[object.Kind] {
+				if owners[childKind] == nil {
+					owners[childKind] = make(map[apimachinery.UID]bool)
+				}
+				owners[childKind][object.UID] = true
+			}
+		}
+
+		level = nil
+		for childKind, ownerUIDs := range owners {
+			children, err := listTimelineObjects(client, childKind, namespace)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, child := range children {
+				if isOwnedBy(child, ownerUIDs) {
+					level = append(level, toTimelineObject(childKind, child))
+				}
+			}
+		}
+
+		sort.SliceStable(level, func(i, j int) bool {
+			if level[i].Kind != level[j].Kind {
+				return level[i].Kind < level[j].Kind
+			}
+			return level[i].Name < level[j].Name
+		})
+		result = append(result, level...)
+	}
+
+	return result, nil
+}
+
+func getTimelineObject(client kubernetes.Interface, kind types.ResourceKind, namespace, name string) (
+	TimelineObject, error) {
+	var object metaV1.Object
+	var err error
+	switch kind {
+	case types.ResourceKindDeployment:
+		object, err = client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	case types.ResourceKindReplicaSet:
+		object, err = client.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	case types.ResourceKindStatefulSet:
+		object, err = client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	case types.ResourceKindDaemonSet:
+		object, err = client.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	case types.ResourceKindCronJob:
+		object, err = client.BatchV1().CronJobs(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	case types.ResourceKindJob:
+		object, err = client.BatchV1().Jobs(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	default:
+		object, err = client.CoreV1().Pods(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	}
+
+	if err != nil {
+		return TimelineObject{}, err
+	}
+
+	return toTimelineObject(kind, object), nil
+}
+
+// listTimelineObjects lists objects of the child kinds defined in timelineChildKinds.
+func listTimelineObjects(client kubernetes.Interface, kind types.ResourceKind, namespace string) (
+	[]metaV1.Object, error) {
+	result := make([]metaV1.Object, 0)
+	switch kind {
+	case types.ResourceKindReplicaSet:
+		list, err := client.AppsV1().ReplicaSets(namespace).List(context.TODO(), helpers.ListEverything)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			result = append(result, &list.Items[i])
+		}
+	case types.ResourceKindJob:
+		list, err := client.BatchV1().Jobs(namespace).List(context.TODO(), helpers.ListEverything)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			result = append(result, &list.Items[i])
+		}
+	case types.ResourceKindPod:
+		list, err := client.CoreV1().Pods(namespace).List(context.TODO(), helpers.ListEverything)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			result = append(result, &list.Items[i])
+		}
+	}
+
+	return result, nil
+}
+
+func toTimelineObject(kind types.ResourceKind, object metaV1.Object) TimelineObject {
+	return TimelineObject{Kind: kind, Name: object.GetName(), UID: object.GetUID()}
+}
+
+func isOwnedBy(object metaV1.Object, ownerUIDs map[apimachinery.UID]bool) bool {
+	for _, reference := range object.GetOwnerReferences() {
+		if ownerUIDs[reference.UID] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toTimelineEvents groups the events of the objects, filters them and sorts them from the most recent.
+func toTimelineEvents(events []eventsv1.Event, objects []TimelineObject, filter *TimelineFilter) []TimelineEvent {
+	objectsByUID := make(map[apimachinery.UID]TimelineObject, len(objects))
+	for _, object := range objects {
+		objectsByUID[object.UID] = object
+	}
+
+	type groupKey struct {
+		uid       apimachinery.UID
+		eventType string
+		reason    string
+		message   string
+	}
+
+	groups := make(map[groupKey]*TimelineEvent)
+	keys := make([]groupKey, 0)
+	for _, event := range events {
+		object, ok := objectsByUID[event.Regarding.UID]
+		if !ok {
+			continue
+		}
+
+		current := toTimelineEvent(object, event)
+		key := groupKey{uid: object.UID, eventType: current.Type, reason: current.Reason, message: current.Message}
+		group, ok := groups[key]
+		if !ok {
+			groups[key] = &current
+			keys = append(keys, key)
+			continue
+		}
+
+		group.Count += current.Count
+		if current.FirstSeen.Before(&group.FirstSeen) {
+			group.FirstSeen = current.FirstSeen
+		}
+		if group.LastSeen.Before(&current.LastSeen) {
+			group.LastSeen = current.LastSeen
+			group.ReportingController = current.ReportingController
+		}
+	}
+
+	result := make([]TimelineEvent, 0)
+	for _, key := range keys {
+		if filter.matches(groups[key]) {
+			result = append(result, *groups[key])
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[j].LastSeen.Before(&result[i].LastSeen)
+	})
+
+	return result
+}
+
+// toTimelineEvent reads the occurrences of the event. Events created by the core/v1 API have only the deprecated
+// fields set, while the events.k8s.io/v1 API aggregates repeated occurrences into the series.
+func toTimelineEvent(object TimelineObject, event eventsv1.Event) TimelineEvent {
+	firstSeen := metaV1.NewTime(event.EventTime.Time)
+	if firstSeen.IsZero() {
+		firstSeen = event.DeprecatedFirstTimestamp
+	}
+	if firstSeen.IsZero() {
+		firstSeen = event.CreationTimestamp
+	}
+
+	lastSeen := event.DeprecatedLastTimestamp
+	count := event.DeprecatedCount
+	if event.Series != nil {
+		lastSeen = metaV1.NewTime(event.Series.LastObservedTime.Time)
+		count = event.Series.Count
+	}
+
+	if lastSeen.IsZero() || lastSeen.Before(&firstSeen) {
+		lastSeen = firstSeen
+	}
+
+	if count < 1 {
+		count = 1
+	}
+
+	eventType := event.Type
+	if len(eventType) == 0 {
+		eventType = v1.EventTypeNormal
+		if isFailedReason(event.Reason, FailedReasonPartials...) {
+			eventType = v1.EventTypeWarning
+		}
+	}
+
+	return TimelineEvent{
+		Object:              object,
+		Type:                eventType,
+		Reason:              event.Reason,
+		Message:             event.Note,
+		ReportingController: event.ReportingController,
+		Count:               count,
+		FirstSeen:           firstSeen,
+		LastSeen:            lastSeen,
+	}
+}
+
+func (self *TimelineFilter) matches(event *TimelineEvent) bool {
+	if self == nil {
+		return true
+	}
+
+	if len(self.Types) > 0 && !containsFold(self.Types, event.Type) {
+		return false
+	}
+
+	if len(self.Reasons) > 0 && !containsFold(self.Reasons, event.Reason) {
+		return false
+	}
+
+	if self.From != nil && event.LastSeen.Before(self.From) {
+		return false
+	}
+
+	return self.To == nil || !self.To.Before(&event.FirstSeen)
+}
+
+func splitList(value string) []string {
+	result := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+func newTimelineEvent(name string, regarding apimachinery.UID, reason string, eventTime time.Time,
+	series *eventsv1.EventSeries) *eventsv1.Event {
+	return &eventsv1.Event{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Regarding:  v1.ObjectReference{UID: regarding},
+		Reason:     reason,
+		Note:       reason + " note",
+		EventTime:  metaV1.NewMicroTime(eventTime),
+		Series:     series,
+	}
+}
+
+func TestGetEventTimeline(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ownedBy := func(uid apimachinery.UID) []metaV1.OwnerReference {
+		return []metaV1.OwnerReference{{UID: uid}}
+	}
+
+	client := fake.NewSimpleClientset(
+		&apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default", UID: "deploy"}},
+		&apps.ReplicaSet{ObjectMeta: metaV1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "rs",
+			OwnerReferences: ownedBy("deploy")}},
+		&apps.ReplicaSet{ObjectMeta: metaV1.ObjectMeta{Name: "other-1", Namespace: "default", UID: "other-rs"}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "web-1-a", Namespace: "default", UID: "pod",
+			OwnerReferences: ownedBy("rs")}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "other-1-a", Namespace: "default", UID: "other-pod",
+			OwnerReferences: ownedBy("other-rs")}},
+		newTimelineEvent("scaled", "deploy", "ScalingReplicaSet", now.Add(-time.Hour), nil),
+		newTimelineEvent("created", "rs", "SuccessfulCreate", now.Add(-50*time.Minute), nil),
+		newTimelineEvent("backoff-1", "pod", "BackOff", now.Add(-20*time.Minute),
+			&eventsv1.EventSeries{Count: 5, LastObservedTime: metaV1.NewMicroTime(now.Add(-10 * time.Minute))}),
+		newTimelineEvent("backoff-2", "pod", "BackOff", now.Add(-5*time.Minute), nil),
+		newTimelineEvent("other", "other-pod", "BackOff", now.Add(-time.Minute), nil),
+	)
+
+	actual, err := GetEventTimeline(client, types.ResourceKindDeployment, "default", "web", nil)
+	if err != nil {
+		t.Fatalf("GetEventTimeline() returned error: %v", err)
+	}
+
+	deployment := TimelineObject{Kind: types.ResourceKindDeployment, Name: "web", UID: "deploy"}
+	replicaSet := TimelineObject{Kind: types.ResourceKindReplicaSet, Name: "web-1", UID: "rs"}
+	pod := TimelineObject{Kind: types.ResourceKindPod, Name: "web-1-a", UID: "pod"}
+	expected := &Timeline{
+		Objects: []TimelineObject{deployment, replicaSet, pod},
+		Events: []TimelineEvent{
+			{Object: pod, Type: v1.EventTypeWarning, Reason: "BackOff", Message: "BackOff note", Count: 6,
+				FirstSeen: metaV1.NewTime(now.Add(-20 * time.Minute)), LastSeen: metaV1.NewTime(now.Add(-5 * time.Minute))},
+			{Object: replicaSet, Type: v1.EventTypeNormal, Reason: "SuccessfulCreate", Message: "SuccessfulCreate note",
+				Count: 1, FirstSeen: metaV1.NewTime(now.Add(-50 * time.Minute)),
+				LastSeen: metaV1.NewTime(now.Add(-50 * time.Minute))},
+			{Object: deployment, Type: v1.EventTypeNormal, Reason: "ScalingReplicaSet", Message: "ScalingReplicaSet note",
+				Count: 1, FirstSeen: metaV1.NewTime(now.Add(-time.Hour)), LastSeen: metaV1.NewTime(now.Add(-time.Hour))},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetEventTimeline() == \n%#v\nexpected \n%#v", actual, expected)
+	}
+
+	from := metaV1.NewTime(now.Add(-30 * time.Minute))
+	filtered, err := GetEventTimeline(client, types.ResourceKindDeployment, "default", "web",
+		&TimelineFilter{Types: []string{"warning"}, From: &from})
+	if err != nil {
+		t.Fatalf("GetEventTimeline() returned error: %v", err)
+	}
+
+	if len(filtered.Events) != 1 || filtered.Events[0].Reason != "BackOff" {
+		t.Errorf("GetEventTimeline() with filter returned events %#v, expected only BackOff", filtered.Events)
+	}
+
+	if _, err := GetEventTimeline(client, types.ResourceKindService, "default", "web", nil); !errors.IsBadRequest(err) {
+		t.Errorf("GetEventTimeline() of service should return bad request error, got %v", err)
+	}
+}
+
+func TestNewTimelineFilter(t *testing.T) {
+	from := metaV1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	cases := []struct {
+		types, reasons, since, from, to string
+		expected                        *TimelineFilter
+		expectedError                   bool
+	}{
+		{"", "", "", "", "", &TimelineFilter{Types: []string{}, Reasons: []string{}}, false},
+		{
+			"Warning", "BackOff, Failed", "", "2024-01-01T12:00:00Z", "",
+			&TimelineFilter{Types: []string{"Warning"}, Reasons: []string{"BackOff", "Failed"}, From: &from},
+			false,
+		},
+		{"", "", "yesterday", "", "", nil, true},
+		{"", "", "", "", "noon", nil, true},
+	}
+
+	for _, c := range cases {
+		actual, err := NewTimelineFilter(c.types, c.reasons, c.since, c.from, c.to)
+		if c.expectedError != errors.IsBadRequest(err) {
+			t.Errorf("NewTimelineFilter(%q, %q, %q, %q, %q) returned error %v", c.types, c.reasons, c.since, c.from,
+				c.to, err)
+		}
+
+		if !c.expectedError && !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("NewTimelineFilter() == \n%#v\nexpected \n%#v", actual, c.expected)
+		}
+	}
+
+	filter, _ := NewTimelineFilter("", "", "30m", "", "")
+	if filter.From == nil || time.Since(filter.From.Time) < 30*time.Minute-time.Second {
+		t.Errorf("NewTimelineFilter() with since should start the window 30 minutes ago, got %v", filter.From)
+	}
+}
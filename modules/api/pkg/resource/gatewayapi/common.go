@@ -0,0 +1,146 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gatewayapi provides Gateway API resources. They are read using the dynamic client, so they work without
+// compiled-in Gateway API types and the Gateway API does not have to be installed in the cluster.
+package gatewayapi
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
+)
+
+// GroupName is the API group of the Gateway API resources.
+const GroupName = "gateway.networking.k8s.io"
+
+var (
+	GatewayClassResource   = schema.GroupVersionResource{Group: GroupName, Version: "v1", Resource: "gatewayclasses"}
+	GatewayResource        = schema.GroupVersionResource{Group: GroupName, Version: "v1", Resource: "gateways"}
+	HTTPRouteResource      = schema.GroupVersionResource{Group: GroupName, Version: "v1", Resource: "httproutes"}
+	GRPCRouteResource      = schema.GroupVersionResource{Group: GroupName, Version: "v1", Resource: "grpcroutes"}
+	ReferenceGrantResource = schema.GroupVersionResource{Group: GroupName, Version: "v1beta1", Resource: "referencegrants"}
+)
+
+// ParentReference identifies a parent of the route, usually a Gateway.
+type ParentReference struct {
+	Group       string `json:"group,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+	Port        *int32 `json:"port,omitempty"`
+}
+
+// listObjects lists objects of the resource in the namespace. Missing resource means that the Gateway API is not
+// installed in the cluster, so it results in an empty list.
+func listObjects(client dynamic.Interface, resource schema.GroupVersionResource, namespace string,
+	options metaV1.ListOptions) (*unstructured.UnstructuredList, error) {
+	list, err := client.Resource(resource).Namespace(namespace).List(context.TODO(), options)
+	if k8serrors.IsNotFound(err) {
+		return &unstructured.UnstructuredList{}, nil
+	}
+
+	if err != nil {
+		return &unstructured.UnstructuredList{}, err
+	}
+
+	return list, nil
+}
+
+// chunkMeta returns list meta of the chunk of objects listed from the apiserver.
+func chunkMeta(list *unstructured.UnstructuredList) metaV1.ListMeta {
+	return metaV1.ListMeta{Continue: list.GetContinue(), RemainingItemCount: list.GetRemainingItemCount()}
+}
+
+// listObjectsWithFallback lists all objects in the namespace query. When listing from all namespaces is forbidden,
+// it falls back to the given namespace and reports the error as non-critical. Objects are not listed in chunks, as
+// continue token of one list can not be used by the other and only the objects are returned.
+func listObjectsWithFallback(client dynamic.Interface, resource schema.GroupVersionResource,
+	namespace *common.NamespaceQuery, fallbackNamespace string, options metaV1.ListOptions, nonCriticalErrors []error) (
+	[]unstructured.Unstructured, []error, error) {
+	options.Limit = 0
+	options.Continue = ""
+
+	list, err := listObjects(client, resource, namespace.ToRequestParam(), options)
+	if err == nil || !k8serrors.IsForbidden(err) || namespace.ToRequestParam() == fallbackNamespace {
+		nonCriticalErrors, criticalError := errors.AppendError(err, nonCriticalErrors)
+		return list.Items, nonCriticalErrors, criticalError
+	}
+
+	nonCriticalErrors, _ = errors.AppendError(err, nonCriticalErrors)
+	list, err = listObjects(client, resource, fallbackNamespace, options)
+	nonCriticalErrors, criticalError := errors.AppendError(err, nonCriticalErrors)
+	return list.Items, nonCriticalErrors, criticalError
+}
+
+func getObject(client dynamic.Interface, resource schema.GroupVersionResource, namespace, name string) (
+	*unstructured.Unstructured, error) {
+	return client.Resource(resource).Namespace(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+}
+
+func fromUnstructured(object *unstructured.Unstructured, into interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(object.UnstructuredContent(), into)
+}
+
+// getSpec returns the spec of the object, so details can show fields that are not parsed by the dashboard.
+func getSpec(object *unstructured.Unstructured) map[string]interface{} {
+	spec, _, _ := unstructured.NestedMap(object.Object, "spec")
+	return spec
+}
+
+// ObjectCell is a wrapper around the Gateway API object that implements the DataCell interface.
+type ObjectCell struct {
+	unstructured.Unstructured
+}
+
+func (self ObjectCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.GetName())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.GetCreationTimestamp().Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.GetNamespace())
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+// selectObjects applies the data select query to the objects and returns them together with the filtered total.
+func selectObjects(objects []unstructured.Unstructured, dsQuery *dataselect.DataSelectQuery) (
+	[]unstructured.Unstructured, int) {
+	cells := make([]dataselect.DataCell, len(objects))
+	for i := range objects {
+		cells[i] = ObjectCell{objects[i]}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(cells, dsQuery)
+	result := make([]unstructured.Unstructured, len(cells))
+	for i := range cells {
+		result[i] = cells[i].(ObjectCell).Unstructured
+	}
+
+	return result, filteredTotal
+}
@@ -0,0 +1,133 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/helpers"
+)
+
+// serviceKind is the kind of the core Service resource as used by the Gateway API references.
+const serviceKind = "Service"
+
+// GetServiceRouteList returns HTTPRoutes and GRPCRoutes from all namespaces that have the service as a backend.
+// When listing routes from all namespaces is forbidden, only routes from the service namespace are returned.
+func GetServiceRouteList(client dynamic.Interface, dsQuery *dataselect.DataSelectQuery, namespace,
+	serviceName string) (*RouteList, error) {
+	grantList, err := listObjects(client, ReferenceGrantResource, namespace, helpers.ListEverything)
+	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	grants, err := toReferenceGrants(grantList.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Routes of all kinds are merged into a single list, so they are not listed in chunks.
+	options := dsQuery.ListOptions()
+	routes := make([]Route, 0)
+	for kind, route := range routeKinds {
+		var objects []unstructured.Unstructured
+		objects, nonCriticalErrors, criticalError = listObjectsWithFallback(client, route.resource,
+			common.NewNamespaceQuery(nil), namespace, options, nonCriticalErrors)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+
+		kindRoutes, err := toRoutes(objects, kind)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, kindRoutes...)
+	}
+
+	return toRouteList(FilterRoutesByService(routes, grants, namespace, serviceName), nonCriticalErrors, dsQuery), nil
+}
+
+// FilterRoutesByService returns routes that have the service as a backend. Backends referencing the service from
+// a different namespace are resolved only when a ReferenceGrant from the service namespace allows it.
+func FilterRoutesByService(routes []Route, grants []ReferenceGrant, namespace, serviceName string) []Route {
+	result := make([]Route, 0)
+	for _, route := range routes {
+		if routeMatchesService(route, grants, namespace, serviceName) {
+			result = append(result, route)
+		}
+	}
+
+	return result
+}
+
+func routeMatchesService(route Route, grants []ReferenceGrant, namespace, serviceName string) bool {
+	for _, backendRef := range route.BackendRefs {
+		if !backendRefMatchesService(backendRef, route.ObjectMeta.Namespace, namespace, serviceName) {
+			continue
+		}
+
+		if route.ObjectMeta.Namespace == namespace || isReferenceGranted(grants, route, serviceName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func backendRefMatchesService(backendRef BackendReference, routeNamespace, namespace, serviceName string) bool {
+	backendNamespace := backendRef.Namespace
+	if len(backendNamespace) == 0 {
+		backendNamespace = routeNamespace
+	}
+
+	return isCoreGroup(backendRef.Group) && (len(backendRef.Kind) == 0 || backendRef.Kind == serviceKind) &&
+		backendRef.Name == serviceName && backendNamespace == namespace
+}
+
+// isReferenceGranted checks if any of the ReferenceGrants allows the route to reference the service.
+func isReferenceGranted(grants []ReferenceGrant, route Route, serviceName string) bool {
+	kind := routeKinds[route.TypeMeta.Kind].name
+	for _, grant := range grants {
+		if !grantAllowsFrom(grant, kind, route.ObjectMeta.Namespace) {
+			continue
+		}
+
+		for _, to := range grant.To {
+			if isCoreGroup(to.Group) && to.Kind == serviceKind && (len(to.Name) == 0 || to.Name == serviceName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func grantAllowsFrom(grant ReferenceGrant, kind, namespace string) bool {
+	for _, from := range grant.From {
+		if from.Group == GroupName && from.Kind == kind && from.Namespace == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isCoreGroup(group string) bool {
+	return len(group) == 0 || group == "core"
+}
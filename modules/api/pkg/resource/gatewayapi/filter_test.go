@@ -0,0 +1,83 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gatewayapi
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/dashboard/types"
+)
+
+func TestFilterRoutesByService(t *testing.T) {
+	httpRoute := func(namespace, name string, backendRefs ...BackendReference) Route {
+		return Route{
+			ObjectMeta:  types.ObjectMeta{Namespace: namespace, Name: name},
+			TypeMeta:    types.TypeMeta{Kind: types.ResourceKindHTTPRoute},
+			BackendRefs: backendRefs,
+		}
+	}
+
+	routes := []Route{
+		httpRoute("default", "same-namespace", BackendReference{Name: "web"}),
+		httpRoute("default", "other-service", BackendReference{Name: "api"}),
+		httpRoute("default", "not-a-service", BackendReference{Group: "example.com", Kind: "Bucket", Name: "web"}),
+		httpRoute("other", "cross-namespace", BackendReference{Kind: "Service", Namespace: "default", Name: "web"}),
+		httpRoute("other", "same-name-other-namespace", BackendReference{Name: "web"}),
+		{
+			ObjectMeta:  types.ObjectMeta{Namespace: "grpc", Name: "grpc-route"},
+			TypeMeta:    types.TypeMeta{Kind: types.ResourceKindGRPCRoute},
+			BackendRefs: []BackendReference{{Group: "core", Namespace: "default", Name: "web"}},
+		},
+	}
+
+	grants := []ReferenceGrant{
+		{
+			From: []ReferenceGrantFrom{{Group: GroupName, Kind: "HTTPRoute", Namespace: "other"}},
+			To:   []ReferenceGrantTo{{Kind: "Service", Name: "web"}},
+		},
+		{
+			From: []ReferenceGrantFrom{{Group: GroupName, Kind: "HTTPRoute", Namespace: "grpc"}},
+			To:   []ReferenceGrantTo{{Kind: "Service"}},
+		},
+	}
+
+	cases := []struct {
+		info     string
+		grants   []ReferenceGrant
+		expected []string
+	}{
+		{
+			"cross namespace references require reference grant",
+			nil,
+			[]string{"same-namespace"},
+		},
+		{
+			"reference grant allows only matching route kinds",
+			grants,
+			[]string{"same-namespace", "cross-namespace"},
+		},
+	}
+
+	for _, c := range cases {
+		actual := make([]string, 0)
+		for _, route := range FilterRoutesByService(routes, c.grants, "default", "web") {
+			actual = append(actual, route.ObjectMeta.Name)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: FilterRoutesByService(...) == \n%#v\nexpected \n%#v", c.info, actual, c.expected)
+		}
+	}
+}
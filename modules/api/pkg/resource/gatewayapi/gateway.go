@@ -0,0 +1,201 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+import (
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// Listener is a logical endpoint of the Gateway together with its status.
+type Listener struct {
+	Name     string `json:"name"`
+	Hostname string `json:"hostname,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+
+	// AttachedRoutes is the number of routes successfully attached to the listener.
+	AttachedRoutes int32 `json:"attachedRoutes"`
+
+	// Conditions of the listener reported by the controller.
+	Conditions []metaV1.Condition `json:"conditions"`
+}
+
+// Gateway is a presentation layer view of the Gateway API Gateway resource.
+type Gateway struct {
+	ObjectMeta types.ObjectMeta `json:"objectMeta"`
+	TypeMeta   types.TypeMeta   `json:"typeMeta"`
+
+	// GatewayClassName is the name of the GatewayClass used by this Gateway.
+	GatewayClassName string `json:"gatewayClassName"`
+
+	// Addresses bound to the Gateway.
+	Addresses []string `json:"addresses"`
+
+	// Listeners of the Gateway.
+	Listeners []Listener `json:"listeners"`
+
+	// Conditions reported by the controller.
+	Conditions []metaV1.Condition `json:"conditions"`
+}
+
+// GatewayList contains a list of Gateways.
+type GatewayList struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+
+	// Unordered list of Gateways.
+	Items []Gateway `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GatewayDetail provides the presentation layer view of the Gateway resource.
+type GatewayDetail struct {
+	// Extends list item structure.
+	Gateway `json:",inline"`
+
+	// Spec is the desired state of the Gateway.
+	Spec map[string]interface{} `json:"spec"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+type gatewayObject struct {
+	metaV1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		GatewayClassName string `json:"gatewayClassName"`
+		Listeners        []struct {
+			Name     string  `json:"name"`
+			Hostname *string `json:"hostname"`
+			Port     int32   `json:"port"`
+			Protocol string  `json:"protocol"`
+		} `json:"listeners"`
+	} `json:"spec"`
+	Status struct {
+		Addresses []struct {
+			Value string `json:"value"`
+		} `json:"addresses"`
+		Conditions []metaV1.Condition `json:"conditions"`
+		Listeners  []struct {
+			Name           string             `json:"name"`
+			AttachedRoutes int32              `json:"attachedRoutes"`
+			Conditions     []metaV1.Condition `json:"conditions"`
+		} `json:"listeners"`
+	} `json:"status"`
+}
+
+// GetGatewayList returns a list of Gateways in the namespace.
+func GetGatewayList(client dynamic.Interface, namespace *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*GatewayList, error) {
+	klog.V(4).Infof("Getting list of gateways in %s namespace", namespace.ToRequestParam())
+
+	list, err := listObjects(client, GatewayResource, namespace.ToRequestParam(), dsQuery.ListOptions())
+	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	objects, filteredTotal := selectObjects(list.Items, dsQuery)
+	result := &GatewayList{
+		ListMeta: types.ListMeta{TotalItems: filteredTotal},
+		Items:    make([]Gateway, 0),
+		Errors:   nonCriticalErrors,
+	}
+	result.ListMeta.SetChunk(chunkMeta(list))
+
+	for i := range objects {
+		gateway, err := toGateway(&objects[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, gateway)
+	}
+
+	return result, nil
+}
+
+// GetGatewayDetail returns detailed information about the Gateway.
+func GetGatewayDetail(client dynamic.Interface, namespace, name string) (*GatewayDetail, error) {
+	klog.V(4).Infof("Getting details of %s gateway in %s namespace", name, namespace)
+
+	object, err := getObject(client, GatewayResource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	gateway, err := toGateway(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GatewayDetail{
+		Gateway: gateway,
+		Spec:    getSpec(object),
+		Errors:  []error{},
+	}, nil
+}
+
+func toGateway(object *unstructured.Unstructured) (Gateway, error) {
+	parsed := new(gatewayObject)
+	if err := fromUnstructured(object, parsed); err != nil {
+		return Gateway{}, err
+	}
+
+	result := Gateway{
+		ObjectMeta:       types.NewObjectMeta(parsed.ObjectMeta),
+		TypeMeta:         types.NewTypeMeta(types.ResourceKindGateway),
+		GatewayClassName: parsed.Spec.GatewayClassName,
+		Addresses:        make([]string, 0),
+		Listeners:        make([]Listener, 0),
+		Conditions:       nonNilConditions(parsed.Status.Conditions),
+	}
+
+	for _, address := range parsed.Status.Addresses {
+		result.Addresses = append(result.Addresses, address.Value)
+	}
+
+	for _, listener := range parsed.Spec.Listeners {
+		item := Listener{
+			Name:       listener.Name,
+			Port:       listener.Port,
+			Protocol:   listener.Protocol,
+			Conditions: []metaV1.Condition{},
+		}
+
+		if listener.Hostname != nil {
+			item.Hostname = *listener.Hostname
+		}
+
+		for _, status := range parsed.Status.Listeners {
+			if status.Name == listener.Name {
+				item.AttachedRoutes = status.AttachedRoutes
+				item.Conditions = nonNilConditions(status.Conditions)
+			}
+		}
+
+		result.Listeners = append(result.Listeners, item)
+	}
+
+	return result, nil
+}
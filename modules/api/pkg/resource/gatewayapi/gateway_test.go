@@ -0,0 +1,198 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gatewayapi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/types"
+)
+
+var listKinds = map[schema.GroupVersionResource]string{
+	GatewayResource:        "GatewayList",
+	HTTPRouteResource:      "HTTPRouteList",
+	GRPCRouteResource:      "GRPCRouteList",
+	ReferenceGrantResource: "ReferenceGrantList",
+}
+
+func newGatewayObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "Gateway",
+		"metadata":   map[string]interface{}{"name": "gateway", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"gatewayClassName": "istio",
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "http", "port": int64(80), "protocol": "HTTP"},
+				map[string]interface{}{"name": "https", "hostname": "example.com", "port": int64(443),
+					"protocol": "HTTPS"},
+			},
+		},
+		"status": map[string]interface{}{
+			"addresses": []interface{}{map[string]interface{}{"value": "10.0.0.1"}},
+			"listeners": []interface{}{
+				map[string]interface{}{
+					"name":           "http",
+					"attachedRoutes": int64(2),
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Accepted", "status": "True", "reason": "Accepted",
+							"message": "", "lastTransitionTime": "2017-01-01T00:00:00Z"},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestGetGatewayList(t *testing.T) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	// Object has to be added with explicit resource, as it would be guessed as "gatewaies" from its kind.
+	if err := client.Tracker().Create(GatewayResource, newGatewayObject(), "default"); err != nil {
+		t.Fatalf("Tracker().Create() returned error: %v", err)
+	}
+
+	actual, err := GetGatewayList(client, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("GetGatewayList() returned error: %v", err)
+	}
+
+	expected := &GatewayList{
+		ListMeta: types.ListMeta{TotalItems: 1},
+		Items: []Gateway{{
+			ObjectMeta:       types.ObjectMeta{Name: "gateway", Namespace: "default"},
+			TypeMeta:         types.TypeMeta{Kind: types.ResourceKindGateway},
+			GatewayClassName: "istio",
+			Addresses:        []string{"10.0.0.1"},
+			Listeners: []Listener{
+				{Name: "http", Port: 80, Protocol: "HTTP", AttachedRoutes: 2, Conditions: []metaV1.Condition{{
+					Type: "Accepted", Status: metaV1.ConditionTrue, Reason: "Accepted",
+					LastTransitionTime: metaV1.Date(2017, 1, 1, 0, 0, 0, 0, time.Local),
+				}}},
+				{Name: "https", Hostname: "example.com", Port: 443, Protocol: "HTTPS",
+					Conditions: []metaV1.Condition{}},
+			},
+			Conditions: []metaV1.Condition{},
+		}},
+		Errors: []error{},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetGatewayList(...) == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
+
+func TestGetRouteListWithoutGatewayAPI(t *testing.T) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	client.PrependReactor("list", "httproutes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8serrors.NewNotFound(HTTPRouteResource.GroupResource(), "")
+	})
+
+	actual, err := GetRouteList(client, types.ResourceKindHTTPRoute, common.NewNamespaceQuery(nil),
+		dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("GetRouteList() returned error: %v", err)
+	}
+
+	expected := &RouteList{ListMeta: types.ListMeta{TotalItems: 0}, Items: []Route{}, Errors: []error{}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetRouteList(...) == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
+
+func TestGetServiceRouteList(t *testing.T) {
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata":   map[string]interface{}{"name": "route", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{map[string]interface{}{"name": "gateway"}},
+			"hostnames":  []interface{}{"example.com"},
+			"rules": []interface{}{
+				map[string]interface{}{"backendRefs": []interface{}{
+					map[string]interface{}{"name": "web", "port": int64(8080)},
+				}},
+				map[string]interface{}{"backendRefs": []interface{}{
+					map[string]interface{}{"name": "web", "port": int64(8080)},
+					map[string]interface{}{"name": "api", "port": int64(8080)},
+				}},
+			},
+		},
+	}}
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, route)
+
+	actual, err := GetServiceRouteList(client, dataselect.NoDataSelect, "default", "web")
+	if err != nil {
+		t.Fatalf("GetServiceRouteList() returned error: %v", err)
+	}
+
+	port := int32(8080)
+	expected := &RouteList{
+		ListMeta: types.ListMeta{TotalItems: 1},
+		Items: []Route{{
+			ObjectMeta:  types.ObjectMeta{Name: "route", Namespace: "default"},
+			TypeMeta:    types.TypeMeta{Kind: types.ResourceKindHTTPRoute},
+			Hostnames:   []string{"example.com"},
+			ParentRefs:  []ParentReference{{Name: "gateway"}},
+			BackendRefs: []BackendReference{{Name: "web", Port: &port}, {Name: "api", Port: &port}},
+			Parents:     []RouteParentStatus{},
+		}},
+		Errors: []error{},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetServiceRouteList(...) == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
+
+func TestListObjectsWithFallback(t *testing.T) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	listed := make([]metaV1.ListOptions, 0)
+	client.PrependReactor("list", "httproutes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		listed = append(listed, action.(clienttesting.ListActionImpl).ListOptions)
+		if action.GetNamespace() == "" {
+			return true, nil, k8serrors.NewForbidden(HTTPRouteResource.GroupResource(), "", nil)
+		}
+
+		return false, nil, nil
+	})
+
+	options := metaV1.ListOptions{LabelSelector: "app=web", Limit: 10, Continue: "cluster-wide-token"}
+	_, nonCriticalErrors, err := listObjectsWithFallback(client, HTTPRouteResource, common.NewNamespaceQuery(nil),
+		"default", options, nil)
+	if err != nil {
+		t.Fatalf("listObjectsWithFallback() returned error: %v", err)
+	}
+
+	if len(nonCriticalErrors) != 1 || !k8serrors.IsForbidden(nonCriticalErrors[0]) {
+		t.Errorf("listObjectsWithFallback() returned non-critical errors %v, expected forbidden error",
+			nonCriticalErrors)
+	}
+
+	expected := metaV1.ListOptions{LabelSelector: "app=web"}
+	if len(listed) != 2 || !reflect.DeepEqual(listed[0], expected) || !reflect.DeepEqual(listed[1], expected) {
+		t.Errorf("listObjectsWithFallback() listed with %#v, expected %#v for both lists", listed, expected)
+	}
+}
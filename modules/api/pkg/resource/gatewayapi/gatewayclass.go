@@ -0,0 +1,153 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+import (
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// GatewayClass is a presentation layer view of the Gateway API GatewayClass resource.
+type GatewayClass struct {
+	ObjectMeta types.ObjectMeta `json:"objectMeta"`
+	TypeMeta   types.TypeMeta   `json:"typeMeta"`
+
+	// ControllerName is the name of the controller that manages Gateways of this class.
+	ControllerName string `json:"controllerName"`
+
+	// Description of the class.
+	Description string `json:"description,omitempty"`
+
+	// Conditions reported by the controller.
+	Conditions []metaV1.Condition `json:"conditions"`
+}
+
+// GatewayClassList contains a list of GatewayClasses in the cluster.
+type GatewayClassList struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+
+	// Unordered list of GatewayClasses.
+	Items []GatewayClass `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GatewayClassDetail provides the presentation layer view of the GatewayClass resource.
+type GatewayClassDetail struct {
+	// Extends list item structure.
+	GatewayClass `json:",inline"`
+
+	// Spec is the desired state of the GatewayClass.
+	Spec map[string]interface{} `json:"spec"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+type gatewayClassObject struct {
+	metaV1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		ControllerName string  `json:"controllerName"`
+		Description    *string `json:"description"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []metaV1.Condition `json:"conditions"`
+	} `json:"status"`
+}
+
+// GetGatewayClassList returns a list of all GatewayClasses in the cluster.
+func GetGatewayClassList(client dynamic.Interface, dsQuery *dataselect.DataSelectQuery) (*GatewayClassList, error) {
+	klog.V(4).Info("Getting list of gateway classes in the cluster")
+
+	list, err := listObjects(client, GatewayClassResource, "", dsQuery.ListOptions())
+	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	objects, filteredTotal := selectObjects(list.Items, dsQuery)
+	result := &GatewayClassList{
+		ListMeta: types.ListMeta{TotalItems: filteredTotal},
+		Items:    make([]GatewayClass, 0),
+		Errors:   nonCriticalErrors,
+	}
+	result.ListMeta.SetChunk(chunkMeta(list))
+
+	for i := range objects {
+		gatewayClass, err := toGatewayClass(&objects[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, gatewayClass)
+	}
+
+	return result, nil
+}
+
+// GetGatewayClassDetail returns detailed information about the GatewayClass.
+func GetGatewayClassDetail(client dynamic.Interface, name string) (*GatewayClassDetail, error) {
+	klog.V(4).Infof("Getting details of %s gateway class", name)
+
+	object, err := getObject(client, GatewayClassResource, "", name)
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayClass, err := toGatewayClass(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GatewayClassDetail{
+		GatewayClass: gatewayClass,
+		Spec:         getSpec(object),
+		Errors:       []error{},
+	}, nil
+}
+
+func toGatewayClass(object *unstructured.Unstructured) (GatewayClass, error) {
+	parsed := new(gatewayClassObject)
+	if err := fromUnstructured(object, parsed); err != nil {
+		return GatewayClass{}, err
+	}
+
+	result := GatewayClass{
+		ObjectMeta:     types.NewObjectMeta(parsed.ObjectMeta),
+		TypeMeta:       types.NewTypeMeta(types.ResourceKindGatewayClass),
+		ControllerName: parsed.Spec.ControllerName,
+		Conditions:     nonNilConditions(parsed.Status.Conditions),
+	}
+
+	if parsed.Spec.Description != nil {
+		result.Description = *parsed.Spec.Description
+	}
+
+	return result, nil
+}
+
+func nonNilConditions(conditions []metaV1.Condition) []metaV1.Condition {
+	if conditions == nil {
+		return []metaV1.Condition{}
+	}
+
+	return conditions
+}
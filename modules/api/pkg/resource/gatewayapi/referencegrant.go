@@ -0,0 +1,153 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+import (
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// ReferenceGrantFrom describes resources that are allowed to reference resources in the ReferenceGrant namespace.
+type ReferenceGrantFrom struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+}
+
+// ReferenceGrantTo describes resources in the ReferenceGrant namespace that may be referenced. Empty name allows
+// references to all resources of the kind.
+type ReferenceGrantTo struct {
+	Group string `json:"group"`
+	Kind  string `json:"kind"`
+	Name  string `json:"name,omitempty"`
+}
+
+// ReferenceGrant is a presentation layer view of the Gateway API ReferenceGrant resource.
+type ReferenceGrant struct {
+	ObjectMeta types.ObjectMeta `json:"objectMeta"`
+	TypeMeta   types.TypeMeta   `json:"typeMeta"`
+
+	From []ReferenceGrantFrom `json:"from"`
+	To   []ReferenceGrantTo   `json:"to"`
+}
+
+// ReferenceGrantList contains a list of ReferenceGrants.
+type ReferenceGrantList struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+
+	// Unordered list of ReferenceGrants.
+	Items []ReferenceGrant `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// ReferenceGrantDetail provides the presentation layer view of the ReferenceGrant resource.
+type ReferenceGrantDetail struct {
+	// Extends list item structure.
+	ReferenceGrant `json:",inline"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+type referenceGrantObject struct {
+	metaV1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		From []ReferenceGrantFrom `json:"from"`
+		To   []ReferenceGrantTo   `json:"to"`
+	} `json:"spec"`
+}
+
+// GetReferenceGrantList returns a list of ReferenceGrants in the namespace.
+func GetReferenceGrantList(client dynamic.Interface, namespace *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*ReferenceGrantList, error) {
+	klog.V(4).Infof("Getting list of reference grants in %s namespace", namespace.ToRequestParam())
+
+	list, err := listObjects(client, ReferenceGrantResource, namespace.ToRequestParam(), dsQuery.ListOptions())
+	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	objects, filteredTotal := selectObjects(list.Items, dsQuery)
+	grants, err := toReferenceGrants(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReferenceGrantList{
+		ListMeta: types.ListMeta{TotalItems: filteredTotal},
+		Items:    grants,
+		Errors:   nonCriticalErrors,
+	}
+	result.ListMeta.SetChunk(chunkMeta(list))
+	return result, nil
+}
+
+// GetReferenceGrantDetail returns detailed information about the ReferenceGrant.
+func GetReferenceGrantDetail(client dynamic.Interface, namespace, name string) (*ReferenceGrantDetail, error) {
+	klog.V(4).Infof("Getting details of %s reference grant in %s namespace", name, namespace)
+
+	object, err := getObject(client, ReferenceGrantResource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	grant, err := toReferenceGrant(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReferenceGrantDetail{ReferenceGrant: grant, Errors: []error{}}, nil
+}
+
+func toReferenceGrants(objects []unstructured.Unstructured) ([]ReferenceGrant, error) {
+	result := make([]ReferenceGrant, 0, len(objects))
+	for i := range objects {
+		grant, err := toReferenceGrant(&objects[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, grant)
+	}
+
+	return result, nil
+}
+
+func toReferenceGrant(object *unstructured.Unstructured) (ReferenceGrant, error) {
+	parsed := new(referenceGrantObject)
+	if err := fromUnstructured(object, parsed); err != nil {
+		return ReferenceGrant{}, err
+	}
+
+	result := ReferenceGrant{
+		ObjectMeta: types.NewObjectMeta(parsed.ObjectMeta),
+		TypeMeta:   types.NewTypeMeta(types.ResourceKindReferenceGrant),
+		From:       make([]ReferenceGrantFrom, 0),
+		To:         make([]ReferenceGrantTo, 0),
+	}
+
+	result.From = append(result.From, parsed.Spec.From...)
+	result.To = append(result.To, parsed.Spec.To...)
+	return result, nil
+}
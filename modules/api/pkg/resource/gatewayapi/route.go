@@ -0,0 +1,272 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// routeKind describes a route resource. HTTPRoutes and GRPCRoutes share the parts of the spec shown by the dashboard.
+type routeKind struct {
+	resource schema.GroupVersionResource
+	// name is the Kubernetes kind of the route, used by ReferenceGrants.
+	name string
+}
+
+var routeKinds = map[types.ResourceKind]routeKind{
+	types.ResourceKindHTTPRoute: {resource: HTTPRouteResource, name: "HTTPRoute"},
+	types.ResourceKindGRPCRoute: {resource: GRPCRouteResource, name: "GRPCRoute"},
+}
+
+// BackendReference identifies a backend of the route, usually a Service.
+type BackendReference struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Port      *int32 `json:"port,omitempty"`
+}
+
+// RouteParentStatus is the status of the route with respect to one of its parents.
+type RouteParentStatus struct {
+	ParentRef      ParentReference    `json:"parentRef"`
+	ControllerName string             `json:"controllerName"`
+	Conditions     []metaV1.Condition `json:"conditions"`
+}
+
+// Route is a presentation layer view of the Gateway API HTTPRoute or GRPCRoute resource.
+type Route struct {
+	ObjectMeta types.ObjectMeta `json:"objectMeta"`
+	TypeMeta   types.TypeMeta   `json:"typeMeta"`
+
+	// Hostnames matched by the route.
+	Hostnames []string `json:"hostnames"`
+
+	// ParentRefs are Gateways that the route wants to be attached to.
+	ParentRefs []ParentReference `json:"parentRefs"`
+
+	// BackendRefs are unique backends of all route rules.
+	BackendRefs []BackendReference `json:"backendRefs"`
+
+	// Parents contain the status of the route for every parent.
+	Parents []RouteParentStatus `json:"parents"`
+}
+
+// RouteList contains a list of routes.
+type RouteList struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+
+	// Unordered list of routes.
+	Items []Route `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// RouteDetail provides the presentation layer view of the route resource.
+type RouteDetail struct {
+	// Extends list item structure.
+	Route `json:",inline"`
+
+	// Spec is the desired state of the route, including matches and filters of the rules.
+	Spec map[string]interface{} `json:"spec"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+type routeObject struct {
+	metaV1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		ParentRefs []ParentReference `json:"parentRefs"`
+		Hostnames  []string          `json:"hostnames"`
+		Rules      []struct {
+			BackendRefs []BackendReference `json:"backendRefs"`
+		} `json:"rules"`
+	} `json:"spec"`
+	Status struct {
+		Parents []RouteParentStatus `json:"parents"`
+	} `json:"status"`
+}
+
+func getRouteKind(kind types.ResourceKind) (routeKind, error) {
+	result, exists := routeKinds[kind]
+	if !exists {
+		return routeKind{}, errors.NewBadRequest(fmt.Sprintf("unsupported route kind %s", kind))
+	}
+
+	return result, nil
+}
+
+// GetRouteList returns a list of routes of the given kind in the namespace.
+func GetRouteList(client dynamic.Interface, kind types.ResourceKind, namespace *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*RouteList, error) {
+	klog.V(4).Infof("Getting list of %s in %s namespace", kind, namespace.ToRequestParam())
+
+	route, err := getRouteKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := listObjects(client, route.resource, namespace.ToRequestParam(), dsQuery.ListOptions())
+	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	routes, err := toRoutes(list.Items, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toRouteList(routes, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(chunkMeta(list))
+	return result, nil
+}
+
+// GetRouteDetail returns detailed information about the route of the given kind.
+func GetRouteDetail(client dynamic.Interface, kind types.ResourceKind, namespace, name string) (*RouteDetail, error) {
+	klog.V(4).Infof("Getting details of %s %s in %s namespace", name, kind, namespace)
+
+	route, err := getRouteKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := getObject(client, route.resource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := toRoute(object, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RouteDetail{
+		Route:  result,
+		Spec:   getSpec(object),
+		Errors: []error{},
+	}, nil
+}
+
+func toRoutes(objects []unstructured.Unstructured, kind types.ResourceKind) ([]Route, error) {
+	result := make([]Route, 0, len(objects))
+	for i := range objects {
+		route, err := toRoute(&objects[i], kind)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, route)
+	}
+
+	return result, nil
+}
+
+func toRoute(object *unstructured.Unstructured, kind types.ResourceKind) (Route, error) {
+	parsed := new(routeObject)
+	if err := fromUnstructured(object, parsed); err != nil {
+		return Route{}, err
+	}
+
+	result := Route{
+		ObjectMeta:  types.NewObjectMeta(parsed.ObjectMeta),
+		TypeMeta:    types.NewTypeMeta(kind),
+		Hostnames:   make([]string, 0),
+		ParentRefs:  make([]ParentReference, 0),
+		BackendRefs: make([]BackendReference, 0),
+		Parents:     make([]RouteParentStatus, 0),
+	}
+
+	result.Hostnames = append(result.Hostnames, parsed.Spec.Hostnames...)
+	result.ParentRefs = append(result.ParentRefs, parsed.Spec.ParentRefs...)
+
+	for _, parent := range parsed.Status.Parents {
+		parent.Conditions = nonNilConditions(parent.Conditions)
+		result.Parents = append(result.Parents, parent)
+	}
+
+	seen := make(map[string]struct{})
+	for _, rule := range parsed.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			key := fmt.Sprintf("%s/%s/%s/%s/%v", backendRef.Group, backendRef.Kind, backendRef.Namespace,
+				backendRef.Name, portValue(backendRef.Port))
+			if _, exists := seen[key]; exists {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			result.BackendRefs = append(result.BackendRefs, backendRef)
+		}
+	}
+
+	return result, nil
+}
+
+func portValue(port *int32) int32 {
+	if port == nil {
+		return 0
+	}
+
+	return *port
+}
+
+// RouteCell is a wrapper around the Route that implements the DataCell interface.
+type RouteCell Route
+
+func (self RouteCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toRouteList(routes []Route, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *RouteList {
+	cells := make([]dataselect.DataCell, len(routes))
+	for i := range routes {
+		cells[i] = RouteCell(routes[i])
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(cells, dsQuery)
+	result := &RouteList{
+		ListMeta: types.ListMeta{TotalItems: filteredTotal},
+		Items:    make([]Route, 0, len(cells)),
+		Errors:   nonCriticalErrors,
+	}
+
+	for _, cell := range cells {
+		result.Items = append(result.Items, Route(cell.(RouteCell)))
+	}
+
+	return result
+}
@@ -48,7 +48,7 @@ type HorizontalPodAutoscaler struct {
 }
 
 func GetHorizontalPodAutoscalerList(client k8sClient.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*HorizontalPodAutoscalerList, error) {
-	channel := common.GetHorizontalPodAutoscalerListChannel(client, nsQuery, 1)
+	channel := common.GetHorizontalPodAutoscalerListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1)
 	hpaList := <-channel.List
 	err := <-channel.Error
 
@@ -57,7 +57,9 @@ func GetHorizontalPodAutoscalerList(client k8sClient.Interface, nsQuery *common.
 		return nil, criticalError
 	}
 
-	return toHorizontalPodAutoscalerList(hpaList.Items, nonCriticalErrors, dsQuery), nil
+	result := toHorizontalPodAutoscalerList(hpaList.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(hpaList.ListMeta)
+	return result, nil
 }
 
 func GetHorizontalPodAutoscalerListForResource(client k8sClient.Interface, namespace, kind, name string) (*HorizontalPodAutoscalerList, error) {
@@ -23,7 +23,6 @@ import (
 	"k8s.io/dashboard/api/pkg/resource/common"
 	"k8s.io/dashboard/api/pkg/resource/dataselect"
 	"k8s.io/dashboard/errors"
-	"k8s.io/dashboard/helpers"
 	"k8s.io/dashboard/types"
 )
 
@@ -51,14 +50,16 @@ type IngressList struct {
 // GetIngressList returns all ingresses in the given namespace.
 func GetIngressList(client client.Interface, namespace *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*IngressList, error) {
-	ingressList, err := client.NetworkingV1().Ingresses(namespace.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+	ingressList, err := client.NetworkingV1().Ingresses(namespace.ToRequestParam()).List(context.TODO(), dsQuery.ListOptions())
 
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return ToIngressList(ingressList.Items, nonCriticalErrors, dsQuery), nil
+	result := ToIngressList(ingressList.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(ingressList.ListMeta)
+	return result, nil
 }
 
 func getEndpoints(ingress *v1.Ingress) []common.Endpoint {
@@ -47,7 +47,7 @@ func GetIngressClassList(client kubernetes.Interface, dsQuery *dataselect.DataSe
 	klog.V(4).Infof("Getting list of ingress classes in the cluster")
 
 	channels := &common.ResourceChannels{
-		IngressClassList: common.GetIngressClassListChannel(client, 1),
+		IngressClassList: common.GetIngressClassListChannelWithOptions(client, dsQuery.ListOptions(), 1),
 	}
 
 	return GetIngressClassListFromChannels(channels, dsQuery)
@@ -63,7 +63,9 @@ func GetIngressClassListFromChannels(channels *common.ResourceChannels,
 		return nil, criticalError
 	}
 
-	return toIngressClassList(ingressClasses.Items, nonCriticalErrors, dsQuery), nil
+	result := toIngressClassList(ingressClasses.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(ingressClasses.ListMeta)
+	return result, nil
 }
 
 func toIngressClassList(ingressClasses []networkingv1.IngressClass, nonCriticalErrors []error,
@@ -91,7 +91,7 @@ func GetJobList(client client.Interface, nsQuery *common.NamespaceQuery,
 	klog.V(4).Infof("Getting list of all jobs in the cluster")
 
 	channels := &common.ResourceChannels{
-		JobList:   common.GetJobListChannel(client, nsQuery, 1),
+		JobList:   common.GetJobListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 		PodList:   common.GetPodListChannel(client, nsQuery, 1),
 		EventList: common.GetEventListChannel(client, nsQuery, 1),
 	}
@@ -125,6 +125,7 @@ func GetJobListFromChannels(channels *common.ResourceChannels, dsQuery *datasele
 	}
 
 	jobList := ToJobList(jobs.Items, pods.Items, events.Items, nonCriticalErrors, dsQuery, metricClient)
+	jobList.ListMeta.SetChunk(jobs.ListMeta)
 	jobList.Status = getStatus(jobs, pods.Items)
 	return jobList, nil
 }
@@ -144,6 +144,10 @@ type LogLines []LogLine
 type LogLine struct {
 	Timestamp LogTimestamp `json:"timestamp"`
 	Content   string       `json:"content"`
+	// Format of the structured content. Empty for plain text lines.
+	Format LogFormat `json:"format,omitempty"`
+	// Fields parsed from the structured content. Set only when structured content is requested, see WithFields.
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 // LogTimestamp is a timestamp that appears on the beginning of each log line.
@@ -152,8 +156,14 @@ type LogTimestamp string
 // SelectLogs returns selected part of LogLines as required by logSelector, moreover it returns IDs of first and last
 // of returned lines and the information of the resulting logView.
 func (self LogLines) SelectLogs(logSelection *Selection) (LogLines, LogTimestamp, LogTimestamp, Selection, bool) {
+	return self.selectLogs(logSelection, self.getLineIndex(&logSelection.ReferencePoint), self.createLogLineId)
+}
+
+// selectLogs selects lines relatively to the line with the given index. ID of the reference point of the resulting
+// selection is created by createLineId from the index of the line.
+func (self LogLines) selectLogs(logSelection *Selection, referenceLineIndex int,
+	createLineId func(lineIndex int) *LogLineId) (LogLines, LogTimestamp, LogTimestamp, Selection, bool) {
 	requestedNumItems := logSelection.OffsetTo - logSelection.OffsetFrom
-	referenceLineIndex := self.getLineIndex(&logSelection.ReferencePoint)
 	if referenceLineIndex == LineIndexNotFound || requestedNumItems <= 0 || len(self) == 0 {
 		// Requested reference line could not be found, probably it's already gone or requested no logs. Return no logs.
 		return LogLines{}, "", "", Selection{}, false
@@ -177,7 +187,7 @@ func (self LogLines) SelectLogs(logSelection *Selection) (LogLines, LogTimestamp
 
 	// set the middle of log array as a reference point, this part of array should not be affected by log deletion/addition.
 	newSelection := Selection{
-		ReferencePoint:  *self.createLogLineId(len(self) / 2),
+		ReferencePoint:  *createLineId(len(self) / 2),
 		OffsetFrom:      fromIndex - len(self)/2,
 		OffsetTo:        toIndex - len(self)/2,
 		LogFilePosition: logSelection.LogFilePosition,
@@ -0,0 +1,164 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"k8s.io/dashboard/errors"
+)
+
+// MaxSearchMatches is the maximum number of matches returned by a single search.
+var MaxSearchMatches = 1000
+
+// MaxSearchContextLines is the maximum number of context lines returned before and after each match.
+var MaxSearchContextLines = 50
+
+// SearchQuery describes which log lines should be matched by Search.
+type SearchQuery struct {
+	// Query is a substring or a regular expression that the line content has to match.
+	Query string
+	// Regex indicates that Query is a regular expression.
+	Regex bool
+	// CaseSensitive indicates that Query should be matched case sensitively.
+	CaseSensitive bool
+	// SinceTime, if set, skips lines older than the given time.
+	SinceTime *time.Time
+	// UntilTime, if set, skips lines newer than the given time.
+	UntilTime *time.Time
+	// ContextLines is the number of lines returned before and after each match.
+	ContextLines int
+
+	matcher *regexp.Regexp
+}
+
+// LogMatch is a single line matched by the search together with its surrounding lines.
+type LogMatch struct {
+	// Id of the matched line. It can be used as a reference point of the Selection to jump to the line.
+	Id LogLineId `json:"id"`
+	// Matched line.
+	Line LogLine `json:"line"`
+	// Lines before the matched one.
+	Before LogLines `json:"before"`
+	// Lines after the matched one.
+	After LogLines `json:"after"`
+}
+
+// LogSearchResult contains lines matched by the search.
+type LogSearchResult struct {
+	// Additional information of the logs e.g. container name.
+	Info LogInfo `json:"info"`
+	// Matched lines, at most MaxSearchMatches of them.
+	Matches []LogMatch `json:"matches"`
+	// Total number of matched lines.
+	TotalMatches int `json:"totalMatches"`
+}
+
+// NewSearchQuery creates search query and validates it. Times have to be provided in the RFC3339 format
+// and are optional.
+func NewSearchQuery(query string, regex, caseSensitive bool, sinceTime, untilTime string, contextLines int) (
+	*SearchQuery, error) {
+	result := &SearchQuery{
+		Query:         query,
+		Regex:         regex,
+		CaseSensitive: caseSensitive,
+		ContextLines:  min(max(contextLines, 0), MaxSearchContextLines),
+	}
+
+	pattern := query
+	if !regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid search query: %s", err.Error()))
+	}
+	result.matcher = matcher
+
+	if result.SinceTime, err = parseSearchTime("sinceTime", sinceTime); err != nil {
+		return nil, err
+	}
+
+	if result.UntilTime, err = parseSearchTime("untilTime", untilTime); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func parseSearchTime(name, value string) (*time.Time, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid %s: %s", name, err.Error()))
+	}
+
+	return &parsed, nil
+}
+
+// Search returns lines matching the query together with their ids and context lines,
+// and the total number of matched lines.
+func (self LogLines) Search(query *SearchQuery) ([]LogMatch, int) {
+	matches := make([]LogMatch, 0)
+	total := 0
+
+	for i, line := range self {
+		if !query.matchesTime(line.Timestamp) || !query.matcher.MatchString(line.Content) {
+			continue
+		}
+
+		total++
+		if len(matches) >= MaxSearchMatches {
+			continue
+		}
+
+		matches = append(matches, LogMatch{
+			Id:     *self.createLogLineId(i),
+			Line:   line,
+			Before: self[max(i-query.ContextLines, 0):i],
+			After:  self[i+1 : min(i+1+query.ContextLines, len(self))],
+		})
+	}
+
+	return matches, total
+}
+
+// matchesTime checks if the line timestamp is within the query time range. Lines without a valid timestamp
+// are matched only when no time range is set.
+func (self *SearchQuery) matchesTime(timestamp LogTimestamp) bool {
+	if self.SinceTime == nil && self.UntilTime == nil {
+		return true
+	}
+
+	lineTime, err := time.Parse(time.RFC3339Nano, string(timestamp))
+	if err != nil {
+		return false
+	}
+
+	if self.SinceTime != nil && lineTime.Before(*self.SinceTime) {
+		return false
+	}
+
+	return self.UntilTime == nil || !lineTime.After(*self.UntilTime)
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/dashboard/errors"
+)
+
+var searchLines = LogLines{
+	{Timestamp: "2017-01-01T00:00:01.000000000Z", Content: "starting server"},
+	{Timestamp: "2017-01-01T00:00:02.000000000Z", Content: "ERROR could not connect"},
+	{Timestamp: "2017-01-01T00:00:03.000000000Z", Content: "retrying"},
+	{Timestamp: "2017-01-01T00:00:04.000000000Z", Content: "error: timeout"},
+	{Timestamp: "2017-01-01T00:00:05.000000000Z", Content: "stopping server"},
+}
+
+func TestSearch(t *testing.T) {
+	cases := []struct {
+		info          string
+		query         string
+		regex         bool
+		caseSensitive bool
+		sinceTime     string
+		untilTime     string
+		contextLines  int
+		expected      []LogMatch
+	}{
+		{
+			"case insensitive substring",
+			"error", false, false, "", "", 0,
+			[]LogMatch{
+				{Id: LogLineId{LogTimestamp: "2017-01-01T00:00:02.000000000Z", LineNum: -1}, Line: searchLines[1],
+					Before: LogLines{}, After: LogLines{}},
+				{Id: LogLineId{LogTimestamp: "2017-01-01T00:00:04.000000000Z", LineNum: -1}, Line: searchLines[3],
+					Before: LogLines{}, After: LogLines{}},
+			},
+		},
+		{
+			"case sensitive substring with context",
+			"ERROR", false, true, "", "", 1,
+			[]LogMatch{
+				{Id: LogLineId{LogTimestamp: "2017-01-01T00:00:02.000000000Z", LineNum: -1}, Line: searchLines[1],
+					Before: searchLines[0:1], After: searchLines[2:3]},
+			},
+		},
+		{
+			"regex within time range",
+			"^(starting|stopping) server$", true, false, "2017-01-01T00:00:02Z", "2017-01-01T00:00:05Z", 0,
+			[]LogMatch{
+				{Id: LogLineId{LogTimestamp: "2017-01-01T00:00:05.000000000Z", LineNum: 1}, Line: searchLines[4],
+					Before: LogLines{}, After: LogLines{}},
+			},
+		},
+		{
+			"special characters are not interpreted in substring search",
+			"error:", false, false, "", "", 0,
+			[]LogMatch{
+				{Id: LogLineId{LogTimestamp: "2017-01-01T00:00:04.000000000Z", LineNum: -1}, Line: searchLines[3],
+					Before: LogLines{}, After: LogLines{}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		query, err := NewSearchQuery(c.query, c.regex, c.caseSensitive, c.sinceTime, c.untilTime, c.contextLines)
+		if err != nil {
+			t.Fatalf("%s: NewSearchQuery() returned error: %v", c.info, err)
+		}
+
+		actual, total := searchLines.Search(query)
+		if total != len(c.expected) {
+			t.Errorf("%s: Search() returned %d total matches, expected %d", c.info, total, len(c.expected))
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: Search() == \n%#v\nexpected \n%#v", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestNewSearchQueryInvalid(t *testing.T) {
+	cases := []struct {
+		query     string
+		regex     bool
+		sinceTime string
+	}{
+		{"(", true, ""},
+		{"a", false, "yesterday"},
+	}
+
+	for _, c := range cases {
+		if _, err := NewSearchQuery(c.query, c.regex, false, c.sinceTime, "", 0); !errors.IsBadRequest(err) {
+			t.Errorf("NewSearchQuery(%s, %t, %s) should return bad request error, got %v", c.query, c.regex,
+				c.sinceTime, err)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/dashboard/errors"
+)
+
+// LogFormat is a format of the structured log line content.
+type LogFormat string
+
+const (
+	LogFormatJSON   LogFormat = "json"
+	LogFormatLogfmt LogFormat = "logfmt"
+)
+
+// Normalized names of well known fields. Values of their aliases are copied to them when parsing,
+// so filters can use a single name no matter which library produced the line.
+const (
+	FieldLevel   = "level"
+	FieldMessage = "msg"
+	FieldTraceID = "trace_id"
+)
+
+var fieldAliases = map[string][]string{
+	FieldLevel:   {"lvl", "severity", "loglevel"},
+	FieldMessage: {"message"},
+	FieldTraceID: {"traceId", "traceID", "trace.id"},
+}
+
+// levelRanks orders log levels, so they can be compared, i.e. `level>=warn`.
+var levelRanks = map[string]int{
+	"trace":    0,
+	"debug":    1,
+	"info":     2,
+	"notice":   2,
+	"warn":     3,
+	"warning":  3,
+	"error":    4,
+	"err":      4,
+	"critical": 5,
+	"fatal":    5,
+	"panic":    5,
+}
+
+// ParseStructured detects whether the content is a JSON object or a logfmt line and returns its fields.
+// Nested JSON objects are flattened using dots, i.e. `http.status`. For plain text an empty format and
+// nil fields are returned.
+func ParseStructured(content string) (LogFormat, map[string]string) {
+	trimmed := strings.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return "", nil
+	}
+
+	if trimmed[0] == '{' {
+		if fields, ok := parseJSON(trimmed); ok {
+			return LogFormatJSON, normalizeFields(fields)
+		}
+		return "", nil
+	}
+
+	if fields, ok := parseLogfmt(trimmed); ok {
+		return LogFormatLogfmt, normalizeFields(fields)
+	}
+
+	return "", nil
+}
+
+func parseJSON(content string) (map[string]string, bool) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil || decoder.More() {
+		return nil, false
+	}
+
+	fields := make(map[string]string, len(object))
+	flattenJSON("", object, fields)
+	return fields, true
+}
+
+func flattenJSON(prefix string, object map[string]interface{}, fields map[string]string) {
+	for key, value := range object {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			flattenJSON(prefix+key+".", typed, fields)
+		case string:
+			fields[prefix+key] = typed
+		case nil:
+			fields[prefix+key] = ""
+		case json.Number, bool:
+			fields[prefix+key] = fmt.Sprint(typed)
+		default:
+			raw, _ := json.Marshal(typed)
+			fields[prefix+key] = string(raw)
+		}
+	}
+}
+
+// parseLogfmt parses `key=value key2="quoted value"` lines. To avoid treating plain text containing
+// a `=` sign as structured, every token of the line has to be a key-value pair.
+func parseLogfmt(content string) (map[string]string, bool) {
+	fields := make(map[string]string)
+	for i := 0; i < len(content); {
+		if content[i] == ' ' || content[i] == '\t' {
+			i++
+			continue
+		}
+
+		keyEnd := i
+		for keyEnd < len(content) && content[keyEnd] != '=' && content[keyEnd] != ' ' && content[keyEnd] != '"' {
+			keyEnd++
+		}
+		if keyEnd == i || keyEnd >= len(content) || content[keyEnd] != '=' {
+			return nil, false
+		}
+		key := content[i:keyEnd]
+
+		valueStart := keyEnd + 1
+		valueEnd := valueStart
+		value := ""
+		if valueStart < len(content) && content[valueStart] == '"' {
+			valueEnd = valueStart + 1
+			for valueEnd < len(content) && content[valueEnd] != '"' {
+				if content[valueEnd] == '\\' {
+					valueEnd++
+				}
+				valueEnd++
+			}
+			if valueEnd >= len(content) {
+				return nil, false
+			}
+			valueEnd++
+
+			unquoted, err := strconv.Unquote(content[valueStart:valueEnd])
+			if err != nil {
+				return nil, false
+			}
+			value = unquoted
+		} else {
+			for valueEnd < len(content) && content[valueEnd] != ' ' && content[valueEnd] != '\t' {
+				valueEnd++
+			}
+			value = content[valueStart:valueEnd]
+		}
+
+		fields[key] = value
+		i = valueEnd
+	}
+
+	return fields, len(fields) > 0
+}
+
+func normalizeFields(fields map[string]string) map[string]string {
+	for name, aliases := range fieldAliases {
+		if _, exists := fields[name]; exists {
+			continue
+		}
+
+		for _, alias := range aliases {
+			if value, exists := fields[alias]; exists {
+				fields[name] = value
+				break
+			}
+		}
+	}
+
+	return fields
+}
+
+// FieldCondition is a single condition of the FieldFilter, i.e. `level>=warn`.
+type FieldCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// FieldFilter selects structured log lines that match all of its conditions.
+type FieldFilter struct {
+	Conditions []FieldCondition `json:"conditions"`
+}
+
+// fieldOperators are supported condition operators. Two characters long operators are listed first,
+// so they are not mistaken for the one character long ones.
+var fieldOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseFieldFilter parses comma separated list of conditions, i.e. `level>=warn,trace_id=abc`.
+// Empty string results in a nil filter that matches every line.
+func ParseFieldFilter(raw string) (*FieldFilter, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	filter := &FieldFilter{Conditions: make([]FieldCondition, 0)}
+	for _, expression := range strings.Split(raw, ",") {
+		condition, err := parseFieldCondition(strings.TrimSpace(expression))
+		if err != nil {
+			return nil, err
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	}
+
+	return filter, nil
+}
+
+func parseFieldCondition(expression string) (FieldCondition, error) {
+	index := strings.IndexAny(expression, "<>!=")
+	if index <= 0 {
+		return FieldCondition{}, errors.NewBadRequest(fmt.Sprintf("invalid field filter condition %q", expression))
+	}
+
+	for _, operator := range fieldOperators {
+		if strings.HasPrefix(expression[index:], operator) {
+			return FieldCondition{
+				Field:    strings.TrimSpace(expression[:index]),
+				Operator: operator,
+				Value:    strings.TrimSpace(expression[index+len(operator):]),
+			}, nil
+		}
+	}
+
+	return FieldCondition{}, errors.NewBadRequest(fmt.Sprintf("invalid field filter condition %q", expression))
+}
+
+// Matches returns true if the line matches all filter conditions. Plain text lines have no fields,
+// so they match only `!=` conditions.
+func (self *FieldFilter) Matches(line LogLine) bool {
+	if self == nil {
+		return true
+	}
+
+	for _, condition := range self.Conditions {
+		if !condition.matches(line.Fields) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (self FieldCondition) matches(fields map[string]string) bool {
+	value, exists := fields[self.Field]
+	if !exists {
+		return self.Operator == "!="
+	}
+
+	result := compareFieldValues(self.Field, value, self.Value)
+	switch self.Operator {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case ">=":
+		return result >= 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case "<":
+		return result < 0
+	}
+
+	return false
+}
+
+// compareFieldValues compares levels by severity, numbers numerically and everything else as strings.
+func compareFieldValues(field, value, other string) int {
+	if field == FieldLevel {
+		valueRank, valueKnown := levelRanks[strings.ToLower(value)]
+		otherRank, otherKnown := levelRanks[strings.ToLower(other)]
+		if valueKnown && otherKnown {
+			return valueRank - otherRank
+		}
+	}
+
+	valueNumber, valueErr := strconv.ParseFloat(value, 64)
+	otherNumber, otherErr := strconv.ParseFloat(other, 64)
+	if valueErr == nil && otherErr == nil {
+		switch {
+		case valueNumber < otherNumber:
+			return -1
+		case valueNumber > otherNumber:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(value, other)
+}
+
+// WithFields returns copy of lines with format and fields of the structured content. Parsing is not done by
+// ToLogLines, as only field filters and the structured view need it.
+func (self LogLines) WithFields() LogLines {
+	result := make(LogLines, len(self))
+	for i, line := range self {
+		line.Format, line.Fields = ParseStructured(line.Content)
+		result[i] = line
+	}
+
+	return result
+}
+
+// SelectFilteredLogs selects the lines matching the filter the same way as SelectLogs. Reference points are IDs of
+// the lines in all logs, so they can be used by both filtered and unfiltered views, while offsets count only matching
+// lines. Reference line that does not match the filter is replaced by the next matching one. Lines have to be parsed
+// with WithFields first.
+func (self LogLines) SelectFilteredLogs(filter *FieldFilter, logSelection *Selection) (LogLines, LogTimestamp,
+	LogTimestamp, Selection, bool) {
+	if filter == nil {
+		return self.SelectLogs(logSelection)
+	}
+
+	matching := LogLines{}
+	// indices of matching lines in all logs
+	indices := make([]int, 0)
+	for i, line := range self {
+		if filter.Matches(line) {
+			matching = append(matching, line)
+			indices = append(indices, i)
+		}
+	}
+
+	referencePoint := logSelection.ReferencePoint
+	referenceLineIndex := LineIndexNotFound
+	switch referencePoint.LogTimestamp {
+	case NewestTimestamp, OldestTimestamp, "":
+		referenceLineIndex = matching.getLineIndex(&referencePoint)
+	default:
+		if lineIndex := self.getLineIndex(&referencePoint); lineIndex != LineIndexNotFound {
+			referenceLineIndex = sort.SearchInts(indices, lineIndex)
+		}
+	}
+
+	return matching.selectLogs(logSelection, referenceLineIndex, func(lineIndex int) *LogLineId {
+		return self.createLogLineId(indices[lineIndex])
+	})
+}
+
+// Filter returns lines matching the filter. Nil filter matches every line. Lines have to be parsed with
+// WithFields first.
+func (self LogLines) Filter(filter *FieldFilter) LogLines {
+	if filter == nil {
+		return self
+	}
+
+	result := LogLines{}
+	for _, line := range self {
+		if filter.Matches(line) {
+			result = append(result, line)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,186 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/dashboard/errors"
+)
+
+func TestParseStructured(t *testing.T) {
+	cases := []struct {
+		content        string
+		expectedFormat LogFormat
+		expectedFields map[string]string
+	}{
+		{
+			`{"severity":"WARN","message":"slow request","http":{"status":503},"ok":false}`,
+			LogFormatJSON,
+			map[string]string{"severity": "WARN", "level": "WARN", "message": "slow request", "msg": "slow request",
+				"http.status": "503", "ok": "false"},
+		},
+		{
+			`level=info msg="request done" traceId=abc duration=12ms`,
+			LogFormatLogfmt,
+			map[string]string{"level": "info", "msg": "request done", "traceId": "abc", "trace_id": "abc",
+				"duration": "12ms"},
+		},
+		{"connecting to host=localhost", "", nil},
+		{`{"unterminated": `, "", nil},
+		{"plain text", "", nil},
+	}
+
+	for _, c := range cases {
+		format, fields := ParseStructured(c.content)
+		if format != c.expectedFormat || !reflect.DeepEqual(fields, c.expectedFields) {
+			t.Errorf("ParseStructured(%s) == %s, %#v, expected %s, %#v", c.content, format, fields,
+				c.expectedFormat, c.expectedFields)
+		}
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	lines := ToLogLines(`1 level=warn msg=b
+2 plain text`)
+	for _, line := range lines {
+		if line.Format != "" || line.Fields != nil {
+			t.Errorf("ToLogLines should not parse structured content, got %#v", line)
+		}
+	}
+
+	expected := LogLines{
+		{Timestamp: "1", Content: "level=warn msg=b", Format: LogFormatLogfmt,
+			Fields: map[string]string{"level": "warn", "msg": "b"}},
+		{Timestamp: "2", Content: "plain text"},
+	}
+	if actual := lines.WithFields(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("WithFields() == %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	lines := ToLogLines(`1 {"level":"debug","msg":"a","trace_id":"abc"}
+2 level=warn msg=b trace_id=abc
+3 level=error msg=c trace_id=def status=500
+4 plain text`).WithFields()
+
+	cases := []struct {
+		filter   string
+		expected []string
+	}{
+		{"level>=warn", []string{"2", "3"}},
+		{"level>=warn,trace_id=abc", []string{"2"}},
+		{"trace_id!=abc", []string{"3", "4"}},
+		{"status>=404", []string{"3"}},
+		{"", []string{"1", "2", "3", "4"}},
+	}
+
+	for _, c := range cases {
+		filter, err := ParseFieldFilter(c.filter)
+		if err != nil {
+			t.Fatalf("ParseFieldFilter(%s) returned error: %v", c.filter, err)
+		}
+
+		actual := make([]string, 0)
+		for _, line := range lines.Filter(filter) {
+			actual = append(actual, string(line.Timestamp))
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Filter(%s) == %v, expected %v", c.filter, actual, c.expected)
+		}
+	}
+}
+
+func TestParseFieldFilterInvalid(t *testing.T) {
+	for _, raw := range []string{"level", "=warn", "level!warn"} {
+		if _, err := ParseFieldFilter(raw); !errors.IsBadRequest(err) {
+			t.Errorf("ParseFieldFilter(%s) should return bad request error, got %v", raw, err)
+		}
+	}
+}
+
+func TestSelectFilteredLogs(t *testing.T) {
+	lines := ToLogLines(`1 level=info msg=a
+2 level=warn msg=b
+3 level=info msg=c
+4 level=error msg=d
+5 level=info msg=e`).WithFields()
+	filter, err := ParseFieldFilter("level>=warn")
+	if err != nil {
+		t.Fatalf("ParseFieldFilter() returned error: %v", err)
+	}
+
+	cases := []struct {
+		info              string
+		selection         *Selection
+		expected          []LogTimestamp
+		expectedSelection Selection
+	}{
+		{
+			"newest matching line",
+			&Selection{ReferencePoint: NewestLogLineId, OffsetFrom: 0, OffsetTo: 1},
+			[]LogTimestamp{"4"},
+			Selection{ReferencePoint: LogLineId{LogTimestamp: "4", LineNum: -1}, OffsetFrom: 0, OffsetTo: 1},
+		},
+		{
+			"previous page using reference of the filtered view",
+			&Selection{ReferencePoint: LogLineId{LogTimestamp: "4", LineNum: -1}, OffsetFrom: -1, OffsetTo: 0},
+			[]LogTimestamp{"2"},
+			Selection{ReferencePoint: LogLineId{LogTimestamp: "4", LineNum: -1}, OffsetFrom: -1, OffsetTo: 0},
+		},
+		{
+			"reference of the unfiltered view that does not match",
+			&Selection{ReferencePoint: LogLineId{LogTimestamp: "3", LineNum: -1}, OffsetFrom: 0, OffsetTo: 1},
+			[]LogTimestamp{"4"},
+			Selection{ReferencePoint: LogLineId{LogTimestamp: "4", LineNum: -1}, OffsetFrom: 0, OffsetTo: 1},
+		},
+		{
+			"reference of the unfiltered view that matches",
+			&Selection{ReferencePoint: LogLineId{LogTimestamp: "2", LineNum: -1}, OffsetFrom: 0, OffsetTo: 2},
+			[]LogTimestamp{"2", "4"},
+			Selection{ReferencePoint: LogLineId{LogTimestamp: "4", LineNum: -1}, OffsetFrom: -1, OffsetTo: 1},
+		},
+		{
+			"missing reference",
+			&Selection{ReferencePoint: LogLineId{LogTimestamp: "6", LineNum: -1}, OffsetFrom: 0, OffsetTo: 1},
+			[]LogTimestamp{},
+			Selection{},
+		},
+	}
+
+	for _, c := range cases {
+		selected, _, _, selection, _ := lines.SelectFilteredLogs(filter, c.selection)
+		actual := make([]LogTimestamp, 0)
+		for _, line := range selected {
+			actual = append(actual, line.Timestamp)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) || !reflect.DeepEqual(selection, c.expectedSelection) {
+			t.Errorf("SelectFilteredLogs() of %s == %v, %#v, expected %v, %#v", c.info, actual, selection,
+				c.expected, c.expectedSelection)
+		}
+	}
+
+	// Without filter it works the same as SelectLogs
+	expected, _, _, expectedSelection, _ := lines.SelectLogs(DefaultSelection)
+	actual, _, _, selection, _ := lines.SelectFilteredLogs(nil, DefaultSelection)
+	if !reflect.DeepEqual(actual, expected) || !reflect.DeepEqual(selection, expectedSelection) {
+		t.Errorf("SelectFilteredLogs() without filter == %v, %#v, expected %v, %#v", actual, selection, expected,
+			expectedSelection)
+	}
+}
@@ -24,7 +24,6 @@ import (
 	"k8s.io/dashboard/api/pkg/resource/common"
 	"k8s.io/dashboard/api/pkg/resource/dataselect"
 	"k8s.io/dashboard/errors"
-	"k8s.io/dashboard/helpers"
 	"k8s.io/dashboard/types"
 )
 
@@ -59,20 +58,24 @@ func GetNamespaceListFromChannels(channels *common.ResourceChannels, dsQuery *da
 		return nil, criticalError
 	}
 
-	return toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery), nil
+	result := toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(namespaces.ListMeta)
+	return result, nil
 }
 
 // GetNamespaceList returns a list of all namespaces in the cluster.
 func GetNamespaceList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery) (*NamespaceList, error) {
 	klog.V(4).Info("Getting list of namespaces")
-	namespaces, err := client.CoreV1().Namespaces().List(context.TODO(), helpers.ListEverything)
+	namespaces, err := client.CoreV1().Namespaces().List(context.TODO(), dsQuery.ListOptions())
 
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery), nil
+	result := toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(namespaces.ListMeta)
+	return result, nil
 }
 
 func toNamespaceList(namespaces []v1.Namespace, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *NamespaceList {
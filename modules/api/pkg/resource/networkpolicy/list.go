@@ -24,7 +24,6 @@ import (
 	"k8s.io/dashboard/api/pkg/resource/common"
 	"k8s.io/dashboard/api/pkg/resource/dataselect"
 	"k8s.io/dashboard/errors"
-	"k8s.io/dashboard/helpers"
 	"k8s.io/dashboard/types"
 )
 
@@ -45,14 +44,16 @@ type NetworkPolicyList struct {
 func GetNetworkPolicyList(client client.Interface, namespace *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*NetworkPolicyList, error) {
 	saList, err := client.NetworkingV1().NetworkPolicies(namespace.ToRequestParam()).List(context.TODO(),
-		helpers.ListEverything)
+		dsQuery.ListOptions())
 
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toNetworkPolicyList(saList.Items, nonCriticalErrors, dsQuery), nil
+	result := toNetworkPolicyList(saList.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(saList.ListMeta)
+	return result, nil
 }
 
 func toNetworkPolicy(sa *v1.NetworkPolicy) NetworkPolicy {
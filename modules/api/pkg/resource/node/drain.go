@@ -17,7 +17,7 @@ package node
 import (
 	"context"
 	"fmt"
-	"os"
+	"strings"
 	"time"
 
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,8 +53,8 @@ func newHelper(ctx context.Context, client k8sClient.Interface, spec *NodeDrainS
 	helper := &drain.Helper{
 		Ctx:                 ctx,
 		Client:              client,
-		Out:                 os.Stdout,
-		ErrOut:              os.Stderr,
+		Out:                 logWriter{},
+		ErrOut:              logWriter{},
 		Force:               true,
 		Timeout:             2 * time.Minute,
 		GracePeriodSeconds:  -1,
@@ -89,26 +89,26 @@ func newHelper(ctx context.Context, client k8sClient.Interface, spec *NodeDrainS
 	return helper
 }
 
-// DrainNode drains the Node.
-func DrainNode(client k8sClient.Interface, name string, spec *NodeDrainSpec) error {
-	klog.V(args.LogLevelVerbose).Infof("Draining %s node", name)
+// UncordonNode marks the Node as schedulable again, i.e. after the drain is finished or cancelled.
+func UncordonNode(client k8sClient.Interface, name string) error {
+	klog.V(args.LogLevelVerbose).Infof("Uncordoning %s node", name)
 
-	node, err := client.CoreV1().Nodes().Get(context.Background(), name, metaV1.GetOptions{})
+	node, err := client.CoreV1().Nodes().Get(context.TODO(), name, metaV1.GetOptions{})
 	if err != nil {
-		return fmt.Errorf("error getting node: %w", err)
+		return err
 	}
 
-	helper := newHelper(context.Background(), client, spec)
-
-	if err := drain.RunCordonOrUncordon(helper, node, true); err != nil {
-		return fmt.Errorf("error cordoning node: %w", err)
+	if err := drain.RunCordonOrUncordon(newHelper(context.TODO(), client, nil), node, false); err != nil {
+		return fmt.Errorf("error uncordoning node: %w", err)
 	}
 
-	if err := drain.RunNodeDrain(helper, name); err != nil {
-		return fmt.Errorf("error draining node: %w", err)
-	}
+	return nil
+}
 
-	klog.V(args.LogLevelVerbose).Infof("Successfully drained %s node", name)
+// logWriter writes the drain helper output to the log instead of the standard output.
+type logWriter struct{}
 
-	return nil
+func (logWriter) Write(p []byte) (int, error) {
+	klog.V(args.LogLevelVerbose).Info(strings.TrimSpace(string(p)))
+	return len(p), nil
 }
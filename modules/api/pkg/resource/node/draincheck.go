@@ -0,0 +1,242 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/poddisruptionbudget"
+	"k8s.io/dashboard/errors"
+)
+
+// NodeDrainCheck describes the impact of draining the Node with the given spec. It is computed without
+// changing anything in the cluster.
+type NodeDrainCheck struct {
+	// Drainable is false when the drain would fail or when pod disruption budgets would block the evictions.
+	Drainable bool `json:"drainable"`
+
+	// EvictedPods are pods that would be evicted from the Node.
+	EvictedPods []DrainPod `json:"evictedPods"`
+
+	// SkippedPods are pods that would stay on the Node, i.e. DaemonSet-managed and mirror pods, or pods that make
+	// the drain fail.
+	SkippedPods []DrainPod `json:"skippedPods"`
+
+	// UnmanagedPods do not declare a controller, so they are not recreated anywhere once deleted.
+	UnmanagedPods []DrainPod `json:"unmanagedPods"`
+
+	// EmptyDirPods use emptyDir volumes, so their data is lost once deleted.
+	EmptyDirPods []DrainPod `json:"emptyDirPods"`
+
+	// PodDisruptionBudgets cover the evicted pods.
+	PodDisruptionBudgets []DrainPodDisruptionBudget `json:"podDisruptionBudgets"`
+
+	// DrainErrors are reasons why the drain would fail with the given spec.
+	DrainErrors []string `json:"drainErrors"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// DrainPod identifies a pod affected by the drain.
+type DrainPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// DrainPodDisruptionBudget is a pod disruption budget that covers pods evicted by the drain.
+type DrainPodDisruptionBudget struct {
+	Namespace          string `json:"namespace"`
+	Name               string `json:"name"`
+	DisruptionsAllowed int32  `json:"disruptionsAllowed"`
+
+	// Pods are evicted pods covered by the budget.
+	Pods []DrainPod `json:"pods"`
+
+	// Blocking is true when the budget does not allow all the evictions. The drain then waits until replacement pods
+	// become healthy somewhere else, or until it times out.
+	Blocking bool `json:"blocking"`
+}
+
+// CheckNodeDrain reports which pods would be evicted by draining the Node, and which pod disruption budgets would
+// block the evictions.
+func CheckNodeDrain(client k8sClient.Interface, name string, spec *NodeDrainSpec) (*NodeDrainCheck, error) {
+	klog.V(args.LogLevelVerbose).Infof("Checking drain of %s node", name)
+
+	if _, err := client.CoreV1().Nodes().Get(context.TODO(), name, metaV1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	helper := newHelper(context.TODO(), client, spec)
+	deleteList, drainErrors := helper.GetPodsForDeletion(name)
+	if deleteList == nil {
+		return nil, fmt.Errorf("error getting pods for deletion: %w", drainErrors[0])
+	}
+
+	podList, err := client.CoreV1().Pods(metaV1.NamespaceAll).List(context.TODO(), metaV1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	channel := common.GetPodDisruptionBudgetListChannel(client, common.NewNamespaceQuery(nil), 1)
+	pdbList := <-channel.List
+	nonCriticalErrors, criticalError := errors.ExtractErrors(<-channel.Error)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	var pdbs []policy.PodDisruptionBudget
+	if pdbList != nil {
+		pdbs = pdbList.Items
+	}
+
+	result := toNodeDrainCheck(podList.Items, deleteList.Pods(), pdbs)
+	result.Errors = nonCriticalErrors
+	for _, drainError := range drainErrors {
+		result.DrainErrors = append(result.DrainErrors, drainError.Error())
+	}
+
+	result.Drainable = len(result.DrainErrors) == 0
+	for _, pdb := range result.PodDisruptionBudgets {
+		result.Drainable = result.Drainable && !pdb.Blocking
+	}
+
+	return result, nil
+}
+
+func toNodeDrainCheck(pods, evictedPods []v1.Pod, pdbs []policy.PodDisruptionBudget) *NodeDrainCheck {
+	result := &NodeDrainCheck{
+		EvictedPods:          make([]DrainPod, 0),
+		SkippedPods:          make([]DrainPod, 0),
+		UnmanagedPods:        make([]DrainPod, 0),
+		EmptyDirPods:         make([]DrainPod, 0),
+		PodDisruptionBudgets: make([]DrainPodDisruptionBudget, 0),
+		DrainErrors:          make([]string, 0),
+	}
+
+	sort.Slice(pods, func(i, j int) bool { return lessObjectMeta(&pods[i].ObjectMeta, &pods[j].ObjectMeta) })
+	sort.Slice(pdbs, func(i, j int) bool { return lessObjectMeta(&pdbs[i].ObjectMeta, &pdbs[j].ObjectMeta) })
+
+	evicted := make(map[DrainPod]struct{}, len(evictedPods))
+	for _, pod := range evictedPods {
+		evicted[toDrainPod(pod)] = struct{}{}
+	}
+
+	for _, pod := range pods {
+		drainPod := toDrainPod(pod)
+		if _, exists := evicted[drainPod]; exists {
+			result.EvictedPods = append(result.EvictedPods, drainPod)
+		} else {
+			result.SkippedPods = append(result.SkippedPods, drainPod)
+		}
+
+		if metaV1.GetControllerOf(&pod) == nil {
+			result.UnmanagedPods = append(result.UnmanagedPods, drainPod)
+		}
+
+		if hasEmptyDir(pod) {
+			result.EmptyDirPods = append(result.EmptyDirPods, drainPod)
+		}
+	}
+
+	for i := range pdbs {
+		if budget, affected := toDrainPodDisruptionBudget(&pdbs[i], pods, evicted); affected {
+			result.PodDisruptionBudgets = append(result.PodDisruptionBudgets, budget)
+		}
+	}
+
+	return result
+}
+
+func toDrainPodDisruptionBudget(pdb *policy.PodDisruptionBudget, pods []v1.Pod, evicted map[DrainPod]struct{}) (
+	DrainPodDisruptionBudget, bool) {
+	result := DrainPodDisruptionBudget{
+		Namespace:          pdb.Namespace,
+		Name:               pdb.Name,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+		Pods:               make([]DrainPod, 0),
+	}
+
+	disruptions := int32(0)
+	for i := range pods {
+		if _, exists := evicted[toDrainPod(pods[i])]; !exists || !poddisruptionbudget.MatchesPod(pdb, &pods[i]) {
+			continue
+		}
+
+		result.Pods = append(result.Pods, toDrainPod(pods[i]))
+		if consumesDisruption(pdb, &pods[i]) {
+			disruptions++
+		}
+	}
+
+	result.Blocking = disruptions > pdb.Status.DisruptionsAllowed
+	return result, len(result.Pods) > 0
+}
+
+// consumesDisruption checks if the eviction of the pod is limited by the budget. Finished pods are deleted without
+// checking budgets and unhealthy pods are evicted freely when the budget allows it.
+func consumesDisruption(pdb *policy.PodDisruptionBudget, pod *v1.Pod) bool {
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return false
+	}
+
+	evictionPolicy := pdb.Spec.UnhealthyPodEvictionPolicy
+	return evictionPolicy == nil || *evictionPolicy != policy.AlwaysAllow || isPodReady(pod)
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func hasEmptyDir(pod v1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lessObjectMeta(a, b *metaV1.ObjectMeta) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+
+	return a.Name < b.Name
+}
+
+func toDrainPod(pod v1.Pod) DrainPod {
+	return DrainPod{Namespace: pod.Namespace, Name: pod.Name}
+}
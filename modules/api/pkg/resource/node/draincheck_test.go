@@ -0,0 +1,149 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDrainCheckPod(name string, owner *metaV1.OwnerReference, labels map[string]string,
+	volumes ...v1.Volume) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec:       v1.PodSpec{NodeName: "node", Volumes: volumes},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+
+	if owner != nil {
+		pod.OwnerReferences = []metaV1.OwnerReference{*owner}
+	}
+
+	return pod
+}
+
+func TestCheckNodeDrain(t *testing.T) {
+	controller := true
+	replicaSet := &metaV1.OwnerReference{Kind: "ReplicaSet", Name: "web", Controller: &controller}
+	daemonSet := &metaV1.OwnerReference{Kind: "DaemonSet", Name: "agent", Controller: &controller}
+	emptyDir := v1.Volume{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}
+	webLabels := map[string]string{"app": "web"}
+
+	objects := func(allowed int32) []runtime.Object {
+		return []runtime.Object{
+			&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "node"}},
+			&apps.DaemonSet{ObjectMeta: metaV1.ObjectMeta{Name: "agent", Namespace: "default"}},
+			newDrainCheckPod("web-1", replicaSet, webLabels),
+			newDrainCheckPod("web-2", replicaSet, webLabels, emptyDir),
+			newDrainCheckPod("agent", daemonSet, nil),
+			newDrainCheckPod("standalone", nil, nil),
+			&policy.PodDisruptionBudget{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       policy.PodDisruptionBudgetSpec{Selector: &metaV1.LabelSelector{MatchLabels: webLabels}},
+				Status:     policy.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+			},
+		}
+	}
+
+	force := false
+	cases := []struct {
+		info     string
+		allowed  int32
+		spec     *NodeDrainSpec
+		expected *NodeDrainCheck
+	}{
+		{
+			"drain blocked by pod disruption budget",
+			1, &NodeDrainSpec{},
+			&NodeDrainCheck{
+				Drainable:     false,
+				EvictedPods:   []DrainPod{{"default", "standalone"}, {"default", "web-1"}, {"default", "web-2"}},
+				SkippedPods:   []DrainPod{{"default", "agent"}},
+				UnmanagedPods: []DrainPod{{"default", "standalone"}},
+				EmptyDirPods:  []DrainPod{{"default", "web-2"}},
+				PodDisruptionBudgets: []DrainPodDisruptionBudget{{
+					Namespace:          "default",
+					Name:               "web",
+					DisruptionsAllowed: 1,
+					Pods:               []DrainPod{{"default", "web-1"}, {"default", "web-2"}},
+					Blocking:           true,
+				}},
+				DrainErrors: []string{},
+				Errors:      []error{},
+			},
+		},
+		{
+			"drain allowed by pod disruption budget",
+			2, &NodeDrainSpec{},
+			&NodeDrainCheck{
+				Drainable:     true,
+				EvictedPods:   []DrainPod{{"default", "standalone"}, {"default", "web-1"}, {"default", "web-2"}},
+				SkippedPods:   []DrainPod{{"default", "agent"}},
+				UnmanagedPods: []DrainPod{{"default", "standalone"}},
+				EmptyDirPods:  []DrainPod{{"default", "web-2"}},
+				PodDisruptionBudgets: []DrainPodDisruptionBudget{{
+					Namespace:          "default",
+					Name:               "web",
+					DisruptionsAllowed: 2,
+					Pods:               []DrainPod{{"default", "web-1"}, {"default", "web-2"}},
+				}},
+				DrainErrors: []string{},
+				Errors:      []error{},
+			},
+		},
+		{
+			"unmanaged pods fail the drain without force",
+			2, &NodeDrainSpec{Force: &force},
+			&NodeDrainCheck{
+				Drainable:     false,
+				EvictedPods:   []DrainPod{{"default", "web-1"}, {"default", "web-2"}},
+				SkippedPods:   []DrainPod{{"default", "agent"}, {"default", "standalone"}},
+				UnmanagedPods: []DrainPod{{"default", "standalone"}},
+				EmptyDirPods:  []DrainPod{{"default", "web-2"}},
+				PodDisruptionBudgets: []DrainPodDisruptionBudget{{
+					Namespace:          "default",
+					Name:               "web",
+					DisruptionsAllowed: 2,
+					Pods:               []DrainPod{{"default", "web-1"}, {"default", "web-2"}},
+				}},
+				DrainErrors: []string{"cannot delete cannot delete Pods that declare no controller " +
+					"(use --force to override): default/standalone"},
+				Errors: []error{},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(objects(c.allowed)...)
+		actual, err := CheckNodeDrain(client, "node", c.spec)
+		if err != nil {
+			t.Fatalf("%s: CheckNodeDrain() returned error: %v", c.info, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: CheckNodeDrain(...) == \n%#v\nexpected \n%#v", c.info, actual, c.expected)
+		}
+	}
+}
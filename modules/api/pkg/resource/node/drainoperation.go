@@ -0,0 +1,395 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/drain"
+
+	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/errors"
+)
+
+const (
+	// drainOperationRetention is the time for which the status of a finished drain is kept.
+	drainOperationRetention = time.Hour
+	// maxDrainMessages is the maximum number of drain messages kept in the status.
+	maxDrainMessages = 100
+)
+
+// DrainPhase is a phase of the node drain operation.
+type DrainPhase string
+
+const (
+	DrainPhasePending   DrainPhase = "pending"
+	DrainPhaseEvicting  DrainPhase = "evicting"
+	DrainPhaseDone      DrainPhase = "done"
+	DrainPhaseFailed    DrainPhase = "failed"
+	DrainPhaseCancelled DrainPhase = "cancelled"
+)
+
+// DrainPodPhase is a phase of the eviction of a single pod.
+type DrainPodPhase string
+
+const (
+	DrainPodPhasePending  DrainPodPhase = "pending"
+	DrainPodPhaseEvicting DrainPodPhase = "evicting"
+	DrainPodPhaseEvicted  DrainPodPhase = "evicted"
+	DrainPodPhaseDeleted  DrainPodPhase = "deleted"
+	DrainPodPhaseFailed   DrainPodPhase = "failed"
+)
+
+// DrainPodProgress is the eviction progress of a single pod.
+type DrainPodProgress struct {
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	Phase     DrainPodPhase `json:"phase"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// NodeDrainStatus is the status of the node drain running in the background.
+type NodeDrainStatus struct {
+	ID             string       `json:"id"`
+	NodeName       string       `json:"nodeName"`
+	Phase          DrainPhase   `json:"phase"`
+	StartTime      metaV1.Time  `json:"startTime"`
+	CompletionTime *metaV1.Time `json:"completionTime,omitempty"`
+
+	// Pods that are evicted by the drain, known once the drain reaches the evicting phase.
+	Pods []DrainPodProgress `json:"pods"`
+
+	// FinishedPods is the number of pods that were already evicted or deleted.
+	FinishedPods int `json:"finishedPods"`
+
+	// Messages reported by the drain, i.e. evictions retried due to pod disruption budgets.
+	Messages []string `json:"messages"`
+
+	// Error is set when the drain failed.
+	Error string `json:"error,omitempty"`
+}
+
+// NodeDrainStatusList contains drains of a Node, the most recent first.
+type NodeDrainStatusList struct {
+	Items []NodeDrainStatus `json:"items"`
+}
+
+type drainOperation struct {
+	lock   sync.RWMutex
+	status NodeDrainStatus
+	cancel context.CancelFunc
+}
+
+type drainOperationMap struct {
+	lock       sync.RWMutex
+	operations map[string]*drainOperation
+}
+
+var drainOperations = &drainOperationMap{operations: make(map[string]*drainOperation)}
+
+// nodeDrainAccess lists permissions needed to drain the node. Drains run in the background, so they are also required
+// to cancel a drain started by someone else.
+var nodeDrainAccess = []authorizationv1.ResourceAttributes{
+	{Verb: "patch", Resource: "nodes"},
+	{Verb: "create", Resource: "pods", Subresource: "eviction"},
+}
+
+// CheckNodeDrainAccess checks that the user is allowed to cordon the node and evict its pods.
+func CheckNodeDrainAccess(client k8sClient.Interface, name string) error {
+	for _, attributes := range nodeDrainAccess {
+		if attributes.Resource == "nodes" {
+			attributes.Name = name
+		}
+
+		review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(),
+			&authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+			}, metaV1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+
+		if !review.Status.Allowed {
+			return errors.NewForbidden(name, fmt.Errorf("node drain requires permission to %s %s", attributes.Verb,
+				toResourceName(attributes)))
+		}
+	}
+
+	return nil
+}
+
+// StartNodeDrain cordons and drains the Node in the background. The returned status contains the ID that can be used
+// to track the progress and to cancel the drain. Only a single drain of a Node can run at a time.
+func StartNodeDrain(client k8sClient.Interface, name string, spec *NodeDrainSpec) (*NodeDrainStatus, error) {
+	node, err := client.CoreV1().Nodes().Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := genDrainOperationId()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	operation := &drainOperation{
+		status: NodeDrainStatus{
+			ID:        id,
+			NodeName:  name,
+			Phase:     DrainPhasePending,
+			StartTime: metaV1.Now(),
+			Pods:      make([]DrainPodProgress, 0),
+			Messages:  make([]string, 0),
+		},
+		cancel: cancel,
+	}
+
+	if err := drainOperations.add(operation); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	klog.V(args.LogLevelVerbose).Infof("Starting drain %s of %s node", id, name)
+	go operation.run(ctx, newHelper(ctx, client, spec), node)
+
+	return operation.snapshot(), nil
+}
+
+// GetNodeDrainStatus returns the status of the Node drain.
+func GetNodeDrainStatus(name, id string) (*NodeDrainStatus, error) {
+	operation, err := drainOperations.get(name, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return operation.snapshot(), nil
+}
+
+// GetNodeDrainStatusList returns statuses of the Node drains that are running or recently finished.
+func GetNodeDrainStatusList(name string) *NodeDrainStatusList {
+	return &NodeDrainStatusList{Items: drainOperations.list(name)}
+}
+
+// CancelNodeDrain stops the Node drain. Pods that were already evicted are not restored and the Node stays
+// cordoned until it is uncordoned.
+func CancelNodeDrain(name, id string) (*NodeDrainStatus, error) {
+	operation, err := drainOperations.get(name, id)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(args.LogLevelVerbose).Infof("Cancelling drain %s of %s node", id, name)
+	operation.cancel()
+	return operation.snapshot(), nil
+}
+
+func (self *drainOperationMap) add(operation *drainOperation) error {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	for id, existing := range self.operations {
+		status := existing.snapshot()
+		if status.CompletionTime != nil && time.Since(status.CompletionTime.Time) > drainOperationRetention {
+			delete(self.operations, id)
+			continue
+		}
+
+		if status.NodeName == operation.status.NodeName && status.CompletionTime == nil {
+			return errors.NewBadRequest(fmt.Sprintf("node %s is already being drained by %s", status.NodeName,
+				status.ID))
+		}
+	}
+
+	self.operations[operation.status.ID] = operation
+	return nil
+}
+
+func (self *drainOperationMap) get(name, id string) (*drainOperation, error) {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	operation, exists := self.operations[id]
+	if !exists || operation.status.NodeName != name {
+		return nil, errors.NewNotFound(fmt.Sprintf("drain %s of node %s not found", id, name))
+	}
+
+	return operation, nil
+}
+
+func (self *drainOperationMap) list(name string) []NodeDrainStatus {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	result := make([]NodeDrainStatus, 0)
+	for _, operation := range self.operations {
+		if operation.status.NodeName == name {
+			result = append(result, *operation.snapshot())
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[j].StartTime.Before(&result[i].StartTime) })
+	return result
+}
+
+// run cordons the Node and evicts its pods the same way as drain.RunNodeDrain, while recording the progress.
+func (self *drainOperation) run(ctx context.Context, helper *drain.Helper, node *v1.Node) {
+	helper.ErrOut = drainMessageWriter{operation: self}
+	helper.OnPodDeletionOrEvictionStarted = func(pod *v1.Pod, _ bool) {
+		self.setPodPhase(pod, DrainPodPhaseEvicting, nil)
+	}
+	helper.OnPodDeletionOrEvictionFinished = func(pod *v1.Pod, usingEviction bool, err error) {
+		phase := DrainPodPhaseDeleted
+		if usingEviction {
+			phase = DrainPodPhaseEvicted
+		}
+		self.setPodPhase(pod, phase, err)
+	}
+
+	if err := drain.RunCordonOrUncordon(helper, node, true); err != nil {
+		self.finish(ctx, fmt.Errorf("error cordoning node: %w", err))
+		return
+	}
+
+	list, errs := helper.GetPodsForDeletion(node.Name)
+	if errs != nil {
+		self.finish(ctx, utilerrors.NewAggregate(errs))
+		return
+	}
+
+	if warnings := list.Warnings(); len(warnings) > 0 {
+		self.addMessage(fmt.Sprintf("WARNING: %s", warnings))
+	}
+
+	if ctx.Err() != nil {
+		self.finish(ctx, ctx.Err())
+		return
+	}
+
+	pods := list.Pods()
+	self.lock.Lock()
+	self.status.Phase = DrainPhaseEvicting
+	for _, pod := range pods {
+		self.status.Pods = append(self.status.Pods, DrainPodProgress{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Phase:     DrainPodPhasePending,
+		})
+	}
+	self.lock.Unlock()
+
+	self.finish(ctx, helper.DeleteOrEvictPods(pods))
+}
+
+func (self *drainOperation) finish(ctx context.Context, err error) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	now := metaV1.Now()
+	self.status.CompletionTime = &now
+	switch {
+	case ctx.Err() != nil:
+		self.status.Phase = DrainPhaseCancelled
+	case err != nil:
+		self.status.Phase = DrainPhaseFailed
+		self.status.Error = err.Error()
+	default:
+		self.status.Phase = DrainPhaseDone
+	}
+
+	// Releases the context resources.
+	self.cancel()
+	klog.V(args.LogLevelVerbose).Infof("Drain %s of %s node finished with %s phase", self.status.ID,
+		self.status.NodeName, self.status.Phase)
+}
+
+func (self *drainOperation) setPodPhase(pod *v1.Pod, phase DrainPodPhase, err error) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	for i := range self.status.Pods {
+		progress := &self.status.Pods[i]
+		if progress.Namespace != pod.Namespace || progress.Name != pod.Name {
+			continue
+		}
+
+		if err != nil {
+			progress.Phase = DrainPodPhaseFailed
+			progress.Error = err.Error()
+		} else {
+			progress.Phase = phase
+		}
+
+		if progress.Phase != DrainPodPhaseEvicting {
+			self.status.FinishedPods++
+		}
+		return
+	}
+}
+
+func (self *drainOperation) addMessage(message string) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	self.status.Messages = append(self.status.Messages, message)
+	if len(self.status.Messages) > maxDrainMessages {
+		self.status.Messages = self.status.Messages[len(self.status.Messages)-maxDrainMessages:]
+	}
+}
+
+// snapshot returns a copy of the status that can be safely read while the drain is running.
+func (self *drainOperation) snapshot() *NodeDrainStatus {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	status := self.status
+	status.Pods = append(make([]DrainPodProgress, 0, len(self.status.Pods)), self.status.Pods...)
+	status.Messages = append(make([]string, 0, len(self.status.Messages)), self.status.Messages...)
+	return &status
+}
+
+// drainMessageWriter records the drain helper error output, i.e. evictions retried due to pod disruption budgets.
+type drainMessageWriter struct {
+	operation *drainOperation
+}
+
+func (self drainMessageWriter) Write(p []byte) (int, error) {
+	message := strings.TrimSpace(string(p))
+	klog.V(args.LogLevelVerbose).Infof("Drain %s: %s", self.operation.status.ID, message)
+	self.operation.addMessage(message)
+	return len(p), nil
+}
+
+// genDrainOperationId generates a random drain operation ID.
+func genDrainOperationId() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
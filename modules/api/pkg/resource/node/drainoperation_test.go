@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/dashboard/errors"
+)
+
+func waitForNodeDrain(t *testing.T, name, id string) *NodeDrainStatus {
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+		status, err := GetNodeDrainStatus(name, id)
+		if err != nil {
+			t.Fatalf("GetNodeDrainStatus() returned error: %v", err)
+		}
+
+		if status.CompletionTime != nil {
+			return status
+		}
+	}
+
+	t.Fatalf("drain %s of %s node did not finish", id, name)
+	return nil
+}
+
+func TestStartNodeDrain(t *testing.T) {
+	controller := true
+	owner := &metaV1.OwnerReference{Kind: "ReplicaSet", Name: "web", Controller: &controller}
+	client := fake.NewSimpleClientset(
+		&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "drained-node"}},
+		newDrainCheckPod("web-1", owner, nil),
+	)
+	// Without the eviction subresource, pods are deleted instead of evicted.
+	client.Resources = []*metaV1.APIResourceList{{GroupVersion: "v1"}}
+
+	status, err := StartNodeDrain(client, "drained-node", &NodeDrainSpec{})
+	if err != nil {
+		t.Fatalf("StartNodeDrain() returned error: %v", err)
+	}
+
+	actual := waitForNodeDrain(t, "drained-node", status.ID)
+	expected := []DrainPodProgress{{Namespace: "default", Name: "web-1", Phase: DrainPodPhaseDeleted}}
+	if actual.Phase != DrainPhaseDone || actual.FinishedPods != 1 || !reflect.DeepEqual(actual.Pods, expected) {
+		t.Errorf("drain finished with status %#v, expected done phase and pods \n%#v", actual, expected)
+	}
+
+	node, _ := client.CoreV1().Nodes().Get(context.TODO(), "drained-node", metaV1.GetOptions{})
+	if !node.Spec.Unschedulable {
+		t.Errorf("drained node should be cordoned")
+	}
+
+	if list := GetNodeDrainStatusList("drained-node"); len(list.Items) == 0 || list.Items[0].ID != status.ID {
+		t.Errorf("GetNodeDrainStatusList() == %#v, expected drain %s", list, status.ID)
+	}
+
+	if err := UncordonNode(client, "drained-node"); err != nil {
+		t.Fatalf("UncordonNode() returned error: %v", err)
+	}
+
+	node, _ = client.CoreV1().Nodes().Get(context.TODO(), "drained-node", metaV1.GetOptions{})
+	if node.Spec.Unschedulable {
+		t.Errorf("uncordoned node should be schedulable")
+	}
+}
+
+func TestCancelNodeDrain(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "cancelled-node"}})
+	listing := make(chan struct{})
+	release := make(chan struct{})
+	client.PrependReactor("list", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		close(listing)
+		<-release
+		return false, nil, nil
+	})
+
+	status, err := StartNodeDrain(client, "cancelled-node", &NodeDrainSpec{})
+	if err != nil {
+		t.Fatalf("StartNodeDrain() returned error: %v", err)
+	}
+	<-listing
+
+	// Fake client is locked while the reactor blocks, so the second drain has to use a different one.
+	otherClient := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "cancelled-node"}})
+	if _, err := StartNodeDrain(otherClient, "cancelled-node", &NodeDrainSpec{}); !errors.IsBadRequest(err) {
+		t.Errorf("StartNodeDrain() of node being drained should return bad request error, got %v", err)
+	}
+
+	if _, err := CancelNodeDrain("other-node", status.ID); !errors.IsNotFound(err) {
+		t.Errorf("CancelNodeDrain() of other node should return not found error, got %v", err)
+	}
+
+	if _, err := CancelNodeDrain("cancelled-node", status.ID); err != nil {
+		t.Fatalf("CancelNodeDrain() returned error: %v", err)
+	}
+	close(release)
+
+	if actual := waitForNodeDrain(t, "cancelled-node", status.ID); actual.Phase != DrainPhaseCancelled {
+		t.Errorf("drain finished with %s phase, expected %s", actual.Phase, DrainPhaseCancelled)
+	}
+}
+
+func TestCheckNodeDrainAccess(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	reviews := make([]authorizationv1.ResourceAttributes, 0)
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			attributes := *review.Spec.ResourceAttributes
+			reviews = append(reviews, attributes)
+			review.Status.Allowed = attributes.Subresource != "eviction"
+			return true, review, nil
+		})
+
+	err := CheckNodeDrainAccess(client, "node-1")
+	if !errors.IsForbidden(err) || !strings.Contains(err.Error(), "create pods/eviction") {
+		t.Errorf("CheckNodeDrainAccess() should return forbidden error for missing eviction permission, got %v", err)
+	}
+
+	expected := []authorizationv1.ResourceAttributes{
+		{Verb: "patch", Resource: "nodes", Name: "node-1"},
+		{Verb: "create", Resource: "pods", Subresource: "eviction"},
+	}
+	if !reflect.DeepEqual(reviews, expected) {
+		t.Errorf("CheckNodeDrainAccess() reviewed %#v, expected %#v", reviews, expected)
+	}
+}
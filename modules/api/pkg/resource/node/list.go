@@ -24,7 +24,6 @@ import (
 	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
 	"k8s.io/dashboard/api/pkg/resource/dataselect"
 	"k8s.io/dashboard/errors"
-	"k8s.io/dashboard/helpers"
 	"k8s.io/dashboard/types"
 )
 
@@ -49,14 +48,16 @@ type Node struct {
 
 // GetNodeList returns a list of all Nodes in the cluster.
 func GetNodeList(client client.Interface, dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*NodeList, error) {
-	nodes, err := client.CoreV1().Nodes().List(context.TODO(), helpers.ListEverything)
+	nodes, err := client.CoreV1().Nodes().List(context.TODO(), dsQuery.ListOptions())
 
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toNodeList(client, nodes.Items, nonCriticalErrors, dsQuery, metricClient), nil
+	result := toNodeList(client, nodes.Items, nonCriticalErrors, dsQuery, metricClient)
+	result.ListMeta.SetChunk(nodes.ListMeta)
+	return result, nil
 }
 
 func toNodeList(client client.Interface, nodes []v1.Node, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery,
@@ -0,0 +1,148 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/errors"
+)
+
+const (
+	// NodeShellContainerName is the name of the container running the node shell.
+	NodeShellContainerName = "debugger"
+
+	// NodeShellLabel marks node shell pods with the name of the node, so they can be found and cleaned up.
+	NodeShellLabel = "dashboard.kubernetes.io/node-shell"
+
+	// nodeShellHostPath is where the host filesystem is mounted in the node shell container.
+	nodeShellHostPath = "/host"
+
+	// nodeShellDeadlineSeconds limits how long the node shell pod can run in case it is not deleted after the session.
+	nodeShellDeadlineSeconds = int64(4 * 60 * 60)
+)
+
+// nodeShellAccess lists permissions needed to run the node shell. They are checked upfront, so the user does not
+// end up with the privileged pod they cannot attach to or delete.
+var nodeShellAccess = []authorizationv1.ResourceAttributes{
+	{Verb: "get", Resource: "nodes"},
+	{Verb: "create", Resource: "pods"},
+	{Verb: "create", Resource: "pods", Subresource: "attach"},
+	{Verb: "delete", Resource: "pods"},
+}
+
+// CheckNodeShellAccess checks that the node shell is enabled and the user is allowed to run it on the node using pods
+// in the namespace.
+func CheckNodeShellAccess(client k8sClient.Interface, namespace, name string) error {
+	if !args.IsNodeShellEnabled() {
+		return errors.NewForbidden(name, fmt.Errorf("node shell is disabled, it can be enabled with the "+
+			"--enable-node-shell flag"))
+	}
+
+	for _, attributes := range nodeShellAccess {
+		attributes.Namespace = namespace
+		if attributes.Resource == "nodes" {
+			attributes.Namespace, attributes.Name = "", name
+		}
+
+		review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(),
+			&authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+			}, metaV1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+
+		if !review.Status.Allowed {
+			return errors.NewForbidden(name, fmt.Errorf("node shell requires permission to %s %s", attributes.Verb,
+				toResourceName(attributes)))
+		}
+	}
+
+	return nil
+}
+
+func toResourceName(attributes authorizationv1.ResourceAttributes) string {
+	if len(attributes.Subresource) > 0 {
+		return attributes.Resource + "/" + attributes.Subresource
+	}
+
+	return attributes.Resource
+}
+
+// CreateNodeShellPod creates a privileged pod on the node in the manner of `kubectl debug node/...`. The pod shares
+// the host PID, network and IPC namespaces and has the host filesystem mounted under /host.
+func CreateNodeShellPod(client k8sClient.Interface, namespace, name, image string) (*v1.Pod, error) {
+	if _, err := client.CoreV1().Nodes().Get(context.TODO(), name, metaV1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	if len(image) == 0 {
+		image = args.DebugImage()
+	}
+
+	klog.V(args.LogLevelVerbose).Infof("Creating shell pod for %s node in %s namespace", name, namespace)
+	privileged := true
+	deadline := nodeShellDeadlineSeconds
+	gracePeriod := int64(0)
+	return client.CoreV1().Pods(namespace).Create(context.TODO(), &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{
+			GenerateName: fmt.Sprintf("node-shell-%s-", name),
+			Namespace:    namespace,
+			Labels:       map[string]string{NodeShellLabel: name},
+		},
+		Spec: v1.PodSpec{
+			NodeName:                      name,
+			HostPID:                       true,
+			HostNetwork:                   true,
+			HostIPC:                       true,
+			RestartPolicy:                 v1.RestartPolicyNever,
+			ActiveDeadlineSeconds:         &deadline,
+			TerminationGracePeriodSeconds: &gracePeriod,
+			// Node shell has to run also on tainted nodes, as they are often the ones that need debugging.
+			Tolerations: []v1.Toleration{{Operator: v1.TolerationOpExists}},
+			Containers: []v1.Container{{
+				Name:                     NodeShellContainerName,
+				Image:                    image,
+				ImagePullPolicy:          v1.PullIfNotPresent,
+				Stdin:                    true,
+				TTY:                      true,
+				TerminationMessagePolicy: v1.TerminationMessageReadFile,
+				SecurityContext:          &v1.SecurityContext{Privileged: &privileged},
+				VolumeMounts:             []v1.VolumeMount{{Name: "host-root", MountPath: nodeShellHostPath}},
+			}},
+			Volumes: []v1.Volume{{
+				Name:         "host-root",
+				VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/"}},
+			}},
+		},
+	}, metaV1.CreateOptions{})
+}
+
+// DeleteNodeShellPod deletes the node shell pod immediately after the session is closed.
+func DeleteNodeShellPod(client k8sClient.Interface, namespace, name string) error {
+	klog.V(args.LogLevelVerbose).Infof("Deleting %s node shell pod in %s namespace", name, namespace)
+	gracePeriod := int64(0)
+	return client.CoreV1().Pods(namespace).Delete(context.TODO(), name,
+		metaV1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+}
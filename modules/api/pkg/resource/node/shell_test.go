@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/dashboard/errors"
+)
+
+func TestCheckNodeShellAccess(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	if err := CheckNodeShellAccess(client, "default", "node-1"); !errors.IsForbidden(err) {
+		t.Errorf("CheckNodeShellAccess() should return forbidden error when node shell is disabled, got %v", err)
+	}
+
+	_ = pflag.Set("enable-node-shell", "true")
+	defer func() { _ = pflag.Set("enable-node-shell", "false") }()
+
+	var reviews []authorizationv1.ResourceAttributes
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			attributes := *review.Spec.ResourceAttributes
+			reviews = append(reviews, attributes)
+			review.Status.Allowed = attributes.Verb != "delete"
+			return true, review, nil
+		})
+
+	err := CheckNodeShellAccess(client, "default", "node-1")
+	if !errors.IsForbidden(err) || !strings.Contains(err.Error(), "delete pods") {
+		t.Errorf("CheckNodeShellAccess() should return forbidden error for missing delete permission, got %v", err)
+	}
+
+	expected := []authorizationv1.ResourceAttributes{
+		{Verb: "get", Resource: "nodes", Name: "node-1"},
+		{Verb: "create", Resource: "pods", Namespace: "default"},
+		{Verb: "create", Resource: "pods", Subresource: "attach", Namespace: "default"},
+		{Verb: "delete", Resource: "pods", Namespace: "default"},
+	}
+	if len(reviews) != len(expected) {
+		t.Fatalf("CheckNodeShellAccess() reviewed %#v, expected %#v", reviews, expected)
+	}
+	for i := range expected {
+		if reviews[i] != expected[i] {
+			t.Errorf("CheckNodeShellAccess() reviewed %#v, expected %#v", reviews[i], expected[i])
+		}
+	}
+}
+
+func TestCreateNodeShellPod(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "node-1"}})
+
+	pod, err := CreateNodeShellPod(client, "default", "node-1", "ubuntu")
+	if err != nil {
+		t.Fatalf("CreateNodeShellPod() returned error: %v", err)
+	}
+
+	container := pod.Spec.Containers[0]
+	if pod.Spec.NodeName != "node-1" || !pod.Spec.HostPID || !pod.Spec.HostNetwork ||
+		pod.Labels[NodeShellLabel] != "node-1" || container.Image != "ubuntu" || !container.TTY ||
+		!*container.SecurityContext.Privileged || pod.Spec.Volumes[0].HostPath.Path != "/" {
+		t.Errorf("CreateNodeShellPod() == %#v, expected privileged pod pinned to node-1", pod)
+	}
+
+	if _, err := CreateNodeShellPod(client, "default", "missing-node", ""); !errors.IsNotFound(err) {
+		t.Errorf("CreateNodeShellPod() on missing node should return not found error, got %v", err)
+	}
+}
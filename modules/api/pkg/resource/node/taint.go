@@ -0,0 +1,158 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"k8s.io/dashboard/errors"
+)
+
+// NodeTaintSpec describes changes of the node taints.
+type NodeTaintSpec struct {
+	// Add adds the taints. Existing taints with the same key and effect are replaced.
+	Add []v1.Taint `json:"add,omitempty"`
+
+	// Remove removes the taints with the same key and effect. Taint without effect removes all taints with the key.
+	Remove []v1.Taint `json:"remove,omitempty"`
+}
+
+// NodeTaints contains taints of the node after the change.
+type NodeTaints struct {
+	Taints []v1.Taint `json:"taints"`
+}
+
+var supportedTaintEffects = []v1.TaintEffect{v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule,
+	v1.TaintEffectNoExecute}
+
+// PatchNodeTaints adds and removes taints of the node. Taints are a list, which JSON merge patch replaces as a whole, so
+// the patch is guarded by the resource version and retried when the node changes in the meantime.
+func PatchNodeTaints(client k8sClient.Interface, name string, spec *NodeTaintSpec) (*NodeTaints, error) {
+	if err := validateNodeTaintSpec(spec); err != nil {
+		return nil, err
+	}
+
+	var result *v1.Node
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"resourceVersion": node.ResourceVersion},
+			"spec":     map[string]interface{}{"taints": updateTaints(node.Spec.Taints, spec)},
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = client.CoreV1().Nodes().Patch(context.TODO(), name, k8stypes.MergePatchType, patch,
+			metaV1.PatchOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeTaints{Taints: result.Spec.Taints}, nil
+}
+
+func updateTaints(taints []v1.Taint, spec *NodeTaintSpec) []v1.Taint {
+	result := make([]v1.Taint, 0, len(taints)+len(spec.Add))
+	for _, taint := range taints {
+		if !matchesAnyTaint(taint, spec.Remove) && !matchesAnyTaint(taint, spec.Add) {
+			result = append(result, taint)
+		}
+	}
+
+	for _, taint := range spec.Add {
+		// Eviction of pods that do not tolerate the taint is measured from the time it is added.
+		if taint.Effect == v1.TaintEffectNoExecute && taint.TimeAdded == nil {
+			now := metaV1.Now()
+			taint.TimeAdded = &now
+		}
+		result = append(result, taint)
+	}
+
+	return result
+}
+
+func matchesAnyTaint(taint v1.Taint, taints []v1.Taint) bool {
+	for _, t := range taints {
+		if t.Key == taint.Key && (len(t.Effect) == 0 || t.Effect == taint.Effect) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateNodeTaintSpec(spec *NodeTaintSpec) error {
+	var errs field.ErrorList
+	for i, taint := range spec.Add {
+		path := field.NewPath("add").Index(i)
+		errs = append(errs, validateTaint(taint, path)...)
+		if len(taint.Effect) == 0 {
+			errs = append(errs, field.Required(path.Child("effect"), ""))
+		}
+	}
+
+	for i, taint := range spec.Remove {
+		errs = append(errs, validateTaint(taint, field.NewPath("remove").Index(i))...)
+	}
+
+	if len(errs) > 0 {
+		return errors.NewBadRequest(errs.ToAggregate().Error())
+	}
+
+	return nil
+}
+
+func validateTaint(taint v1.Taint, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, msg := range validation.IsQualifiedName(taint.Key) {
+		errs = append(errs, field.Invalid(path.Child("key"), taint.Key, msg))
+	}
+
+	for _, msg := range validation.IsValidLabelValue(taint.Value) {
+		errs = append(errs, field.Invalid(path.Child("value"), taint.Value, msg))
+	}
+
+	if len(taint.Effect) > 0 && !isSupportedTaintEffect(taint.Effect) {
+		errs = append(errs, field.NotSupported(path.Child("effect"), taint.Effect, supportedTaintEffects))
+	}
+
+	return errs
+}
+
+func isSupportedTaintEffect(effect v1.TaintEffect) bool {
+	for _, supported := range supportedTaintEffects {
+		if effect == supported {
+			return true
+		}
+	}
+
+	return false
+}
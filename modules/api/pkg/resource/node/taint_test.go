@@ -0,0 +1,70 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/dashboard/errors"
+)
+
+func TestPatchNodeTaints(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "tainted-node"},
+		Spec: v1.NodeSpec{Taints: []v1.Taint{
+			{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectPreferNoSchedule},
+			{Key: "maintenance", Effect: v1.TaintEffectNoSchedule},
+			{Key: "zone", Value: "a", Effect: v1.TaintEffectNoSchedule},
+		}},
+	})
+
+	actual, err := PatchNodeTaints(client, "tainted-node", &NodeTaintSpec{
+		Add:    []v1.Taint{{Key: "zone", Value: "b", Effect: v1.TaintEffectNoSchedule}},
+		Remove: []v1.Taint{{Key: "dedicated"}, {Key: "maintenance", Effect: v1.TaintEffectNoExecute}},
+	})
+	if err != nil {
+		t.Fatalf("PatchNodeTaints() returned error: %v", err)
+	}
+
+	expected := &NodeTaints{Taints: []v1.Taint{
+		{Key: "maintenance", Effect: v1.TaintEffectNoSchedule},
+		{Key: "zone", Value: "b", Effect: v1.TaintEffectNoSchedule},
+	}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("PatchNodeTaints() == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
+
+func TestPatchNodeTaintsInvalidSpec(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "tainted-node"}})
+	cases := []*NodeTaintSpec{
+		{Add: []v1.Taint{{Key: "dedicated"}}},
+		{Add: []v1.Taint{{Key: "invalid key", Effect: v1.TaintEffectNoSchedule}}},
+		{Add: []v1.Taint{{Key: "dedicated", Value: "invalid value!", Effect: v1.TaintEffectNoSchedule}}},
+		{Remove: []v1.Taint{{Key: "dedicated", Effect: "Explode"}}},
+	}
+
+	for _, c := range cases {
+		if _, err := PatchNodeTaints(client, "tainted-node", c); !errors.IsBadRequest(err) {
+			t.Errorf("PatchNodeTaints(%#v) should return bad request error, got %v", c, err)
+		}
+	}
+}
@@ -52,7 +52,7 @@ type PersistentVolume struct {
 func GetPersistentVolumeList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery) (*PersistentVolumeList, error) {
 	klog.V(4).Infof("Getting list persistent volumes")
 	channels := &common.ResourceChannels{
-		PersistentVolumeList: common.GetPersistentVolumeListChannel(client, 1),
+		PersistentVolumeList: common.GetPersistentVolumeListChannelWithOptions(client, dsQuery.ListOptions(), 1),
 	}
 
 	return GetPersistentVolumeListFromChannels(channels, dsQuery)
@@ -69,7 +69,9 @@ func GetPersistentVolumeListFromChannels(channels *common.ResourceChannels, dsQu
 		return nil, criticalError
 	}
 
-	return toPersistentVolumeList(persistentVolumes.Items, nonCriticalErrors, dsQuery), nil
+	result := toPersistentVolumeList(persistentVolumes.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(persistentVolumes.ListMeta)
+	return result, nil
 }
 
 func toPersistentVolumeList(persistentVolumes []v1.PersistentVolume, nonCriticalErrors []error,
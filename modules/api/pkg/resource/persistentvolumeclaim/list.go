@@ -53,7 +53,7 @@ func GetPersistentVolumeClaimList(client kubernetes.Interface, nsQuery *common.N
 
 	klog.V(4).Infof("Getting list persistent volumes claims")
 	channels := &common.ResourceChannels{
-		PersistentVolumeClaimList: common.GetPersistentVolumeClaimListChannel(client, nsQuery, 1),
+		PersistentVolumeClaimList: common.GetPersistentVolumeClaimListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 	}
 
 	return GetPersistentVolumeClaimListFromChannels(channels, nsQuery, dsQuery)
@@ -71,7 +71,9 @@ func GetPersistentVolumeClaimListFromChannels(channels *common.ResourceChannels,
 		return nil, criticalError
 	}
 
-	return toPersistentVolumeClaimList(persistentVolumeClaims.Items, nonCriticalErrors, dsQuery), nil
+	result := toPersistentVolumeClaimList(persistentVolumeClaims.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(persistentVolumeClaims.ListMeta)
+	return result, nil
 }
 
 func toPersistentVolumeClaim(pvc v1.PersistentVolumeClaim) PersistentVolumeClaim {
@@ -191,6 +191,8 @@ func (self PodCell) GetProperty(name dataselect.PropertyName) dataselect.Compara
 		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
 	case dataselect.NamespaceProperty:
 		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	case dataselect.RestartsProperty:
+		return dataselect.StdComparableInt(getRestartCount(v1.Pod(self)))
 	default:
 		// if name is not supported then just return a constant dummy value, sort will have no effect.
 		return nil
@@ -0,0 +1,195 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/errors"
+)
+
+// debugContainerStartTimeout is how long to wait for the debug container to start, including the image pull.
+const debugContainerStartTimeout = 2 * time.Minute
+
+// DebugContainerSpec is a specification of the ephemeral container used to debug a running pod.
+type DebugContainerSpec struct {
+	// Name of the container. Defaulted to a generated "debugger-" name.
+	Name string `json:"name,omitempty"`
+
+	// Image of the container. Defaulted to the image given by the --debug-image flag.
+	Image string `json:"image,omitempty"`
+
+	// Command of the container. Defaulted to the image entrypoint.
+	Command []string `json:"command,omitempty"`
+
+	// TargetContainerName is a container whose process namespace is shared with the debug container, so its
+	// processes can be inspected even when the pod does not share the process namespace.
+	TargetContainerName string `json:"targetContainerName,omitempty"`
+}
+
+// DebugContainer is the ephemeral container added to the pod.
+type DebugContainer struct {
+	Name                string `json:"name"`
+	Image               string `json:"image"`
+	TargetContainerName string `json:"targetContainerName,omitempty"`
+}
+
+// CreateDebugContainer adds an ephemeral container with an interactive terminal to the running pod. Ephemeral
+// containers cannot be removed, they stay in the pod until it is deleted.
+func CreateDebugContainer(client kubernetes.Interface, namespace, name string, spec *DebugContainerSpec) (
+	*DebugContainer, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return nil, errors.NewBadRequest(fmt.Sprintf("cannot debug %s pod in %s phase", name, pod.Status.Phase))
+	}
+
+	container, err := toEphemeralContainer(pod, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(args.LogLevelVerbose).Infof("Adding %s debug container to %s pod in %s namespace", container.Name, name,
+		namespace)
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, *container)
+	if _, err = client.CoreV1().Pods(namespace).UpdateEphemeralContainers(context.TODO(), name, pod,
+		metaV1.UpdateOptions{}); err != nil {
+		return nil, err
+	}
+
+	return &DebugContainer{
+		Name:                container.Name,
+		Image:               container.Image,
+		TargetContainerName: container.TargetContainerName,
+	}, nil
+}
+
+func toEphemeralContainer(pod *v1.Pod, spec *DebugContainerSpec) (*v1.EphemeralContainer, error) {
+	names := make(map[string]bool)
+	for _, container := range pod.Spec.InitContainers {
+		names[container.Name] = true
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		names[container.Name] = true
+	}
+
+	target := false
+	for _, container := range pod.Spec.Containers {
+		names[container.Name] = true
+		target = target || container.Name == spec.TargetContainerName
+	}
+
+	if len(spec.TargetContainerName) > 0 && !target {
+		return nil, errors.NewBadRequest(fmt.Sprintf("target container %s not found in %s pod",
+			spec.TargetContainerName, pod.Name))
+	}
+
+	name := spec.Name
+	if len(name) == 0 {
+		for name = genDebugContainerName(); names[name]; {
+			name = genDebugContainerName()
+		}
+	}
+
+	if msgs := validation.IsDNS1123Label(name); len(msgs) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid container name %s: %s", name, strings.Join(msgs, ", ")))
+	}
+
+	if names[name] {
+		return nil, errors.NewBadRequest(fmt.Sprintf("container %s already exists in %s pod", name, pod.Name))
+	}
+
+	image := spec.Image
+	if len(image) == 0 {
+		image = args.DebugImage()
+	}
+
+	return &v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			Command:                  spec.Command,
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+		},
+		TargetContainerName: spec.TargetContainerName,
+	}, nil
+}
+
+func genDebugContainerName() string {
+	return "debugger-" + utilrand.String(5)
+}
+
+// WaitForContainerRunning waits until the container of the pod is running. It fails if the container terminates or
+// does not start in time, i.e. because its image cannot be pulled.
+func WaitForContainerRunning(ctx context.Context, client kubernetes.Interface, namespace, name, container string) error {
+	var waitingReason string
+	err := wait.PollUntilContextTimeout(ctx, time.Second, debugContainerStartTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metaV1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+
+			status := findContainerStatus(pod, container)
+			switch {
+			case status == nil:
+				return false, nil
+			case status.State.Running != nil:
+				return true, nil
+			case status.State.Terminated != nil:
+				return false, fmt.Errorf("container %s terminated: %s", container, status.State.Terminated.Reason)
+			case status.State.Waiting != nil:
+				waitingReason = status.State.Waiting.Reason
+			}
+
+			return false, nil
+		})
+	if wait.Interrupted(err) && len(waitingReason) > 0 {
+		return fmt.Errorf("container %s did not start: %s", container, waitingReason)
+	}
+
+	return err
+}
+
+func findContainerStatus(pod *v1.Pod, container string) *v1.ContainerStatus {
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses,
+		pod.Status.EphemeralContainerStatuses} {
+		for i := range statuses {
+			if statuses[i].Name == container {
+				return &statuses[i]
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/dashboard/errors"
+)
+
+func newDebuggedPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "distroless", Namespace: "default"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "gcr.io/distroless/static"}}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestCreateDebugContainer(t *testing.T) {
+	client := fake.NewSimpleClientset(newDebuggedPod())
+
+	actual, err := CreateDebugContainer(client, "default", "distroless", &DebugContainerSpec{
+		Image:               "nicolaka/netshoot",
+		TargetContainerName: "app",
+	})
+	if err != nil {
+		t.Fatalf("CreateDebugContainer() returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(actual.Name, "debugger-") || actual.Image != "nicolaka/netshoot" ||
+		actual.TargetContainerName != "app" {
+		t.Errorf("CreateDebugContainer() == %#v, expected generated debugger container targeting app", actual)
+	}
+
+	pod, _ := client.CoreV1().Pods("default").Get(context.TODO(), "distroless", metaV1.GetOptions{})
+	expected := []v1.EphemeralContainer{{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     actual.Name,
+			Image:                    "nicolaka/netshoot",
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+		},
+		TargetContainerName: "app",
+	}}
+	if !reflect.DeepEqual(pod.Spec.EphemeralContainers, expected) {
+		t.Errorf("pod ephemeral containers == \n%#v\nexpected \n%#v", pod.Spec.EphemeralContainers, expected)
+	}
+}
+
+func TestCreateDebugContainerInvalidSpec(t *testing.T) {
+	cases := []*DebugContainerSpec{
+		{Name: "app"},
+		{Name: "Invalid_Name"},
+		{TargetContainerName: "sidecar"},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(newDebuggedPod())
+		if _, err := CreateDebugContainer(client, "default", "distroless", c); !errors.IsBadRequest(err) {
+			t.Errorf("CreateDebugContainer(%#v) should return bad request error, got %v", c, err)
+		}
+	}
+}
+
+func TestWaitForContainerRunning(t *testing.T) {
+	pod := newDebuggedPod()
+	pod.Status.EphemeralContainerStatuses = []v1.ContainerStatus{
+		{Name: "debugger", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+		{Name: "crashed", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error"}}},
+	}
+	client := fake.NewSimpleClientset(pod)
+
+	if err := WaitForContainerRunning(context.TODO(), client, "default", "distroless", "debugger"); err != nil {
+		t.Errorf("WaitForContainerRunning() of running container returned error: %v", err)
+	}
+
+	err := WaitForContainerRunning(context.TODO(), client, "default", "distroless", "crashed")
+	if err == nil || !strings.Contains(err.Error(), "terminated: Error") {
+		t.Errorf("WaitForContainerRunning() of terminated container should return error, got %v", err)
+	}
+}
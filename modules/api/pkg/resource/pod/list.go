@@ -16,7 +16,6 @@ package pod
 
 import (
 	v1 "k8s.io/api/core/v1"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -107,7 +106,7 @@ func GetPodList(client k8sClient.Interface, metricClient metricapi.MetricClient,
 	klog.V(4).Infof("Getting list of all pods in the cluster")
 
 	channels := &common.ResourceChannels{
-		PodList:   common.GetPodListChannelWithOptions(client, nsQuery, metaV1.ListOptions{}, 1),
+		PodList:   common.GetPodListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 		EventList: common.GetEventListChannel(client, nsQuery, 1),
 	}
 
@@ -134,6 +133,7 @@ func GetPodListFromChannels(channels *common.ResourceChannels, dsQuery *datasele
 	}
 
 	podList := ToPodList(pods.Items, eventList.Items, nonCriticalErrors, dsQuery, metricClient)
+	podList.ListMeta.SetChunk(pods.ListMeta)
 	podList.Status = getStatus(pods, eventList.Items)
 	return &podList, nil
 }
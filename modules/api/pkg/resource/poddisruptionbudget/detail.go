@@ -0,0 +1,70 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"context"
+
+	policy "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// PodDisruptionBudgetDetail provides the presentation layer view of Kubernetes pod disruption budget resource.
+type PodDisruptionBudgetDetail struct {
+	// Extends list item structure.
+	PodDisruptionBudget `json:",inline"`
+
+	// Selector of the pods covered by the budget.
+	Selector *metaV1.LabelSelector `json:"selector"`
+
+	// UnhealthyPodEvictionPolicy defines when unhealthy pods can be evicted.
+	UnhealthyPodEvictionPolicy *policy.UnhealthyPodEvictionPolicyType `json:"unhealthyPodEvictionPolicy,omitempty"`
+
+	// DisruptedPods contains pods that were evicted, but were not yet deleted, with the eviction time.
+	DisruptedPods map[string]metaV1.Time `json:"disruptedPods,omitempty"`
+
+	// Conditions of the budget, i.e. whether disruptions are allowed.
+	Conditions []metaV1.Condition `json:"conditions"`
+}
+
+// GetPodDisruptionBudgetDetail returns detailed information about a pod disruption budget.
+func GetPodDisruptionBudgetDetail(client k8sClient.Interface, namespace, name string) (
+	*PodDisruptionBudgetDetail, error) {
+	klog.V(4).Infof("Getting details of %s pod disruption budget in %s namespace", name, namespace)
+
+	pdb, err := client.PolicyV1().PodDisruptionBudgets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPodDisruptionBudgetDetail(pdb), nil
+}
+
+func toPodDisruptionBudgetDetail(pdb *policy.PodDisruptionBudget) *PodDisruptionBudgetDetail {
+	conditions := pdb.Status.Conditions
+	if conditions == nil {
+		conditions = []metaV1.Condition{}
+	}
+
+	return &PodDisruptionBudgetDetail{
+		PodDisruptionBudget:        toPodDisruptionBudget(pdb),
+		Selector:                   pdb.Spec.Selector,
+		UnhealthyPodEvictionPolicy: pdb.Spec.UnhealthyPodEvictionPolicy,
+		DisruptedPods:              pdb.Status.DisruptedPods,
+		Conditions:                 conditions,
+	}
+}
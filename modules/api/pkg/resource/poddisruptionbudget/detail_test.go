@@ -0,0 +1,74 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"reflect"
+	"testing"
+
+	policy "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/dashboard/types"
+)
+
+func TestGetPodDisruptionBudgetDetail(t *testing.T) {
+	maxUnavailable := intstr.FromString("25%")
+	alwaysAllow := policy.AlwaysAllow
+	selector := &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	conditions := []metaV1.Condition{{Type: policy.DisruptionAllowedCondition, Status: metaV1.ConditionFalse,
+		Reason: policy.InsufficientPodsReason}}
+
+	pdb := &policy.PodDisruptionBudget{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pdb", Namespace: "default"},
+		Spec: policy.PodDisruptionBudgetSpec{
+			MaxUnavailable:             &maxUnavailable,
+			Selector:                   selector,
+			UnhealthyPodEvictionPolicy: &alwaysAllow,
+		},
+		Status: policy.PodDisruptionBudgetStatus{
+			CurrentHealthy: 1,
+			DesiredHealthy: 2,
+			ExpectedPods:   2,
+			Conditions:     conditions,
+		},
+	}
+
+	client := fake.NewSimpleClientset(pdb)
+	actual, err := GetPodDisruptionBudgetDetail(client, "default", "pdb")
+	if err != nil {
+		t.Fatalf("GetPodDisruptionBudgetDetail() returned error: %v", err)
+	}
+
+	expected := &PodDisruptionBudgetDetail{
+		PodDisruptionBudget: PodDisruptionBudget{
+			ObjectMeta:     types.ObjectMeta{Name: "pdb", Namespace: "default"},
+			TypeMeta:       types.TypeMeta{Kind: types.ResourceKindPodDisruptionBudget},
+			MaxUnavailable: &maxUnavailable,
+			CurrentHealthy: 1,
+			DesiredHealthy: 2,
+			ExpectedPods:   2,
+		},
+		Selector:                   selector,
+		UnhealthyPodEvictionPolicy: &alwaysAllow,
+		Conditions:                 conditions,
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetPodDisruptionBudgetDetail(...) == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8sClient "k8s.io/client-go/kubernetes"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// PodDisruptionBudgetList contains a list of pod disruption budgets.
+type PodDisruptionBudgetList struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+
+	// Unordered list of pod disruption budgets.
+	Items []PodDisruptionBudget `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// PodDisruptionBudget is a presentation layer view of Kubernetes pod disruption budget resource.
+type PodDisruptionBudget struct {
+	ObjectMeta types.ObjectMeta `json:"objectMeta"`
+	TypeMeta   types.TypeMeta   `json:"typeMeta"`
+
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// DisruptionsAllowed is the number of pods that can be evicted at the moment.
+	DisruptionsAllowed int32 `json:"disruptionsAllowed"`
+
+	// CurrentHealthy is the number of healthy pods matched by the budget.
+	CurrentHealthy int32 `json:"currentHealthy"`
+
+	// DesiredHealthy is the minimum number of healthy pods required by the budget.
+	DesiredHealthy int32 `json:"desiredHealthy"`
+
+	// ExpectedPods is the number of pods matched by the budget.
+	ExpectedPods int32 `json:"expectedPods"`
+}
+
+// GetPodDisruptionBudgetList returns a list of pod disruption budgets in the namespace.
+func GetPodDisruptionBudgetList(client k8sClient.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*PodDisruptionBudgetList, error) {
+	channel := common.GetPodDisruptionBudgetListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1)
+	pdbList := <-channel.List
+	err := <-channel.Error
+
+	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	result := toPodDisruptionBudgetList(pdbList.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(pdbList.ListMeta)
+	return result, nil
+}
+
+func toPodDisruptionBudgetList(pdbs []policy.PodDisruptionBudget, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *PodDisruptionBudgetList {
+	result := &PodDisruptionBudgetList{
+		Items:    make([]PodDisruptionBudget, 0),
+		ListMeta: types.ListMeta{TotalItems: len(pdbs)},
+		Errors:   nonCriticalErrors,
+	}
+
+	pdbCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(pdbs), dsQuery)
+	pdbs = fromCells(pdbCells)
+	result.ListMeta = types.ListMeta{TotalItems: filteredTotal}
+
+	for _, pdb := range pdbs {
+		result.Items = append(result.Items, toPodDisruptionBudget(&pdb))
+	}
+
+	return result
+}
+
+func toPodDisruptionBudget(pdb *policy.PodDisruptionBudget) PodDisruptionBudget {
+	return PodDisruptionBudget{
+		ObjectMeta:         types.NewObjectMeta(pdb.ObjectMeta),
+		TypeMeta:           types.NewTypeMeta(types.ResourceKindPodDisruptionBudget),
+		MinAvailable:       pdb.Spec.MinAvailable,
+		MaxUnavailable:     pdb.Spec.MaxUnavailable,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		ExpectedPods:       pdb.Status.ExpectedPods,
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/types"
+)
+
+func TestGetPodDisruptionBudgetList(t *testing.T) {
+	minAvailable := intstr.FromInt32(2)
+	pdb := &policy.PodDisruptionBudget{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pdb", Namespace: "default"},
+		Spec:       policy.PodDisruptionBudgetSpec{MinAvailable: &minAvailable},
+		Status: policy.PodDisruptionBudgetStatus{
+			DisruptionsAllowed: 1,
+			CurrentHealthy:     3,
+			DesiredHealthy:     2,
+			ExpectedPods:       3,
+		},
+	}
+
+	client := fake.NewSimpleClientset(pdb)
+	actual, err := GetPodDisruptionBudgetList(client, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("GetPodDisruptionBudgetList() returned error: %v", err)
+	}
+
+	expected := &PodDisruptionBudgetList{
+		ListMeta: types.ListMeta{TotalItems: 1},
+		Items: []PodDisruptionBudget{{
+			ObjectMeta:         types.ObjectMeta{Name: "pdb", Namespace: "default"},
+			TypeMeta:           types.TypeMeta{Kind: types.ResourceKindPodDisruptionBudget},
+			MinAvailable:       &minAvailable,
+			DisruptionsAllowed: 1,
+			CurrentHealthy:     3,
+			DesiredHealthy:     2,
+			ExpectedPods:       3,
+		}},
+		Errors: []error{},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetPodDisruptionBudgetList(...) == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
+
+func TestMatchesPod(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Namespace: "default", Labels: map[string]string{"app": "web"}}}
+
+	cases := []struct {
+		info     string
+		pdb      *policy.PodDisruptionBudget
+		expected bool
+	}{
+		{
+			"nil selector matches no pods",
+			&policy.PodDisruptionBudget{ObjectMeta: metaV1.ObjectMeta{Namespace: "default"}},
+			false,
+		},
+		{
+			"empty selector matches all pods",
+			&policy.PodDisruptionBudget{
+				ObjectMeta: metaV1.ObjectMeta{Namespace: "default"},
+				Spec:       policy.PodDisruptionBudgetSpec{Selector: &metaV1.LabelSelector{}},
+			},
+			true,
+		},
+		{
+			"matching labels",
+			&policy.PodDisruptionBudget{
+				ObjectMeta: metaV1.ObjectMeta{Namespace: "default"},
+				Spec: policy.PodDisruptionBudgetSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				},
+			},
+			true,
+		},
+		{
+			"different namespace",
+			&policy.PodDisruptionBudget{
+				ObjectMeta: metaV1.ObjectMeta{Namespace: "other"},
+				Spec:       policy.PodDisruptionBudgetSpec{Selector: &metaV1.LabelSelector{}},
+			},
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		if actual := MatchesPod(c.pdb, pod); actual != c.expected {
+			t.Errorf("%s: MatchesPod(...) == %t, expected %t", c.info, actual, c.expected)
+		}
+	}
+}
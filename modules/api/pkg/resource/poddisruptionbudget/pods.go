@@ -0,0 +1,75 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"context"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8sClient "k8s.io/client-go/kubernetes"
+
+	metricapi "k8s.io/dashboard/api/pkg/integration/metric/api"
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/api/pkg/resource/event"
+	"k8s.io/dashboard/api/pkg/resource/pod"
+	"k8s.io/dashboard/errors"
+)
+
+// GetPodDisruptionBudgetPods returns a list of pods covered by the pod disruption budget.
+func GetPodDisruptionBudgetPods(client k8sClient.Interface, metricClient metricapi.MetricClient, namespace,
+	name string, dsQuery *dataselect.DataSelectQuery) (*pod.PodList, error) {
+	podList := pod.PodList{
+		Pods:              []pod.Pod{},
+		CumulativeMetrics: []metricapi.Metric{},
+	}
+
+	pdb, err := client.PolicyV1().PodDisruptionBudgets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return &podList, err
+	}
+
+	if pdb.Spec.Selector == nil {
+		return &podList, nil
+	}
+
+	labelSelector, err := selectorFor(pdb)
+	if err != nil {
+		return &podList, errors.NewBadRequest(err.Error())
+	}
+
+	channels := &common.ResourceChannels{
+		PodList: common.GetPodListChannelWithOptions(client, common.NewSameNamespaceQuery(namespace),
+			metaV1.ListOptions{
+				LabelSelector: labelSelector.String(),
+				FieldSelector: fields.Everything().String(),
+			}, 1),
+	}
+
+	apiPodList := <-channels.PodList.List
+	if err := <-channels.PodList.Error; err != nil {
+		return &podList, err
+	}
+
+	events, err := event.GetPodsEvents(client, namespace, apiPodList.Items)
+	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+	if criticalError != nil {
+		return &podList, criticalError
+	}
+
+	podList = pod.ToPodList(apiPodList.Items, events, nonCriticalErrors, dsQuery, metricClient)
+	return &podList, nil
+}
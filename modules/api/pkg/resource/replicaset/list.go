@@ -75,7 +75,7 @@ func GetReplicaSetList(client client.Interface, nsQuery *common.NamespaceQuery,
 	klog.V(4).Infof("Getting list of all replica sets in the cluster")
 
 	channels := &common.ResourceChannels{
-		ReplicaSetList: common.GetReplicaSetListChannel(client, nsQuery, 1),
+		ReplicaSetList: common.GetReplicaSetListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 		PodList:        common.GetPodListChannel(client, nsQuery, 1),
 		EventList:      common.GetEventListChannel(client, nsQuery, 1),
 	}
@@ -110,6 +110,7 @@ func GetReplicaSetListFromChannels(channels *common.ResourceChannels,
 	}
 
 	rsList := ToReplicaSetList(replicaSets.Items, pods.Items, events.Items, nonCriticalErrors, dsQuery, metricClient)
+	rsList.ListMeta.SetChunk(replicaSets.ListMeta)
 	rsList.Status = getStatus(replicaSets, pods.Items, events.Items)
 	return rsList, nil
 }
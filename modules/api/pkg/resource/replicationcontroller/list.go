@@ -64,7 +64,7 @@ func GetReplicationControllerList(client client.Interface, nsQuery *common.Names
 	klog.V(4).Infof("Getting list of all replication controllers in the cluster")
 
 	channels := &common.ResourceChannels{
-		ReplicationControllerList: common.GetReplicationControllerListChannel(client, nsQuery, 1),
+		ReplicationControllerList: common.GetReplicationControllerListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 		PodList:                   common.GetPodListChannel(client, nsQuery, 1),
 		EventList:                 common.GetEventListChannel(client, nsQuery, 1),
 	}
@@ -100,6 +100,7 @@ func GetReplicationControllerListFromChannels(channels *common.ResourceChannels,
 
 	rcs := toReplicationControllerList(rcList.Items, dsQuery, podList.Items, eventList.Items, nonCriticalErrors,
 		metricClient)
+	rcs.ListMeta.SetChunk(rcList.ListMeta)
 	rcs.Status = getStatus(rcList, podList.Items, eventList.Items)
 	return rcs, nil
 }
@@ -45,7 +45,7 @@ type Role struct {
 func GetRoleList(client kubernetes.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*RoleList, error) {
 	klog.V(4).Infof("Getting list of all roles in the cluster")
 	channels := &common.ResourceChannels{
-		RoleList: common.GetRoleListChannel(client, nsQuery, 1),
+		RoleList: common.GetRoleListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 	}
 
 	return GetRoleListFromChannels(channels, dsQuery)
@@ -61,6 +61,7 @@ func GetRoleListFromChannels(channels *common.ResourceChannels, dsQuery *datasel
 		return nil, criticalError
 	}
 	roleList := toRoleList(roles.Items, nonCriticalErrors, dsQuery)
+	roleList.ListMeta.SetChunk(roles.ListMeta)
 	return roleList, nil
 }
 
@@ -45,7 +45,7 @@ type RoleBinding struct {
 func GetRoleBindingList(client kubernetes.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*RoleBindingList, error) {
 	klog.V(4).Infof("Getting list of all roleBindings in the cluster")
 	channels := &common.ResourceChannels{
-		RoleBindingList: common.GetRoleBindingListChannel(client, nsQuery, 1),
+		RoleBindingList: common.GetRoleBindingListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 	}
 
 	return GetRoleBindingListFromChannels(channels, dsQuery)
@@ -61,6 +61,7 @@ func GetRoleBindingListFromChannels(channels *common.ResourceChannels, dsQuery *
 		return nil, criticalError
 	}
 	roleBindingList := toRoleBindingList(roleBindings.Items, nonCriticalErrors, dsQuery)
+	roleBindingList.ListMeta.SetChunk(roleBindings.ListMeta)
 	return roleBindingList, nil
 }
 
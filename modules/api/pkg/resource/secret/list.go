@@ -25,7 +25,6 @@ import (
 	"k8s.io/dashboard/api/pkg/resource/common"
 	"k8s.io/dashboard/api/pkg/resource/dataselect"
 	"k8s.io/dashboard/errors"
-	"k8s.io/dashboard/helpers"
 	"k8s.io/dashboard/types"
 )
 
@@ -88,14 +87,16 @@ type SecretList struct {
 func GetSecretList(client kubernetes.Interface, namespace *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*SecretList, error) {
 	klog.V(4).Infof("Getting list of secrets in %s namespace\n", namespace)
-	secretList, err := client.CoreV1().Secrets(namespace.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+	secretList, err := client.CoreV1().Secrets(namespace.ToRequestParam()).List(context.TODO(), dsQuery.ListOptions())
 
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return ToSecretList(secretList.Items, nonCriticalErrors, dsQuery), nil
+	result := ToSecretList(secretList.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(secretList.ListMeta)
+	return result, nil
 }
 
 // CreateSecret creates a single secret using the cluster API client
@@ -66,7 +66,7 @@ func GetServiceList(client client.Interface, nsQuery *common.NamespaceQuery,
 	klog.V(4).Infof("Getting list of all services in the cluster")
 
 	channels := &common.ResourceChannels{
-		ServiceList: common.GetServiceListChannel(client, nsQuery, 1),
+		ServiceList: common.GetServiceListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 	}
 
 	return GetServiceListFromChannels(channels, dsQuery)
@@ -82,7 +82,9 @@ func GetServiceListFromChannels(channels *common.ResourceChannels,
 		return nil, criticalError
 	}
 
-	return CreateServiceList(services.Items, nonCriticalErrors, dsQuery), nil
+	result := CreateServiceList(services.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(services.ListMeta)
+	return result, nil
 }
 
 func toService(service *v1.Service) Service {
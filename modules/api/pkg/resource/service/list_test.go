@@ -20,7 +20,9 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 
 	"k8s.io/dashboard/api/pkg/resource/endpoint"
 	"k8s.io/dashboard/api/pkg/resource/pod"
@@ -88,6 +90,69 @@ func TestGetServiceList(t *testing.T) {
 	}
 }
 
+func TestGetServiceListWithSelectors(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1", Labels: map[string]string{"app": "web"}}},
+		&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "svc-2", Namespace: "ns-1", Labels: map[string]string{"app": "db"}}},
+	)
+	dsQuery := dataselect.NewDataSelectQuery(dataselect.NoPagination, dataselect.NoSort, dataselect.NoFilter,
+		dataselect.NoMetrics)
+	dsQuery.SelectorQuery = &dataselect.SelectorQuery{LabelSelector: "app=web"}
+
+	actual, err := GetServiceList(fakeClient, common.NewNamespaceQuery(nil), dsQuery)
+	if err != nil {
+		t.Fatalf("GetServiceList(client) returned error: %v", err)
+	}
+
+	actions := fakeClient.Actions()
+	if len(actions) != 1 {
+		t.Fatalf("Unexpected actions: %v, expected 1 action", actions)
+	}
+
+	listAction, ok := actions[0].(clienttesting.ListAction)
+	if !ok {
+		t.Fatalf("Unexpected action: %+v, expected list", actions[0])
+	}
+
+	if selector := listAction.GetListRestrictions().Labels.String(); selector != "app=web" {
+		t.Errorf("Unexpected label selector %q, expected %q", selector, "app=web")
+	}
+
+	if len(actual.Services) != 1 || actual.Services[0].ObjectMeta.Name != "svc-1" {
+		t.Errorf("GetServiceList(client) == %#v, expected only svc-1", actual.Services)
+	}
+}
+
+func TestGetServiceListInChunks(t *testing.T) {
+	remainingItemCount := int64(5)
+	var options metaV1.ListOptions
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.PrependReactor("list", "services", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		options = action.(clienttesting.ListActionImpl).GetListOptions()
+		return true, &v1.ServiceList{
+			ListMeta: metaV1.ListMeta{Continue: "token-2", RemainingItemCount: &remainingItemCount},
+			Items:    []v1.Service{{ObjectMeta: metaV1.ObjectMeta{Name: "svc-2", Namespace: "ns-1"}}},
+		}, nil
+	})
+	dsQuery := dataselect.NewDataSelectQuery(dataselect.NoPagination, dataselect.NoSort, dataselect.NoFilter,
+		dataselect.NoMetrics)
+	dsQuery.ChunkQuery = &dataselect.ChunkQuery{Limit: 1, Continue: "token-1"}
+
+	actual, err := GetServiceList(fakeClient, common.NewNamespaceQuery(nil), dsQuery)
+	if err != nil {
+		t.Fatalf("GetServiceList(client) returned error: %v", err)
+	}
+
+	if options.Limit != 1 || options.Continue != "token-1" {
+		t.Errorf("Unexpected list options %+v, expected limit 1 and continue token-1", options)
+	}
+
+	expected := types.ListMeta{TotalItems: 1, Continue: "token-2", RemainingItemCount: &remainingItemCount}
+	if !reflect.DeepEqual(actual.ListMeta, expected) {
+		t.Errorf("GetServiceList(client).ListMeta == %+v, expected %+v", actual.ListMeta, expected)
+	}
+}
+
 func TestToServiceDetail(t *testing.T) {
 	cases := []struct {
 		service      *v1.Service
@@ -24,7 +24,6 @@ import (
 	"k8s.io/dashboard/api/pkg/resource/common"
 	"k8s.io/dashboard/api/pkg/resource/dataselect"
 	"k8s.io/dashboard/errors"
-	"k8s.io/dashboard/helpers"
 	"k8s.io/dashboard/types"
 )
 
@@ -45,14 +44,16 @@ type ServiceAccountList struct {
 func GetServiceAccountList(client client.Interface, namespace *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*ServiceAccountList, error) {
 	saList, err := client.CoreV1().ServiceAccounts(namespace.ToRequestParam()).List(context.TODO(),
-		helpers.ListEverything)
+		dsQuery.ListOptions())
 
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toServiceAccountList(saList.Items, nonCriticalErrors, dsQuery), nil
+	result := toServiceAccountList(saList.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(saList.ListMeta)
+	return result, nil
 }
 
 func toServiceAccount(sa *v1.ServiceAccount) ServiceAccount {
@@ -55,7 +55,7 @@ func GetStatefulSetList(client kubernetes.Interface, nsQuery *common.NamespaceQu
 	klog.V(4).Infof("Getting list of all stateful sets in the cluster")
 
 	channels := &common.ResourceChannels{
-		StatefulSetList: common.GetStatefulSetListChannel(client, nsQuery, 1),
+		StatefulSetList: common.GetStatefulSetListChannelWithOptions(client, nsQuery, dsQuery.ListOptions(), 1),
 		PodList:         common.GetPodListChannel(client, nsQuery, 1),
 		EventList:       common.GetEventListChannel(client, nsQuery, 1),
 	}
@@ -90,6 +90,7 @@ func GetStatefulSetListFromChannels(channels *common.ResourceChannels, dsQuery *
 	}
 
 	ssList := toStatefulSetList(statefulSets.Items, pods.Items, events.Items, nonCriticalErrors, dsQuery, metricClient)
+	ssList.ListMeta.SetChunk(statefulSets.ListMeta)
 	ssList.Status = getStatus(statefulSets, pods.Items, events.Items)
 	return ssList, nil
 }
@@ -48,7 +48,7 @@ func GetStorageClassList(client kubernetes.Interface, dsQuery *dataselect.DataSe
 	klog.V(4).Infof("Getting list of storage classes in the cluster")
 
 	channels := &common.ResourceChannels{
-		StorageClassList: common.GetStorageClassListChannel(client, 1),
+		StorageClassList: common.GetStorageClassListChannelWithOptions(client, dsQuery.ListOptions(), 1),
 	}
 
 	return GetStorageClassListFromChannels(channels, dsQuery)
@@ -64,7 +64,9 @@ func GetStorageClassListFromChannels(channels *common.ResourceChannels,
 		return nil, criticalError
 	}
 
-	return toStorageClassList(storageClasses.Items, nonCriticalErrors, dsQuery), nil
+	result := toStorageClassList(storageClasses.Items, nonCriticalErrors, dsQuery)
+	result.ListMeta.SetChunk(storageClasses.ListMeta)
+	return result, nil
 }
 
 func toStorageClassList(storageClasses []storage.StorageClass, nonCriticalErrors []error,
@@ -0,0 +1,169 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminal
+
+import (
+	"errors"
+	"io"
+	"net/url"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// Shells are tried in this order when the terminal is opened without a command.
+var Shells = []string{"bash", "sh", "powershell", "cmd"}
+
+// Exit codes used by container runtimes to report that the command could not be executed or was not found.
+const (
+	exitCodeCannotExecute = 126
+	exitCodeNotFound      = 127
+)
+
+// newExecutor is replaced in tests.
+var newExecutor = NewExecutor
+
+// Options describes the process the stream is connected to.
+type Options struct {
+	Namespace string
+	Pod       string
+	Container string
+	// Command is executed in the container. When it is empty, the process of the container is attached.
+	Command []string
+	// Stdin connects the stdin channel to the process.
+	Stdin bool
+	// TTY allocates the terminal. Stderr is merged into stdout and the terminal can be resized.
+	TTY bool
+}
+
+// Exec runs the command in the container and streams it until it exits or the client disconnects. The exit code of
+// the command is returned as utilexec.ExitError.
+func Exec(client kubernetes.Interface, cfg *rest.Config, opts Options, stream *Stream) error {
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(opts.Pod).
+		Namespace(opts.Namespace).
+		SubResource("exec")
+
+	req.VersionedParams(&v1.PodExecOptions{
+		Container: opts.Container,
+		Command:   opts.Command,
+		Stdin:     opts.Stdin,
+		Stdout:    true,
+		Stderr:    !opts.TTY,
+		TTY:       opts.TTY,
+	}, scheme.ParameterCodec)
+
+	return run(cfg, req.URL(), opts, stream)
+}
+
+// ExecShell runs the shell in the container. When no shell is given, it tries Shells until one of them starts. Shells
+// that cannot be executed or are not found in the container are skipped.
+func ExecShell(client kubernetes.Interface, cfg *rest.Config, opts Options, shell string, stream *Stream) error {
+	shells := Shells
+	if contains(Shells, shell) {
+		shells = []string{shell}
+	}
+
+	var err error
+	for _, shell := range shells {
+		opts.Command = []string{shell}
+		err = Exec(client, cfg, opts, stream)
+
+		if err == nil || stream.Context().Err() != nil {
+			return err
+		}
+
+		// Shell that exited on its own was found, so there is no reason to try other ones.
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && !isShellNotFound(exitErr) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// isShellNotFound returns true when runtime reported that the shell is missing, i.e. containerd and runc report
+// "command terminated with exit code 126".
+func isShellNotFound(err utilexec.ExitError) bool {
+	return err.ExitStatus() == exitCodeCannotExecute || err.ExitStatus() == exitCodeNotFound
+}
+
+// Attach connects the stream to the process of the container, which has to be started with stdin and TTY enabled.
+func Attach(client kubernetes.Interface, cfg *rest.Config, opts Options, stream *Stream) error {
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(opts.Pod).
+		Namespace(opts.Namespace).
+		SubResource("attach")
+
+	req.VersionedParams(&v1.PodAttachOptions{
+		Container: opts.Container,
+		Stdin:     opts.Stdin,
+		Stdout:    true,
+		Stderr:    !opts.TTY,
+		TTY:       opts.TTY,
+	}, scheme.ParameterCodec)
+
+	return run(cfg, req.URL(), opts, stream)
+}
+
+// NewExecutor creates executor of the exec or attach request. It uses the WebSocket protocol and falls back to SPDY
+// for API servers that do not support it, the same way as kubectl.
+func NewExecutor(cfg *rest.Config, location *url.URL) (remotecommand.Executor, error) {
+	websocketExec, err := remotecommand.NewWebSocketExecutor(cfg, "GET", location.String())
+	if err != nil {
+		return nil, err
+	}
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(cfg, "POST", location)
+	if err != nil {
+		return nil, err
+	}
+
+	return remotecommand.NewFallbackExecutor(websocketExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+func run(cfg *rest.Config, location *url.URL, opts Options, stream *Stream) error {
+	exec, err := newExecutor(cfg, location)
+	if err != nil {
+		return err
+	}
+
+	streamOpts := remotecommand.StreamOptions{Stdout: stream.Stdout(), Tty: opts.TTY}
+	if opts.Stdin {
+		streamOpts.Stdin = stream.Stdin()
+	}
+	if opts.TTY {
+		streamOpts.TerminalSizeQueue = stream
+	} else {
+		streamOpts.Stderr = stream.Stderr()
+	}
+
+	return exec.StreamWithContext(stream.Context(), streamOpts)
+}
+
+// Message writes the message for the user to the terminal, the same way kubectl prints its hints.
+func Message(stream *Stream, message string) {
+	_, _ = io.WriteString(stream.Stdout(), message+"\r\n")
+}
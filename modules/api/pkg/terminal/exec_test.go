@@ -0,0 +1,151 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// fakeExecutor records executed commands and returns results by the command name.
+type fakeExecutor struct {
+	command  string
+	results  map[string]error
+	executed *[]string
+}
+
+func (self *fakeExecutor) Stream(options remotecommand.StreamOptions) error {
+	return self.StreamWithContext(context.Background(), options)
+}
+
+func (self *fakeExecutor) StreamWithContext(_ context.Context, _ remotecommand.StreamOptions) error {
+	*self.executed = append(*self.executed, self.command)
+	if err, exists := self.results[self.command]; exists {
+		return err
+	}
+
+	return fmt.Errorf("unexpected command %s", self.command)
+}
+
+func exitError(code int) error {
+	return utilexec.CodeExitError{Err: fmt.Errorf("command terminated with exit code %d", code), Code: code}
+}
+
+func TestExecShell(t *testing.T) {
+	cases := []struct {
+		name             string
+		shell            string
+		results          map[string]error
+		cancelled        bool
+		expectedExecuted []string
+		expectedErr      error
+	}{
+		{
+			"should start first shell",
+			"",
+			map[string]error{"bash": nil},
+			false,
+			[]string{"bash"},
+			nil,
+		},
+		{
+			"should skip shell that cannot be executed",
+			"",
+			map[string]error{"bash": exitError(126), "sh": nil},
+			false,
+			[]string{"bash", "sh"},
+			nil,
+		},
+		{
+			"should skip shell that is not found",
+			"",
+			map[string]error{"bash": exitError(127), "sh": exitError(2)},
+			false,
+			[]string{"bash", "sh"},
+			exitError(2),
+		},
+		{
+			"should skip shell that failed to start",
+			"",
+			map[string]error{"bash": fmt.Errorf("failed to start"), "sh": nil},
+			false,
+			[]string{"bash", "sh"},
+			nil,
+		},
+		{
+			"should stop on shell that exited on its own",
+			"",
+			map[string]error{"bash": exitError(1)},
+			false,
+			[]string{"bash"},
+			exitError(1),
+		},
+		{
+			"should not try other shells when shell is given",
+			"sh",
+			map[string]error{"sh": exitError(127)},
+			false,
+			[]string{"sh"},
+			exitError(127),
+		},
+		{
+			"should stop when client has gone away",
+			"",
+			map[string]error{"bash": exitError(126)},
+			true,
+			[]string{"bash"},
+			exitError(126),
+		},
+	}
+
+	defer func(original func(*rest.Config, *url.URL) (remotecommand.Executor, error)) {
+		newExecutor = original
+	}(newExecutor)
+
+	cfg := &rest.Config{Host: "https://localhost"}
+	client := kubernetes.NewForConfigOrDie(cfg)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			executed := make([]string, 0)
+			newExecutor = func(_ *rest.Config, location *url.URL) (remotecommand.Executor, error) {
+				return &fakeExecutor{command: location.Query().Get("command"), results: c.results, executed: &executed}, nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if c.cancelled {
+				cancel()
+			}
+
+			err := ExecShell(client, cfg, Options{Namespace: "default", Pod: "pod", TTY: true}, c.shell,
+				&Stream{ctx: ctx})
+			if !reflect.DeepEqual(err, c.expectedErr) {
+				t.Errorf("ExecShell() returned error %v, expected %v", err, c.expectedErr)
+			}
+
+			if !reflect.DeepEqual(executed, c.expectedExecuted) {
+				t.Errorf("ExecShell() executed %v, expected %v", executed, c.expectedExecuted)
+			}
+		})
+	}
+}
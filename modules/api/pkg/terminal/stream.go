@@ -0,0 +1,251 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package terminal connects terminal sessions in containers to WebSocket clients using the Kubernetes v5 streaming
+// protocol, the same one that the API server uses for exec and attach.
+package terminal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/args"
+	"k8s.io/dashboard/api/pkg/recording"
+)
+
+// ProtocolV5 is the name of the v5 streaming subprotocol. Each binary message starts with the channel number followed
+// by the channel data. Compared to v4, it adds the close channel used to half-close stdin.
+const ProtocolV5 = "v5.channel.k8s.io"
+
+const (
+	StdinChannel  byte = 0
+	StdoutChannel byte = 1
+	StderrChannel byte = 2
+	// ErrorChannel carries the metaV1.Status of the finished process, including its exit code.
+	ErrorChannel byte = 3
+	// ResizeChannel carries the JSON encoded remotecommand.TerminalSize.
+	ResizeChannel byte = 4
+	// CloseChannel carries the number of the channel closed by the sender.
+	CloseChannel byte = 255
+)
+
+const (
+	// writeTimeout is the time after which the client that does not read the messages is disconnected.
+	writeTimeout = 10 * time.Second
+
+	// nonZeroExitCodeReason and exitCodeCauseType are used by the API server to report the exit code.
+	nonZeroExitCodeReason = metaV1.StatusReason("NonZeroExitCode")
+	exitCodeCauseType     = metaV1.CauseType("ExitCode")
+)
+
+// Stream implements the v5 streaming protocol over the WebSocket connection. It provides stdin, stdout, stderr and
+// the terminal size queue of the process, so it can be passed to remotecommand.StreamOptions.
+type Stream struct {
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+	recorder  *recording.Recorder
+
+	// stdinEnabled is false when stdin is not connected to the process. Stdin data is dropped then, as nobody reads
+	// the pipe.
+	stdinEnabled bool
+	stdin        *io.PipeReader
+	stdinWriter  *io.PipeWriter
+	sizes        chan remotecommand.TerminalSize
+
+	// ctx is cancelled when the client closes the connection.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewStream starts reading messages of the client. Stdin data is passed to the process only when stdin is enabled.
+// Recorder, which can be nil, records the whole session.
+func NewStream(conn *websocket.Conn, stdinEnabled bool, recorder *recording.Recorder) *Stream {
+	stdin, stdinWriter := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &Stream{
+		conn:         conn,
+		recorder:     recorder,
+		stdinEnabled: stdinEnabled,
+		stdin:        stdin,
+		stdinWriter:  stdinWriter,
+		sizes:        make(chan remotecommand.TerminalSize, 1),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+
+	go stream.readLoop()
+	return stream
+}
+
+// Context is cancelled when the client closes the connection, which stops the process streaming.
+func (self *Stream) Context() context.Context {
+	return self.ctx
+}
+
+// Stdin returns data sent by the client to the stdin channel. It returns EOF once the client closes the channel.
+func (self *Stream) Stdin() io.Reader {
+	return self.stdin
+}
+
+// Stdout returns writer sending data to the stdout channel.
+func (self *Stream) Stdout() io.Writer {
+	return channelWriter{stream: self, channel: StdoutChannel}
+}
+
+// Stderr returns writer sending data to the stderr channel.
+func (self *Stream) Stderr() io.Writer {
+	return channelWriter{stream: self, channel: StderrChannel}
+}
+
+// Next returns the next terminal size requested by the client. It returns nil once the connection is closed.
+func (self *Stream) Next() *remotecommand.TerminalSize {
+	size, ok := <-self.sizes
+	if !ok {
+		return nil
+	}
+
+	return &size
+}
+
+// Finish sends the status of the finished process to the client and closes the connection. Stdin is closed too, so
+// the stdin data that nobody reads anymore does not block the reading of the client messages.
+func (self *Stream) Finish(err error) {
+	_ = self.stdin.CloseWithError(io.ErrClosedPipe)
+	self.recorder.Close()
+	data, marshalErr := json.Marshal(StatusForError(err))
+	if marshalErr == nil {
+		marshalErr = self.write(ErrorChannel, data)
+	}
+	if marshalErr != nil {
+		klog.V(args.LogLevelVerbose).InfoS("Could not send terminal status", "error", marshalErr)
+	}
+
+	self.writeLock.Lock()
+	defer self.writeLock.Unlock()
+	_ = self.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeTimeout))
+	_ = self.conn.Close()
+}
+
+// StatusForError converts the result of the process to the status sent over the error channel. The exit code is sent
+// in the same way as the API server does.
+func StatusForError(err error) metaV1.Status {
+	if err == nil {
+		return metaV1.Status{Status: metaV1.StatusSuccess}
+	}
+
+	status := metaV1.Status{Status: metaV1.StatusFailure, Message: err.Error()}
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		status.Reason = nonZeroExitCodeReason
+		status.Details = &metaV1.StatusDetails{
+			Causes: []metaV1.StatusCause{{Type: exitCodeCauseType, Message: strconv.Itoa(exitErr.ExitStatus())}},
+		}
+	}
+
+	return status
+}
+
+func (self *Stream) readLoop() {
+	defer func() {
+		_ = self.stdinWriter.Close()
+		close(self.sizes)
+		self.cancel()
+	}()
+
+	for {
+		messageType, data, err := self.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if messageType != websocket.BinaryMessage || len(data) == 0 {
+			continue
+		}
+
+		switch data[0] {
+		case StdinChannel:
+			if !self.stdinEnabled {
+				continue
+			}
+			self.recorder.Input(string(data[1:]))
+			if _, err := self.stdinWriter.Write(data[1:]); err != nil {
+				return
+			}
+		case ResizeChannel:
+			size := remotecommand.TerminalSize{}
+			if err := json.Unmarshal(data[1:], &size); err != nil {
+				klog.V(args.LogLevelVerbose).InfoS("Invalid terminal size", "error", err)
+				continue
+			}
+			self.recorder.Resize(size.Width, size.Height)
+			self.resize(size)
+		case CloseChannel:
+			if len(data) > 1 && data[1] == StdinChannel {
+				_ = self.stdinWriter.Close()
+			}
+		}
+	}
+}
+
+// resize queues the terminal size. Only the latest size matters, so it replaces the one that was not used yet.
+func (self *Stream) resize(size remotecommand.TerminalSize) {
+	for {
+		select {
+		case self.sizes <- size:
+			return
+		default:
+		}
+
+		select {
+		case <-self.sizes:
+		default:
+		}
+	}
+}
+
+func (self *Stream) write(channel byte, data []byte) error {
+	self.writeLock.Lock()
+	defer self.writeLock.Unlock()
+	if err := self.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	return self.conn.WriteMessage(websocket.BinaryMessage, append([]byte{channel}, data...))
+}
+
+type channelWriter struct {
+	stream  *Stream
+	channel byte
+}
+
+func (self channelWriter) Write(p []byte) (int, error) {
+	if err := self.stream.write(self.channel, p); err != nil {
+		return 0, err
+	}
+
+	self.stream.recorder.Output(p)
+	return len(p), nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"k8s.io/dashboard/errors"
+)
+
+func TestStream(t *testing.T) {
+	type result struct {
+		stdin string
+		size  *remotecommand.TerminalSize
+	}
+	results := make(chan result, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := AuthenticateRequest(r); err != nil {
+			t.Errorf("AuthenticateRequest() returned error: %v", err)
+			return
+		}
+
+		stream, err := Upgrade(w, r, true, nil)
+		if err != nil {
+			t.Errorf("Upgrade() returned error: %v", err)
+			return
+		}
+
+		size := stream.Next()
+		stdin, _ := io.ReadAll(stream.Stdin())
+		_, _ = stream.Stdout().Write([]byte("out"))
+		_, _ = stream.Stderr().Write([]byte("err"))
+		stream.Finish(utilexec.CodeExitError{Err: fmt.Errorf("command terminated with exit code 3"), Code: 3})
+		results <- result{stdin: string(stdin), size: size}
+	}))
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{ProtocolV5}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial() returned error: %v", err)
+	}
+	defer conn.Close()
+
+	if conn.Subprotocol() != ProtocolV5 {
+		t.Errorf("negotiated subprotocol == %s, expected %s", conn.Subprotocol(), ProtocolV5)
+	}
+
+	for _, message := range [][]byte{
+		append([]byte{ResizeChannel}, `{"Width":80,"Height":24}`...),
+		append([]byte{StdinChannel}, "ls\n"...),
+		{CloseChannel, StdinChannel},
+	} {
+		if err := conn.WriteMessage(websocket.BinaryMessage, message); err != nil {
+			t.Fatalf("WriteMessage() returned error: %v", err)
+		}
+	}
+
+	var received [][]byte
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		received = append(received, data)
+	}
+
+	actual := <-results
+	expected := result{stdin: "ls\n", size: &remotecommand.TerminalSize{Width: 80, Height: 24}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("stream received \n%#v\nexpected \n%#v", actual, expected)
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("client received %d messages, expected 3", len(received))
+	}
+
+	if string(received[0]) != "\x01out" || string(received[1]) != "\x02err" || received[2][0] != ErrorChannel {
+		t.Errorf("client received unexpected messages %q", received)
+	}
+
+	status := metaV1.Status{}
+	if err := json.Unmarshal(received[2][1:], &status); err != nil {
+		t.Fatalf("status could not be unmarshalled: %v", err)
+	}
+
+	if status.Reason != nonZeroExitCodeReason || status.Details.Causes[0].Message != "3" {
+		t.Errorf("client received status %#v, expected exit code 3", status)
+	}
+}
+
+func TestStreamWithoutStdin(t *testing.T) {
+	sizes := make(chan *remotecommand.TerminalSize, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stream, err := Upgrade(w, r, false, nil)
+		if err != nil {
+			t.Errorf("Upgrade() returned error: %v", err)
+			return
+		}
+
+		sizes <- stream.Next()
+		stream.Finish(nil)
+	}))
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{ProtocolV5}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial() returned error: %v", err)
+	}
+	defer conn.Close()
+
+	for _, message := range [][]byte{
+		append([]byte{StdinChannel}, "ls\n"...),
+		append([]byte{ResizeChannel}, `{"Width":80,"Height":24}`...),
+	} {
+		if err := conn.WriteMessage(websocket.BinaryMessage, message); err != nil {
+			t.Fatalf("WriteMessage() returned error: %v", err)
+		}
+	}
+
+	select {
+	case size := <-sizes:
+		expected := &remotecommand.TerminalSize{Width: 80, Height: 24}
+		if !reflect.DeepEqual(size, expected) {
+			t.Errorf("stream received size %#v, expected %#v", size, expected)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream should drop stdin data when stdin is not enabled")
+	}
+}
+
+func TestAuthenticateRequest(t *testing.T) {
+	token := base64.RawURLEncoding.EncodeToString([]byte("token"))
+	cases := []struct {
+		info          string
+		protocols     string
+		upgrade       bool
+		expectedError bool
+		expectedAuth  string
+	}{
+		{"plain HTTP request", ProtocolV5, false, true, ""},
+		{"missing streaming protocol", bearerProtocolPrefix + token, true, true, ""},
+		{"invalid token encoding", ProtocolV5 + ", " + bearerProtocolPrefix + "!", true, true, ""},
+		{"token in subprotocol", ProtocolV5 + ", " + bearerProtocolPrefix + token, true, false, "Bearer token"},
+		{"token in header", ProtocolV5, true, false, "Bearer header"},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Sec-Websocket-Protocol", c.protocols)
+		if c.upgrade {
+			request.Header.Set("Connection", "Upgrade")
+			request.Header.Set("Upgrade", "websocket")
+		}
+		if c.expectedAuth == "Bearer header" {
+			request.Header.Set("Authorization", "Bearer header")
+		}
+
+		err := AuthenticateRequest(request)
+		if c.expectedError != errors.IsBadRequest(err) {
+			t.Errorf("AuthenticateRequest() of %s returned %v", c.info, err)
+		}
+
+		if auth := request.Header.Get("Authorization"); auth != c.expectedAuth {
+			t.Errorf("AuthenticateRequest() of %s set authorization header %q, expected %q", c.info, auth,
+				c.expectedAuth)
+		}
+	}
+}
+
+func TestIsSameOrigin(t *testing.T) {
+	cases := []struct {
+		origin        string
+		host          string
+		forwardedHost string
+		expected      bool
+	}{
+		{"", "example.com", "", true},
+		{"https://example.com", "example.com", "", true},
+		{"https://EXAMPLE.com", "example.com", "", true},
+		{"https://evil.com", "example.com", "", false},
+		{"https://example.com.evil.com", "example.com", "", false},
+		{"://example.com", "example.com", "", false},
+		// Proxied by Kong to the API service
+		{"https://dashboard.example.com", "kubernetes-dashboard-api:8000", "dashboard.example.com", true},
+		{"https://dashboard.example.com:8443", "kubernetes-dashboard-api:8000", "dashboard.example.com:8443", true},
+		{"https://dashboard.example.com", "kubernetes-dashboard-api:8000", "dashboard.example.com, proxy.local", true},
+		{"https://evil.com", "kubernetes-dashboard-api:8000", "dashboard.example.com", false},
+		{"https://dashboard.example.com", "kubernetes-dashboard-api:8000", "", false},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Host = c.host
+		request.Header.Set("Origin", c.origin)
+		if len(c.forwardedHost) > 0 {
+			request.Header.Set(forwardedHostHeader, c.forwardedHost)
+		}
+
+		if actual := isSameOrigin(request); actual != c.expected {
+			t.Errorf("isSameOrigin() of %q with host %q and forwarded host %q == %v, expected %v", c.origin, c.host,
+				c.forwardedHost, actual, c.expected)
+		}
+	}
+}
+
+func TestStatusForError(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected metaV1.Status
+	}{
+		{nil, metaV1.Status{Status: metaV1.StatusSuccess}},
+		{
+			fmt.Errorf("container not found"),
+			metaV1.Status{Status: metaV1.StatusFailure, Message: "container not found"},
+		},
+		{
+			utilexec.CodeExitError{Err: fmt.Errorf("command terminated with exit code 127"), Code: 127},
+			metaV1.Status{
+				Status:  metaV1.StatusFailure,
+				Message: "command terminated with exit code 127",
+				Reason:  nonZeroExitCodeReason,
+				Details: &metaV1.StatusDetails{Causes: []metaV1.StatusCause{{Type: exitCodeCauseType, Message: "127"}}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual := StatusForError(c.err)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("StatusForError(%v) == \n%#v\nexpected \n%#v", c.err, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminal
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+
+	"k8s.io/dashboard/api/pkg/recording"
+	"k8s.io/dashboard/client"
+	"k8s.io/dashboard/errors"
+)
+
+// bearerProtocolPrefix is the prefix of the subprotocol carrying the base64url encoded bearer token. Browsers do not
+// allow setting headers of WebSocket requests, so the API server accepts the token in this form too.
+const bearerProtocolPrefix = "base64url.bearer.authorization.k8s.io."
+
+// upgrader checks the origin, so other sites cannot open terminals on behalf of the user.
+var upgrader = websocket.Upgrader{Subprotocols: []string{ProtocolV5}, CheckOrigin: isSameOrigin}
+
+// AuthenticateRequest validates the WebSocket handshake and moves the bearer token from the subprotocol to the
+// Authorization header, so the request can be used to create the Kubernetes client. Every terminal is bound to the
+// connection opened with the token, there is no session ID that could be used by anyone else.
+func AuthenticateRequest(request *http.Request) error {
+	if !websocket.IsWebSocketUpgrade(request) {
+		return errors.NewBadRequest("terminal requires WebSocket connection")
+	}
+
+	// Origin is checked before the upgrade too, as some terminals, i.e. the node shell, create pods before it
+	if !isSameOrigin(request) {
+		return errors.NewForbidden("terminal", fmt.Errorf("origin %s is not allowed", request.Header.Get("Origin")))
+	}
+
+	protocols := websocket.Subprotocols(request)
+	if !contains(protocols, ProtocolV5) {
+		return errors.NewBadRequest("terminal requires " + ProtocolV5 + " subprotocol")
+	}
+
+	for _, protocol := range protocols {
+		if !strings.HasPrefix(protocol, bearerProtocolPrefix) {
+			continue
+		}
+
+		token, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(protocol, bearerProtocolPrefix))
+		if err != nil {
+			return errors.NewBadRequest("invalid bearer token subprotocol")
+		}
+
+		client.SetAuthorizationHeader(request, string(token))
+	}
+
+	return nil
+}
+
+// Upgrade upgrades the authenticated request to the WebSocket connection and starts the stream.
+func Upgrade(w http.ResponseWriter, request *http.Request, stdinEnabled bool, recorder *recording.Recorder) (*Stream,
+	error) {
+	conn, err := upgrader.Upgrade(w, request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStream(conn, stdinEnabled, recorder), nil
+}
+
+// forwardedHostHeader is set by reverse proxies, i.e. Kong shipped with the chart, to the host requested by the client.
+// Browsers do not allow setting it, so it cannot be used by other sites to pass the check.
+const forwardedHostHeader = "X-Forwarded-Host"
+
+// isSameOrigin returns true when the Origin header is not set, i.e. by non-browser clients, or matches the host. The
+// host requested by the client is taken from the X-Forwarded-Host header if the request went through a proxy.
+func isSameOrigin(request *http.Request) bool {
+	origin := request.Header.Get("Origin")
+	if len(origin) == 0 {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || len(u.Host) == 0 {
+		return false
+	}
+
+	if strings.EqualFold(u.Host, request.Host) {
+		return true
+	}
+
+	// The first proxy adds the host requested by the client, following ones may append their own
+	forwardedHost := strings.TrimSpace(strings.Split(request.Header.Get(forwardedHostHeader), ",")[0])
+	return len(forwardedHost) > 0 && strings.EqualFold(u.Host, forwardedHost)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"k8s.io/dashboard/types"
+)
+
+// EventType describes how an item of the watched list page has changed.
+type EventType string
+
+const (
+	// EventTypeAdded is used when item appeared on the list page.
+	EventTypeAdded EventType = "ADDED"
+	// EventTypeModified is used when item stayed on the list page but its content has changed.
+	EventTypeModified EventType = "MODIFIED"
+	// EventTypeDeleted is used when item disappeared from the list page.
+	EventTypeDeleted EventType = "DELETED"
+)
+
+// Event is a single change of the watched list page.
+type Event struct {
+	Type EventType    `json:"type"`
+	UID  k8stypes.UID `json:"uid"`
+	// Object is the current state of the item. It is not set for deleted items.
+	Object json.RawMessage `json:"object,omitempty"`
+}
+
+// Delta is a set of changes between two consecutive states of the watched list page.
+type Delta struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+	// Order contains UIDs of all items on the current list page in the order selected by the data select query.
+	Order  []k8stypes.UID `json:"order"`
+	Events []Event        `json:"events"`
+
+	orderChanged    bool
+	listMetaChanged bool
+}
+
+// IsEmpty returns true if applying the delta would not change the list page.
+func (self *Delta) IsEmpty() bool {
+	return len(self.Events) == 0 && !self.orderChanged && !self.listMetaChanged
+}
+
+// Snapshot is the serialized state of the list page.
+type Snapshot struct {
+	listMeta types.ListMeta
+	order    []k8stypes.UID
+	objects  map[k8stypes.UID]json.RawMessage
+}
+
+// NewSnapshot serializes given list page items.
+func NewSnapshot(listMeta types.ListMeta, items []Item) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		listMeta: listMeta,
+		order:    make([]k8stypes.UID, 0, len(items)),
+		objects:  make(map[k8stypes.UID]json.RawMessage, len(items)),
+	}
+
+	for _, item := range items {
+		raw, err := json.Marshal(item.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.order = append(snapshot.order, item.UID)
+		snapshot.objects[item.UID] = raw
+	}
+
+	return snapshot, nil
+}
+
+// Diff returns changes needed to get from the previous snapshot to the current one. Previous snapshot
+// can be nil, in which case all current items are reported as added.
+func Diff(previous, current *Snapshot) *Delta {
+	if previous == nil {
+		previous = &Snapshot{listMeta: types.ListMeta{TotalItems: -1}}
+	}
+
+	delta := &Delta{
+		ListMeta:        current.listMeta,
+		Order:           current.order,
+		Events:          make([]Event, 0),
+		orderChanged:    !slices.Equal(previous.order, current.order),
+		listMetaChanged: previous.listMeta != current.listMeta,
+	}
+
+	for _, uid := range current.order {
+		object := current.objects[uid]
+		old, exists := previous.objects[uid]
+		switch {
+		case !exists:
+			delta.Events = append(delta.Events, Event{Type: EventTypeAdded, UID: uid, Object: object})
+		case !bytes.Equal(old, object):
+			delta.Events = append(delta.Events, Event{Type: EventTypeModified, UID: uid, Object: object})
+		}
+	}
+
+	for _, uid := range previous.order {
+		if _, exists := current.objects[uid]; !exists {
+			delta.Events = append(delta.Events, Event{Type: EventTypeDeleted, UID: uid})
+		}
+	}
+
+	return delta
+}
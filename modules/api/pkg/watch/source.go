@@ -0,0 +1,447 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"context"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachinerywatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/configmap"
+	"k8s.io/dashboard/api/pkg/resource/cronjob"
+	"k8s.io/dashboard/api/pkg/resource/daemonset"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/api/pkg/resource/deployment"
+	"k8s.io/dashboard/api/pkg/resource/event"
+	"k8s.io/dashboard/api/pkg/resource/ingress"
+	"k8s.io/dashboard/api/pkg/resource/job"
+	"k8s.io/dashboard/api/pkg/resource/namespace"
+	"k8s.io/dashboard/api/pkg/resource/persistentvolumeclaim"
+	"k8s.io/dashboard/api/pkg/resource/pod"
+	"k8s.io/dashboard/api/pkg/resource/replicaset"
+	"k8s.io/dashboard/api/pkg/resource/secret"
+	"k8s.io/dashboard/api/pkg/resource/service"
+	"k8s.io/dashboard/api/pkg/resource/statefulset"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// selectFunc builds a list page from the resource channels using the same functions as regular list endpoints.
+type selectFunc func(channels *common.ResourceChannels, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error)
+
+// source describes how a list of given kind is built. First entry of kinds is the kind of the list itself,
+// remaining entries are resources required to compute additional list information, i.e. pod status.
+type source struct {
+	kinds      []types.ResourceKind
+	selectFunc selectFunc
+}
+
+// sources maps supported list kinds to their sources.
+var sources = map[types.ResourceKind]source{
+	types.ResourceKindPod: {
+		kinds: []types.ResourceKind{types.ResourceKindPod, types.ResourceKindEvent},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := pod.GetPodListFromChannels(channels, dsQuery, nil)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Pods))
+			for i, p := range list.Pods {
+				items[i] = Item{UID: p.ObjectMeta.UID, Object: p}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindDeployment: {
+		kinds: []types.ResourceKind{types.ResourceKindDeployment, types.ResourceKindPod, types.ResourceKindEvent,
+			types.ResourceKindReplicaSet},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := deployment.GetDeploymentListFromChannels(channels, dsQuery, nil)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Deployments))
+			for i, d := range list.Deployments {
+				items[i] = Item{UID: d.ObjectMeta.UID, Object: d}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindReplicaSet: {
+		kinds: []types.ResourceKind{types.ResourceKindReplicaSet, types.ResourceKindPod, types.ResourceKindEvent},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := replicaset.GetReplicaSetListFromChannels(channels, dsQuery, nil)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.ReplicaSets))
+			for i, rs := range list.ReplicaSets {
+				items[i] = Item{UID: rs.ObjectMeta.UID, Object: rs}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindStatefulSet: {
+		kinds: []types.ResourceKind{types.ResourceKindStatefulSet, types.ResourceKindPod, types.ResourceKindEvent},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := statefulset.GetStatefulSetListFromChannels(channels, dsQuery, nil)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.StatefulSets))
+			for i, ss := range list.StatefulSets {
+				items[i] = Item{UID: ss.ObjectMeta.UID, Object: ss}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindDaemonSet: {
+		kinds: []types.ResourceKind{types.ResourceKindDaemonSet, types.ResourceKindPod, types.ResourceKindEvent},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := daemonset.GetDaemonSetListFromChannels(channels, dsQuery, nil)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.DaemonSets))
+			for i, ds := range list.DaemonSets {
+				items[i] = Item{UID: ds.ObjectMeta.UID, Object: ds}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindJob: {
+		kinds: []types.ResourceKind{types.ResourceKindJob, types.ResourceKindPod, types.ResourceKindEvent},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := job.GetJobListFromChannels(channels, dsQuery, nil)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Jobs))
+			for i, j := range list.Jobs {
+				items[i] = Item{UID: j.ObjectMeta.UID, Object: j}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindCronJob: {
+		kinds: []types.ResourceKind{types.ResourceKindCronJob},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := cronjob.GetCronJobListFromChannels(channels, dsQuery)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Items))
+			for i, cj := range list.Items {
+				items[i] = Item{UID: cj.ObjectMeta.UID, Object: cj}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindService: {
+		kinds: []types.ResourceKind{types.ResourceKindService},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := service.GetServiceListFromChannels(channels, dsQuery)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Services))
+			for i, s := range list.Services {
+				items[i] = Item{UID: s.ObjectMeta.UID, Object: s}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindIngress: {
+		kinds: []types.ResourceKind{types.ResourceKindIngress},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			ingresses := <-channels.IngressList.List
+			err := <-channels.IngressList.Error
+			nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+			if criticalError != nil {
+				return types.ListMeta{}, nil, criticalError
+			}
+
+			list := ingress.ToIngressList(ingresses.Items, nonCriticalErrors, dsQuery)
+			items := make([]Item, len(list.Items))
+			for i, ing := range list.Items {
+				items[i] = Item{UID: ing.ObjectMeta.UID, Object: ing}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindConfigMap: {
+		kinds: []types.ResourceKind{types.ResourceKindConfigMap},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := configmap.GetConfigMapListFromChannels(channels, dsQuery)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Items))
+			for i, cm := range list.Items {
+				items[i] = Item{UID: cm.ObjectMeta.UID, Object: cm}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindSecret: {
+		kinds: []types.ResourceKind{types.ResourceKindSecret},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			secrets := <-channels.SecretList.List
+			err := <-channels.SecretList.Error
+			nonCriticalErrors, criticalError := errors.ExtractErrors(err)
+			if criticalError != nil {
+				return types.ListMeta{}, nil, criticalError
+			}
+
+			list := secret.ToSecretList(secrets.Items, nonCriticalErrors, dsQuery)
+			items := make([]Item, len(list.Secrets))
+			for i, s := range list.Secrets {
+				items[i] = Item{UID: s.ObjectMeta.UID, Object: s}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindPersistentVolumeClaim: {
+		kinds: []types.ResourceKind{types.ResourceKindPersistentVolumeClaim},
+		selectFunc: func(channels *common.ResourceChannels, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := persistentvolumeclaim.GetPersistentVolumeClaimListFromChannels(channels, nsQuery, dsQuery)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Items))
+			for i, pvc := range list.Items {
+				items[i] = Item{UID: pvc.ObjectMeta.UID, Object: pvc}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindEvent: {
+		kinds: []types.ResourceKind{types.ResourceKindEvent},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := event.GetEventListFromChannels(channels, dsQuery)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Events))
+			for i, e := range list.Events {
+				items[i] = Item{UID: e.ObjectMeta.UID, Object: e}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+	types.ResourceKindNamespace: {
+		kinds: []types.ResourceKind{types.ResourceKindNamespace},
+		selectFunc: func(channels *common.ResourceChannels, _ *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (types.ListMeta, []Item, error) {
+			list, err := namespace.GetNamespaceListFromChannels(channels, dsQuery)
+			if err != nil {
+				return types.ListMeta{}, nil, err
+			}
+
+			items := make([]Item, len(list.Namespaces))
+			for i, ns := range list.Namespaces {
+				items[i] = Item{UID: ns.ObjectMeta.UID, Object: ns}
+			}
+			return list.ListMeta, items, nil
+		},
+	},
+}
+
+// newListWatch returns a ListerWatcher and an example object of the given kind in the given namespace.
+func newListWatch(ctx context.Context, client kubernetes.Interface, kind types.ResourceKind, namespace string) (
+	cache.ListerWatcher, runtime.Object) {
+	switch kind {
+	case types.ResourceKindPod:
+		c := client.CoreV1().Pods(namespace)
+		return listWatch(ctx, c.List, c.Watch), &v1.Pod{}
+	case types.ResourceKindEvent:
+		c := client.CoreV1().Events(namespace)
+		return listWatch(ctx, c.List, c.Watch), &v1.Event{}
+	case types.ResourceKindService:
+		c := client.CoreV1().Services(namespace)
+		return listWatch(ctx, c.List, c.Watch), &v1.Service{}
+	case types.ResourceKindConfigMap:
+		c := client.CoreV1().ConfigMaps(namespace)
+		return listWatch(ctx, c.List, c.Watch), &v1.ConfigMap{}
+	case types.ResourceKindSecret:
+		c := client.CoreV1().Secrets(namespace)
+		return listWatch(ctx, c.List, c.Watch), &v1.Secret{}
+	case types.ResourceKindPersistentVolumeClaim:
+		c := client.CoreV1().PersistentVolumeClaims(namespace)
+		return listWatch(ctx, c.List, c.Watch), &v1.PersistentVolumeClaim{}
+	case types.ResourceKindNamespace:
+		c := client.CoreV1().Namespaces()
+		return listWatch(ctx, c.List, c.Watch), &v1.Namespace{}
+	case types.ResourceKindDeployment:
+		c := client.AppsV1().Deployments(namespace)
+		return listWatch(ctx, c.List, c.Watch), &apps.Deployment{}
+	case types.ResourceKindReplicaSet:
+		c := client.AppsV1().ReplicaSets(namespace)
+		return listWatch(ctx, c.List, c.Watch), &apps.ReplicaSet{}
+	case types.ResourceKindStatefulSet:
+		c := client.AppsV1().StatefulSets(namespace)
+		return listWatch(ctx, c.List, c.Watch), &apps.StatefulSet{}
+	case types.ResourceKindDaemonSet:
+		c := client.AppsV1().DaemonSets(namespace)
+		return listWatch(ctx, c.List, c.Watch), &apps.DaemonSet{}
+	case types.ResourceKindJob:
+		c := client.BatchV1().Jobs(namespace)
+		return listWatch(ctx, c.List, c.Watch), &batch.Job{}
+	case types.ResourceKindCronJob:
+		c := client.BatchV1().CronJobs(namespace)
+		return listWatch(ctx, c.List, c.Watch), &batch.CronJob{}
+	case types.ResourceKindIngress:
+		c := client.NetworkingV1().Ingresses(namespace)
+		return listWatch(ctx, c.List, c.Watch), &networkingv1.Ingress{}
+	}
+
+	return nil, nil
+}
+
+func listWatch[T runtime.Object](ctx context.Context,
+	list func(context.Context, metaV1.ListOptions) (T, error),
+	watch func(context.Context, metaV1.ListOptions) (apimachinerywatch.Interface, error)) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+			return list(ctx, options)
+		},
+		WatchFunc: func(options metaV1.ListOptions) (apimachinerywatch.Interface, error) {
+			return watch(ctx, options)
+		},
+	}
+}
+
+// withSelectors returns a ListerWatcher that lists and watches only objects matching label and field selectors
+// of the given options.
+func withSelectors(lw cache.ListerWatcher, selectors metaV1.ListOptions) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selectors.LabelSelector
+			options.FieldSelector = selectors.FieldSelector
+			return lw.List(options)
+		},
+		WatchFunc: func(options metaV1.ListOptions) (apimachinerywatch.Interface, error) {
+			options.LabelSelector = selectors.LabelSelector
+			options.FieldSelector = selectors.FieldSelector
+			return lw.Watch(options)
+		},
+	}
+}
+
+// toChannels fills resource channels of given kind with objects from the local store. Every channel can be read once.
+func toChannels(channels *common.ResourceChannels, kind types.ResourceKind, objects []interface{},
+	nsQuery *common.NamespaceQuery) {
+	switch kind {
+	case types.ResourceKindPod:
+		channels.PodList = common.PodListChannel{List: make(chan *v1.PodList, 1), Error: make(chan error, 1)}
+		channels.PodList.List <- &v1.PodList{Items: fromStore[v1.Pod](objects, nsQuery)}
+		channels.PodList.Error <- nil
+	case types.ResourceKindEvent:
+		channels.EventList = common.EventListChannel{List: make(chan *v1.EventList, 1), Error: make(chan error, 1)}
+		channels.EventList.List <- &v1.EventList{Items: fromStore[v1.Event](objects, nsQuery)}
+		channels.EventList.Error <- nil
+	case types.ResourceKindService:
+		channels.ServiceList = common.ServiceListChannel{List: make(chan *v1.ServiceList, 1), Error: make(chan error, 1)}
+		channels.ServiceList.List <- &v1.ServiceList{Items: fromStore[v1.Service](objects, nsQuery)}
+		channels.ServiceList.Error <- nil
+	case types.ResourceKindConfigMap:
+		channels.ConfigMapList = common.ConfigMapListChannel{List: make(chan *v1.ConfigMapList, 1), Error: make(chan error, 1)}
+		channels.ConfigMapList.List <- &v1.ConfigMapList{Items: fromStore[v1.ConfigMap](objects, nsQuery)}
+		channels.ConfigMapList.Error <- nil
+	case types.ResourceKindSecret:
+		channels.SecretList = common.SecretListChannel{List: make(chan *v1.SecretList, 1), Error: make(chan error, 1)}
+		channels.SecretList.List <- &v1.SecretList{Items: fromStore[v1.Secret](objects, nsQuery)}
+		channels.SecretList.Error <- nil
+	case types.ResourceKindPersistentVolumeClaim:
+		channels.PersistentVolumeClaimList = common.PersistentVolumeClaimListChannel{
+			List: make(chan *v1.PersistentVolumeClaimList, 1), Error: make(chan error, 1)}
+		channels.PersistentVolumeClaimList.List <- &v1.PersistentVolumeClaimList{
+			Items: fromStore[v1.PersistentVolumeClaim](objects, nsQuery)}
+		channels.PersistentVolumeClaimList.Error <- nil
+	case types.ResourceKindNamespace:
+		channels.NamespaceList = common.NamespaceListChannel{List: make(chan *v1.NamespaceList, 1), Error: make(chan error, 1)}
+		channels.NamespaceList.List <- &v1.NamespaceList{Items: fromStore[v1.Namespace](objects, nsQuery)}
+		channels.NamespaceList.Error <- nil
+	case types.ResourceKindDeployment:
+		channels.DeploymentList = common.DeploymentListChannel{List: make(chan *apps.DeploymentList, 1), Error: make(chan error, 1)}
+		channels.DeploymentList.List <- &apps.DeploymentList{Items: fromStore[apps.Deployment](objects, nsQuery)}
+		channels.DeploymentList.Error <- nil
+	case types.ResourceKindReplicaSet:
+		channels.ReplicaSetList = common.ReplicaSetListChannel{List: make(chan *apps.ReplicaSetList, 1), Error: make(chan error, 1)}
+		channels.ReplicaSetList.List <- &apps.ReplicaSetList{Items: fromStore[apps.ReplicaSet](objects, nsQuery)}
+		channels.ReplicaSetList.Error <- nil
+	case types.ResourceKindStatefulSet:
+		channels.StatefulSetList = common.StatefulSetListChannel{List: make(chan *apps.StatefulSetList, 1), Error: make(chan error, 1)}
+		channels.StatefulSetList.List <- &apps.StatefulSetList{Items: fromStore[apps.StatefulSet](objects, nsQuery)}
+		channels.StatefulSetList.Error <- nil
+	case types.ResourceKindDaemonSet:
+		channels.DaemonSetList = common.DaemonSetListChannel{List: make(chan *apps.DaemonSetList, 1), Error: make(chan error, 1)}
+		channels.DaemonSetList.List <- &apps.DaemonSetList{Items: fromStore[apps.DaemonSet](objects, nsQuery)}
+		channels.DaemonSetList.Error <- nil
+	case types.ResourceKindJob:
+		channels.JobList = common.JobListChannel{List: make(chan *batch.JobList, 1), Error: make(chan error, 1)}
+		channels.JobList.List <- &batch.JobList{Items: fromStore[batch.Job](objects, nsQuery)}
+		channels.JobList.Error <- nil
+	case types.ResourceKindCronJob:
+		channels.CronJobList = common.CronJobListChannel{List: make(chan *batch.CronJobList, 1), Error: make(chan error, 1)}
+		channels.CronJobList.List <- &batch.CronJobList{Items: fromStore[batch.CronJob](objects, nsQuery)}
+		channels.CronJobList.Error <- nil
+	case types.ResourceKindIngress:
+		channels.IngressList = common.IngressListChannel{List: make(chan *networkingv1.IngressList, 1), Error: make(chan error, 1)}
+		channels.IngressList.List <- &networkingv1.IngressList{Items: fromStore[networkingv1.Ingress](objects, nsQuery)}
+		channels.IngressList.Error <- nil
+	}
+}
+
+// fromStore converts objects kept in the informer store to a list of given type matching the namespace query.
+func fromStore[T any](objects []interface{}, nsQuery *common.NamespaceQuery) []T {
+	result := make([]T, 0, len(objects))
+	for _, obj := range objects {
+		item, ok := obj.(*T)
+		if !ok {
+			continue
+		}
+
+		accessor, err := meta.Accessor(obj)
+		if err != nil || !nsQuery.Matches(accessor.GetNamespace()) {
+			continue
+		}
+
+		result = append(result, *item)
+	}
+
+	return result
+}
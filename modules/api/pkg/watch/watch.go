@@ -0,0 +1,220 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+// DefaultDebounce is the time that watcher waits after a change before recomputing the list. It groups
+// bursts of changes (i.e. a rollout) into a single delta.
+const DefaultDebounce = time.Second
+
+// Item is a single element of a watched list page.
+type Item struct {
+	UID    k8stypes.UID
+	Object interface{}
+}
+
+// Watcher keeps a list page of given kind, namespace and data select query up to date and sends deltas
+// between consecutive states of the page.
+type Watcher struct {
+	client    kubernetes.Interface
+	kind      types.ResourceKind
+	source    source
+	nsQuery   *common.NamespaceQuery
+	dsQuery   *dataselect.DataSelectQuery
+	debounce  time.Duration
+	informers map[types.ResourceKind]cache.SharedIndexInformer
+	changed   chan struct{}
+	failed    chan error
+	previous  *Snapshot
+}
+
+// IsSupported returns true if list of given kind can be watched.
+func IsSupported(kind types.ResourceKind) bool {
+	_, ok := sources[kind]
+	return ok
+}
+
+// NewWatcher creates watcher for list of given kind. Only kinds for which IsSupported returns true can be watched.
+func NewWatcher(client kubernetes.Interface, kind types.ResourceKind, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*Watcher, error) {
+	s, ok := sources[kind]
+	if !ok {
+		return nil, errors.NewBadRequest(fmt.Sprintf("watching %s list is not supported", kind))
+	}
+
+	return &Watcher{
+		kind:      kind,
+		source:    s,
+		nsQuery:   nsQuery,
+		dsQuery:   dsQuery,
+		debounce:  DefaultDebounce,
+		informers: make(map[types.ResourceKind]cache.SharedIndexInformer),
+		changed:   make(chan struct{}, 1),
+		failed:    make(chan error, 1),
+		client:    client,
+	}, nil
+}
+
+// Run starts informers, sends the initial state of the list page and then a delta after every change
+// until the context is done. The deltas channel is closed when Run returns.
+func (self *Watcher) Run(ctx context.Context, deltas chan<- *Delta) error {
+	defer close(deltas)
+
+	namespace := self.nsQuery.ToRequestParam()
+	synced := make([]cache.InformerSynced, 0, len(self.source.kinds))
+	for _, kind := range self.source.kinds {
+		lw, example := newListWatch(ctx, self.client, kind, namespace)
+		if kind == self.kind {
+			lw = withSelectors(lw, self.dsQuery.ListOptions())
+		}
+
+		informer := cache.NewSharedIndexInformer(lw, example, 0, cache.Indexers{})
+		if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { self.notify() },
+			UpdateFunc: func(interface{}, interface{}) { self.notify() },
+			DeleteFunc: func(interface{}) { self.notify() },
+		}); err != nil {
+			return err
+		}
+
+		// Reflector retries failed requests indefinitely. Errors that will not go away without user action
+		// are passed to the client instead.
+		if err := informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+			if errors.IsUnauthorized(err) || errors.IsForbidden(err) {
+				self.fail(err)
+			}
+		}); err != nil {
+			return err
+		}
+
+		self.informers[kind] = informer
+		synced = append(synced, informer.HasSynced)
+		go informer.Run(ctx.Done())
+	}
+
+	if !cache.WaitForCacheSync(self.stopCh(ctx), synced...) {
+		select {
+		case err := <-self.failed:
+			return err
+		default:
+			return nil
+		}
+	}
+
+	// Initial state is sent as a delta against an empty page.
+	self.notify()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-self.failed:
+			return err
+		case <-self.changed:
+		}
+
+		delta, err := self.next()
+		if err != nil {
+			return err
+		}
+
+		if delta != nil {
+			select {
+			case deltas <- delta:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(self.debounce):
+		}
+	}
+}
+
+// notify marks the list as changed. Multiple notifications are merged into one.
+func (self *Watcher) notify() {
+	select {
+	case self.changed <- struct{}{}:
+	default:
+	}
+}
+
+// fail stops the watcher with given error. Only the first error is kept.
+func (self *Watcher) fail(err error) {
+	select {
+	case self.failed <- err:
+	default:
+	}
+}
+
+// stopCh returns a channel that is closed when either the context is done or the watcher has failed.
+// Failure error is put back, so it can be read again by the caller.
+func (self *Watcher) stopCh(ctx context.Context) <-chan struct{} {
+	stop := make(chan struct{})
+	go func() {
+		defer close(stop)
+		select {
+		case <-ctx.Done():
+		case err := <-self.failed:
+			self.fail(err)
+		}
+	}()
+
+	return stop
+}
+
+// next recomputes the list page and returns delta against the previous one or nil if nothing has changed.
+func (self *Watcher) next() (*Delta, error) {
+	channels := new(common.ResourceChannels)
+	for kind, informer := range self.informers {
+		toChannels(channels, kind, informer.GetStore().List(), self.nsQuery)
+	}
+
+	listMeta, items, err := self.source.selectFunc(channels, self.nsQuery, self.dsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := NewSnapshot(listMeta, items)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := Diff(self.previous, current)
+	self.previous = current
+	if delta.IsEmpty() {
+		klog.V(4).InfoS("Watched list has not changed", "kind", self.kind)
+		return nil, nil
+	}
+
+	return delta, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/dashboard/api/pkg/resource/common"
+	"k8s.io/dashboard/api/pkg/resource/dataselect"
+	"k8s.io/dashboard/types"
+)
+
+func snapshot(t *testing.T, items ...Item) *Snapshot {
+	result, err := NewSnapshot(types.ListMeta{TotalItems: len(items)}, items)
+	if err != nil {
+		t.Fatalf("NewSnapshot() returned error: %v", err)
+	}
+	return result
+}
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		info     string
+		previous []Item
+		current  []Item
+		expected []Event
+		empty    bool
+	}{
+		{
+			"initial state should report all items as added",
+			nil,
+			[]Item{{UID: "a", Object: "a"}},
+			[]Event{{Type: EventTypeAdded, UID: "a", Object: []byte(`"a"`)}},
+			false,
+		},
+		{
+			"unchanged list should produce empty delta",
+			[]Item{{UID: "a", Object: "a"}},
+			[]Item{{UID: "a", Object: "a"}},
+			[]Event{},
+			true,
+		},
+		{
+			"changed, added and removed items should be reported",
+			[]Item{{UID: "a", Object: "a"}, {UID: "b", Object: "b"}},
+			[]Item{{UID: "a", Object: "a2"}, {UID: "c", Object: "c"}},
+			[]Event{
+				{Type: EventTypeModified, UID: "a", Object: []byte(`"a2"`)},
+				{Type: EventTypeAdded, UID: "c", Object: []byte(`"c"`)},
+				{Type: EventTypeDeleted, UID: "b"},
+			},
+			false,
+		},
+		{
+			"reordered list should produce delta without events",
+			[]Item{{UID: "a", Object: "a"}, {UID: "b", Object: "b"}},
+			[]Item{{UID: "b", Object: "b"}, {UID: "a", Object: "a"}},
+			[]Event{},
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		var previous *Snapshot
+		if c.previous != nil {
+			previous = snapshot(t, c.previous...)
+		}
+
+		actual := Diff(previous, snapshot(t, c.current...))
+		if len(actual.Events) != len(c.expected) {
+			t.Fatalf("%s: Diff() returned %d events, expected %d", c.info, len(actual.Events), len(c.expected))
+		}
+
+		for i := range c.expected {
+			if actual.Events[i].Type != c.expected[i].Type || actual.Events[i].UID != c.expected[i].UID ||
+				string(actual.Events[i].Object) != string(c.expected[i].Object) {
+				t.Errorf("%s: Diff() event %d == %#v, expected %#v", c.info, i, actual.Events[i], c.expected[i])
+			}
+		}
+
+		if actual.IsEmpty() != c.empty {
+			t.Errorf("%s: IsEmpty() == %t, expected %t", c.info, actual.IsEmpty(), c.empty)
+		}
+	}
+}
+
+func TestNewWatcherUnsupportedKind(t *testing.T) {
+	_, err := NewWatcher(fake.NewSimpleClientset(), types.ResourceKindNode, common.NewNamespaceQuery(nil),
+		dataselect.NoDataSelect)
+	if err == nil {
+		t.Error("NewWatcher() should return error for unsupported kind")
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "foo", Namespace: "default", UID: "foo"},
+	})
+
+	dsQuery := dataselect.NewDataSelectQuery(dataselect.NoPagination, dataselect.NewSortQuery([]string{"a", "name"}),
+		dataselect.NoFilter, dataselect.NoMetrics)
+	watcher, err := NewWatcher(client, types.ResourceKindConfigMap, common.NewNamespaceQuery([]string{"default"}),
+		dsQuery)
+	if err != nil {
+		t.Fatalf("NewWatcher() returned error: %v", err)
+	}
+	watcher.debounce = 0
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	deltas := make(chan *Delta)
+	go func() {
+		_ = watcher.Run(ctx, deltas)
+	}()
+
+	expectDelta := func(expected []k8stypes.UID, eventType EventType) {
+		select {
+		case delta := <-deltas:
+			if !reflect.DeepEqual(delta.Order, expected) {
+				t.Fatalf("delta.Order == %v, expected %v", delta.Order, expected)
+			}
+			if len(delta.Events) != 1 || delta.Events[0].Type != eventType {
+				t.Fatalf("delta.Events == %#v, expected single %s event", delta.Events, eventType)
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for delta")
+		}
+	}
+
+	expectDelta([]k8stypes.UID{"foo"}, EventTypeAdded)
+
+	_, err = client.CoreV1().ConfigMaps("default").Create(ctx, &v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "bar", Namespace: "default", UID: "bar"},
+	}, metaV1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+
+	expectDelta([]k8stypes.UID{"bar", "foo"}, EventTypeAdded)
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/errors"
+)
+
+// DefaultFieldManager is the field manager name used for server-side apply when none was provided.
+const DefaultFieldManager = "kubernetes-dashboard"
+
+// conflictManagerRegexp extracts the manager name from the field manager conflict message,
+// i.e. `conflict with "kubectl-client-side-apply" using apps/v1`.
+var conflictManagerRegexp = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// ApplyOptions configures server-side apply.
+type ApplyOptions struct {
+	// FieldManager is the name of the actor that owns the applied fields.
+	FieldManager string
+	// Force takes the ownership of conflicting fields from other field managers.
+	Force bool
+	// DryRun processes the request without persisting the object.
+	DryRun bool
+}
+
+// ApplyConflict describes a field that is owned by a different field manager.
+type ApplyConflict struct {
+	// Manager is the name of the field manager that owns the field.
+	Manager string `json:"manager"`
+	// Field is the path of the conflicting field, i.e. `.spec.replicas`.
+	Field string `json:"field"`
+	// Message is the conflict message returned by the API server.
+	Message string `json:"message"`
+}
+
+// ApplyResult is a result of the server-side apply.
+type ApplyResult struct {
+	// Object is the applied object as returned by the API server. In dry-run mode it is the object that would be
+	// persisted. It is empty when the apply failed due to conflicts.
+	Object *unstructured.Unstructured `json:"object,omitempty"`
+	// Conflicts are fields owned by other field managers that prevented the apply.
+	Conflicts []ApplyConflict `json:"conflicts,omitempty"`
+}
+
+// NewApplyOptions creates apply options. Empty field manager is replaced by the DefaultFieldManager and
+// dry-run accepts only empty value or `All`, the same as the API server.
+func NewApplyOptions(fieldManager string, force bool, dryRun string) (*ApplyOptions, error) {
+	if len(fieldManager) == 0 {
+		fieldManager = DefaultFieldManager
+	}
+
+	if len(dryRun) > 0 && dryRun != metav1.DryRunAll {
+		return nil, errors.NewBadRequest(fmt.Sprintf("unsupported dryRun value %q, only %q is allowed",
+			dryRun, metav1.DryRunAll))
+	}
+
+	return &ApplyOptions{FieldManager: fieldManager, Force: force, DryRun: len(dryRun) > 0}, nil
+}
+
+// Apply applies the object using server-side apply. When the apply is rejected due to conflicts, they are returned
+// as a part of the result instead of the error. In dry-run mode the apply is then repeated with force, so
+// the result contains the object that would be persisted if the conflicts were overridden.
+func Apply(ctx context.Context, resource dynamic.ResourceInterface, object *unstructured.Unstructured,
+	opts ApplyOptions) (*ApplyResult, error) {
+	// Server-side apply rejects objects with managed fields.
+	object.SetManagedFields(nil)
+
+	applyOptions := metav1.ApplyOptions{FieldManager: opts.FieldManager, Force: opts.Force}
+	if opts.DryRun {
+		applyOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	klog.V(2).InfoS("applying resource", "kind", object.GetKind(), "namespace", object.GetNamespace(),
+		"name", object.GetName(), "fieldManager", opts.FieldManager, "force", opts.Force, "dryRun", opts.DryRun)
+	applied, err := resource.Apply(ctx, object.GetName(), object, applyOptions)
+	if err == nil {
+		return &ApplyResult{Object: applied}, nil
+	}
+
+	conflicts := ApplyConflicts(err)
+	if len(conflicts) == 0 {
+		return nil, err
+	}
+
+	result := &ApplyResult{Conflicts: conflicts}
+	if opts.DryRun && !opts.Force {
+		applyOptions.Force = true
+		if result.Object, err = resource.Apply(ctx, object.GetName(), object, applyOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ApplyConflicts returns field manager conflicts from the apply error. It returns nil if the error was not caused by
+// conflicts.
+func ApplyConflicts(err error) []ApplyConflict {
+	status, ok := err.(k8serrors.APIStatus)
+	if !ok || !k8serrors.IsConflict(err) || status.Status().Details == nil {
+		return nil
+	}
+
+	var conflicts []ApplyConflict
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		conflict := ApplyConflict{Field: cause.Field, Message: cause.Message}
+		if match := conflictManagerRegexp.FindStringSubmatch(cause.Message); match != nil {
+			conflict.Manager = match[1]
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts
+}
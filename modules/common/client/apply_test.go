@@ -0,0 +1,255 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+var configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+type fakeApplyResult struct {
+	object *unstructured.Unstructured
+	err    error
+}
+
+// fakeResource records options of the requests, which are not recorded by the fake dynamic client. Apply returns
+// given results, other requests are handled by the fake dynamic client.
+type fakeResource struct {
+	dynamic.ResourceInterface
+	applyResults []fakeApplyResult
+
+	applied []metav1.ApplyOptions
+	created []metav1.CreateOptions
+	updated []metav1.UpdateOptions
+}
+
+func newFakeResource(applyResults []fakeApplyResult, objects ...runtime.Object) *fakeResource {
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	return &fakeResource{
+		ResourceInterface: client.Resource(configMapsGVR).Namespace("default"),
+		applyResults:      applyResults,
+	}
+}
+
+func (in *fakeResource) Apply(_ context.Context, _ string, obj *unstructured.Unstructured, opts metav1.ApplyOptions,
+	_ ...string) (*unstructured.Unstructured, error) {
+	if len(obj.GetManagedFields()) > 0 {
+		return nil, k8serrors.NewBadRequest("metadata.managedFields must be nil")
+	}
+
+	if len(in.applied) >= len(in.applyResults) {
+		return nil, fmt.Errorf("unexpected apply %#v", opts)
+	}
+
+	result := in.applyResults[len(in.applied)]
+	in.applied = append(in.applied, opts)
+	return result.object, result.err
+}
+
+func (in *fakeResource) Create(ctx context.Context, obj *unstructured.Unstructured, opts metav1.CreateOptions,
+	subresources ...string) (*unstructured.Unstructured, error) {
+	in.created = append(in.created, opts)
+	return in.ResourceInterface.Create(ctx, obj, opts, subresources...)
+}
+
+func (in *fakeResource) Update(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions,
+	subresources ...string) (*unstructured.Unstructured, error) {
+	in.updated = append(in.updated, opts)
+	return in.ResourceInterface.Update(ctx, obj, opts, subresources...)
+}
+
+func newConfigMap(name string, data map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"data":       data,
+	}}
+}
+
+func newApplyConflict(causes ...metav1.StatusCause) error {
+	return k8serrors.NewApplyConflict(causes, "Apply failed with conflicts")
+}
+
+var replicasConflict = metav1.StatusCause{
+	Type:    metav1.CauseTypeFieldManagerConflict,
+	Message: `conflict with "kubectl-client-side-apply" using apps/v1`,
+	Field:   ".spec.replicas",
+}
+
+func TestApplyConflicts(t *testing.T) {
+	cases := []struct {
+		info     string
+		err      error
+		expected []ApplyConflict
+	}{
+		{"generic error", fmt.Errorf("conflict"), nil},
+		{"not found error", k8serrors.NewNotFound(configMapsGVR.GroupResource(), "a"), nil},
+		{"conflict without details", k8serrors.NewConflict(configMapsGVR.GroupResource(), "a", fmt.Errorf("stale")), nil},
+		{
+			"field manager conflicts",
+			newApplyConflict(
+				replicasConflict,
+				metav1.StatusCause{Type: metav1.CauseTypeFieldValueInvalid, Field: ".spec"},
+				metav1.StatusCause{Type: metav1.CauseTypeFieldManagerConflict, Message: "conflict", Field: ".data.a"},
+			),
+			[]ApplyConflict{
+				{Manager: "kubectl-client-side-apply", Field: ".spec.replicas", Message: replicasConflict.Message},
+				{Field: ".data.a", Message: "conflict"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if actual := ApplyConflicts(c.err); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("ApplyConflicts() of %s == %#v, expected %#v", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	applied := newConfigMap("a", map[string]interface{}{"key": "applied"})
+	forced := newConfigMap("a", map[string]interface{}{"key": "forced"})
+	conflict := newApplyConflict(replicasConflict)
+	conflicts := []ApplyConflict{
+		{Manager: "kubectl-client-side-apply", Field: ".spec.replicas", Message: replicasConflict.Message},
+	}
+
+	cases := []struct {
+		info            string
+		opts            ApplyOptions
+		applyResults    []fakeApplyResult
+		expected        *ApplyResult
+		expectedErr     bool
+		expectedApplied []metav1.ApplyOptions
+	}{
+		{
+			"applied object",
+			ApplyOptions{FieldManager: "dashboard"},
+			[]fakeApplyResult{{object: applied}},
+			&ApplyResult{Object: applied},
+			false,
+			[]metav1.ApplyOptions{{FieldManager: "dashboard"}},
+		},
+		{
+			"dry-run",
+			ApplyOptions{FieldManager: "dashboard", DryRun: true},
+			[]fakeApplyResult{{object: applied}},
+			&ApplyResult{Object: applied},
+			false,
+			[]metav1.ApplyOptions{{FieldManager: "dashboard", DryRun: []string{metav1.DryRunAll}}},
+		},
+		{
+			"conflicts",
+			ApplyOptions{FieldManager: "dashboard"},
+			[]fakeApplyResult{{err: conflict}},
+			&ApplyResult{Conflicts: conflicts},
+			false,
+			[]metav1.ApplyOptions{{FieldManager: "dashboard"}},
+		},
+		{
+			"conflicts in dry-run are forced",
+			ApplyOptions{FieldManager: "dashboard", DryRun: true},
+			[]fakeApplyResult{{err: conflict}, {object: forced}},
+			&ApplyResult{Object: forced, Conflicts: conflicts},
+			false,
+			[]metav1.ApplyOptions{
+				{FieldManager: "dashboard", DryRun: []string{metav1.DryRunAll}},
+				{FieldManager: "dashboard", DryRun: []string{metav1.DryRunAll}, Force: true},
+			},
+		},
+		{
+			"conflicts in forced dry-run",
+			ApplyOptions{FieldManager: "dashboard", DryRun: true, Force: true},
+			[]fakeApplyResult{{err: conflict}},
+			&ApplyResult{Conflicts: conflicts},
+			false,
+			[]metav1.ApplyOptions{{FieldManager: "dashboard", DryRun: []string{metav1.DryRunAll}, Force: true}},
+		},
+		{
+			"failed forced dry-run",
+			ApplyOptions{FieldManager: "dashboard", DryRun: true},
+			[]fakeApplyResult{{err: conflict}, {err: k8serrors.NewBadRequest("invalid")}},
+			nil,
+			true,
+			[]metav1.ApplyOptions{
+				{FieldManager: "dashboard", DryRun: []string{metav1.DryRunAll}},
+				{FieldManager: "dashboard", DryRun: []string{metav1.DryRunAll}, Force: true},
+			},
+		},
+		{
+			"other error",
+			ApplyOptions{FieldManager: "dashboard", DryRun: true},
+			[]fakeApplyResult{{err: k8serrors.NewBadRequest("invalid")}},
+			nil,
+			true,
+			[]metav1.ApplyOptions{{FieldManager: "dashboard", DryRun: []string{metav1.DryRunAll}}},
+		},
+	}
+
+	for _, c := range cases {
+		resource := newFakeResource(c.applyResults)
+		object := newConfigMap("a", map[string]interface{}{"key": "value"})
+		object.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+
+		actual, err := Apply(context.TODO(), resource, object, c.opts)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("Apply() with %s returned error %v", c.info, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Apply() with %s == %#v, expected %#v", c.info, actual, c.expected)
+		}
+
+		if !reflect.DeepEqual(resource.applied, c.expectedApplied) {
+			t.Errorf("Apply() with %s used options %#v, expected %#v", c.info, resource.applied, c.expectedApplied)
+		}
+	}
+}
+
+func TestNewApplyOptions(t *testing.T) {
+	cases := []struct {
+		fieldManager, dryRun string
+		expected             *ApplyOptions
+		expectedErr          bool
+	}{
+		{"", "", &ApplyOptions{FieldManager: DefaultFieldManager}, false},
+		{"kubectl", metav1.DryRunAll, &ApplyOptions{FieldManager: "kubectl", DryRun: true}, false},
+		{"", "Server", nil, true},
+	}
+
+	for _, c := range cases {
+		actual, err := NewApplyOptions(c.fieldManager, false, c.dryRun)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("NewApplyOptions(%q, %q) returned error %v", c.fieldManager, c.dryRun, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("NewApplyOptions(%q, %q) == %#v, expected %#v", c.fieldManager, c.dryRun, actual, c.expected)
+		}
+	}
+}
@@ -20,6 +20,17 @@ import (
 	"github.com/spf13/pflag"
 )
 
+const (
+	// CacheBackendList stores whole LIST responses per token and refreshes them in the background.
+	CacheBackendList = "list"
+	// CacheBackendInformer serves LIST requests from shared informers started with the dashboard credentials.
+	CacheBackendInformer = "informer"
+
+	// minCacheInformerIdleTTL is the minimal value of `cache-informer-idle-ttl`. Idle informers are checked twice
+	// within the TTL, so shorter values would only restart the informers all the time.
+	minCacheInformerIdleTTL = time.Second
+)
+
 var (
 	argCacheEnabled          = pflag.Bool("cache-enabled", true, "whether client cache should be enabled or not")
 	argClusterContextEnabled = pflag.Bool("cluster-context-enabled", false, "whether multi-cluster cache context support should be enabled or not")
@@ -27,12 +38,26 @@ var (
 	argCacheSize             = pflag.Int("cache-size", 1000, "max number of cache entries")
 	argCacheTTL              = pflag.Duration("cache-ttl", 10*time.Minute, "cache entry TTL")
 	argCacheRefreshDebounce  = pflag.Duration("cache-refresh-debounce", 5*time.Second, "minimal time between cache refreshes in the background")
+	argCacheBackend          = pflag.String("cache-backend", CacheBackendList, "cache backend used by the client cache, one of 'list' or 'informer'. The 'informer' backend requires dashboard service account to be able to list and watch cached resources")
+	argCacheInformerIdleTTL  = pflag.Duration("cache-informer-idle-ttl", 10*time.Minute, "time after which unused shared informer is stopped when 'informer' cache backend is used")
 )
 
 func Ensure() {
 	if *argClusterContextEnabled && len(*argTokenExchangeEndpoint) == 0 {
 		panic("token-exchange-endpoint must be set when cluster-context-enabled is set to true")
 	}
+
+	if *argCacheBackend != CacheBackendList && *argCacheBackend != CacheBackendInformer {
+		panic("cache-backend must be one of 'list' or 'informer'")
+	}
+
+	if *argClusterContextEnabled && *argCacheBackend == CacheBackendInformer {
+		panic("cache-backend 'informer' can not be used when cluster-context-enabled is set to true")
+	}
+
+	if *argCacheInformerIdleTTL < minCacheInformerIdleTTL {
+		panic("cache-informer-idle-ttl must be at least " + minCacheInformerIdleTTL.String())
+	}
 }
 
 func CacheEnabled() bool {
@@ -58,3 +83,11 @@ func CacheTTL() time.Duration {
 func CacheRefreshDebounce() time.Duration {
 	return *argCacheRefreshDebounce
 }
+
+func CacheBackend() string {
+	return *argCacheBackend
+}
+
+func CacheInformerIdleTTL() time.Duration {
+	return *argCacheInformerIdleTTL
+}
@@ -20,15 +20,24 @@ import (
 
 	authorizationapiv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/klog/v2"
 
+	"k8s.io/dashboard/client/args"
 	"k8s.io/dashboard/client/cache"
 
 	"k8s.io/dashboard/errors"
 	"k8s.io/dashboard/types"
 )
 
+// informerStore returns the store of the shared informer. It can be replaced in tests.
+var informerStore = cache.InformerStore
+
 type ResourceListerInterface[T any] interface {
 	List(ctx context.Context, opts metav1.ListOptions) (*T, error)
 }
@@ -40,6 +49,10 @@ type CachedResourceLister[T any] struct {
 }
 
 func (in CachedResourceLister[T]) List(ctx context.Context, lister ResourceListerInterface[T], opts metav1.ListOptions) (*T, error) {
+	if args.CacheBackend() == args.CacheBackendInformer && canListFromInformer(opts) {
+		return in.listFromInformer(ctx, lister, opts)
+	}
+
 	cacheKey := in.cacheKey(opts)
 	cachedList, found, err := cache.Get[T](cacheKey)
 	if err != nil {
@@ -52,23 +65,125 @@ func (in CachedResourceLister[T]) List(ctx context.Context, lister ResourceListe
 		})
 	}
 
-	review, err := in.authorizationV1.SelfSubjectAccessReviews().Create(ctx, in.selfSubjectAccessReview(types.VerbList), metav1.CreateOptions{})
-	if err != nil {
+	if err = in.authorize(ctx); err != nil {
 		return new(T), err
 	}
 
-	if review.Status.Allowed {
-		klog.V(3).InfoS("resource found in cache, updating in background", "kind", in.kind(), "namespace", in.namespace())
-		cache.DeferredLoad[*T](cacheKey, func() (*T, error) {
-			return lister.List(ctx, opts)
-		})
+	if cacheKey.IsChunkContinuation() {
+		klog.V(3).InfoS("resource chunk found in cache", "kind", in.kind(), "namespace", in.namespace())
 		return cachedList, nil
 	}
 
-	return new(T), errors.NewForbidden(
-		errors.MsgForbiddenError,
-		fmt.Errorf("%s: %s", review.Status.Reason, review.Status.EvaluationError),
-	)
+	klog.V(3).InfoS("resource found in cache, updating in background", "kind", in.kind(), "namespace", in.namespace())
+	cache.DeferredLoad[*T](cacheKey, func() (*T, error) {
+		return lister.List(ctx, opts)
+	})
+	return cachedList, nil
+}
+
+// listFromInformer serves the list from the shared informer store after verifying that the user
+// is allowed to list the resource. If the informer can not be used, the request is sent directly
+// to the API server.
+func (in CachedResourceLister[T]) listFromInformer(ctx context.Context, lister ResourceListerInterface[T], opts metav1.ListOptions) (*T, error) {
+	if err := in.authorize(ctx); err != nil {
+		return new(T), err
+	}
+
+	store, err := informerStore(ctx, in.groupVersionResource())
+	if err != nil {
+		klog.ErrorS(err, "could not use shared informer, falling back to the API server", "kind", in.kind())
+		return lister.List(ctx, opts)
+	}
+
+	labelSelector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return new(T), errors.NewBadRequest(err.Error())
+	}
+
+	fieldSelector, err := fields.ParseSelector(opts.FieldSelector)
+	if err != nil {
+		return new(T), errors.NewBadRequest(err.Error())
+	}
+
+	items := make([]interface{}, 0)
+	for _, obj := range store.List() {
+		object, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		if len(in.namespace()) > 0 && object.GetNamespace() != in.namespace() {
+			continue
+		}
+
+		if !labelSelector.Matches(labels.Set(object.GetLabels())) || !fieldSelector.Matches(objectMetaFields(object)) {
+			continue
+		}
+
+		items = append(items, object.UnstructuredContent())
+	}
+
+	result := new(T)
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]interface{}{"items": items}, result); err != nil {
+		return new(T), err
+	}
+
+	klog.V(3).InfoS("resource served from shared informer", "kind", in.kind(), "namespace", in.namespace(), "items", len(items))
+	return result, nil
+}
+
+// authorize verifies with the SelfSubjectAccessReview that the token owner is allowed to list the resource.
+func (in CachedResourceLister[_]) authorize(ctx context.Context) error {
+	review, err := in.authorizationV1.SelfSubjectAccessReviews().Create(ctx, in.selfSubjectAccessReview(types.VerbList), metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	if !review.Status.Allowed {
+		return errors.NewForbidden(
+			errors.MsgForbiddenError,
+			fmt.Errorf("%s: %s", review.Status.Reason, review.Status.EvaluationError),
+		)
+	}
+
+	return nil
+}
+
+func (in CachedResourceLister[_]) groupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    in.ssar.Spec.ResourceAttributes.Group,
+		Version:  in.ssar.Spec.ResourceAttributes.Version,
+		Resource: in.ssar.Spec.ResourceAttributes.Resource,
+	}
+}
+
+// canListFromInformer checks if the list options can be applied to the informer store.
+// Pagination, specific resource versions and field selectors other than object name and
+// namespace are supported only by the API server.
+func canListFromInformer(opts metav1.ListOptions) bool {
+	if opts.Limit > 0 || len(opts.Continue) > 0 || len(opts.ResourceVersion) > 0 {
+		return false
+	}
+
+	fieldSelector, err := fields.ParseSelector(opts.FieldSelector)
+	if err != nil {
+		return false
+	}
+
+	for _, requirement := range fieldSelector.Requirements() {
+		if requirement.Field != "metadata.name" && requirement.Field != "metadata.namespace" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func objectMetaFields(object *unstructured.Unstructured) fields.Set {
+	return fields.Set{
+		"metadata.name":      object.GetName(),
+		"metadata.namespace": object.GetNamespace(),
+	}
 }
 
 func (in CachedResourceLister[_]) selfSubjectAccessReview(verb types.Verb) *authorizationapiv1.SelfSubjectAccessReview {
@@ -0,0 +1,162 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	authorizationapiv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"k8s.io/dashboard/errors"
+	"k8s.io/dashboard/types"
+)
+
+type fakePodLister struct {
+	called bool
+}
+
+func (in *fakePodLister) List(context.Context, metav1.ListOptions) (*corev1.PodList, error) {
+	in.called = true
+	return &corev1.PodList{Items: []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "from-api-server"}}}}, nil
+}
+
+func newUnstructuredPod(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace(namespace)
+	pod.SetName(name)
+	pod.SetLabels(labels)
+	return pod
+}
+
+func TestCanListFromInformer(t *testing.T) {
+	cases := []struct {
+		opts     metav1.ListOptions
+		expected bool
+	}{
+		{metav1.ListOptions{}, true},
+		{metav1.ListOptions{LabelSelector: "app=test"}, true},
+		{metav1.ListOptions{FieldSelector: "metadata.name=test,metadata.namespace=default"}, true},
+		{metav1.ListOptions{FieldSelector: "status.phase=Running"}, false},
+		{metav1.ListOptions{FieldSelector: "metadata.name"}, false},
+		{metav1.ListOptions{Limit: 10}, false},
+		{metav1.ListOptions{Continue: "token"}, false},
+		{metav1.ListOptions{ResourceVersion: "0"}, false},
+	}
+
+	for _, c := range cases {
+		if actual := canListFromInformer(c.opts); actual != c.expected {
+			t.Errorf("canListFromInformer(%#v) == %v, expected %v", c.opts, actual, c.expected)
+		}
+	}
+}
+
+func TestListFromInformer(t *testing.T) {
+	store := toolscache.NewStore(toolscache.MetaNamespaceKeyFunc)
+	for _, pod := range []*unstructured.Unstructured{
+		newUnstructuredPod("default", "a", map[string]string{"app": "a"}),
+		newUnstructuredPod("default", "b", map[string]string{"app": "b"}),
+		newUnstructuredPod("other", "c", map[string]string{"app": "a"}),
+	} {
+		if err := store.Add(pod); err != nil {
+			t.Fatalf("store.Add() returned error: %v", err)
+		}
+	}
+
+	defer func(original func(context.Context, schema.GroupVersionResource) (toolscache.Store, error)) {
+		informerStore = original
+	}(informerStore)
+
+	cases := []struct {
+		info        string
+		namespace   string
+		opts        metav1.ListOptions
+		allowed     bool
+		storeErr    error
+		expected    []string
+		expectedErr func(error) bool
+	}{
+		{"all namespaces", "", metav1.ListOptions{}, true, nil, []string{"a", "b", "c"}, nil},
+		{"single namespace", "default", metav1.ListOptions{}, true, nil, []string{"a", "b"}, nil},
+		{"label selector", "", metav1.ListOptions{LabelSelector: "app=a"}, true, nil, []string{"a", "c"}, nil},
+		{"field selector", "", metav1.ListOptions{FieldSelector: "metadata.name=b"}, true, nil, []string{"b"}, nil},
+		{"invalid label selector", "", metav1.ListOptions{LabelSelector: "app in (a"}, true, nil, nil,
+			errors.IsBadRequest},
+		{"not allowed", "", metav1.ListOptions{}, false, nil, nil, errors.IsForbidden},
+		{"informer not synced", "", metav1.ListOptions{}, true, fmt.Errorf("not synced"),
+			[]string{"from-api-server"}, nil},
+	}
+
+	for _, c := range cases {
+		informerStore = func(context.Context, schema.GroupVersionResource) (toolscache.Store, error) {
+			return store, c.storeErr
+		}
+
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("create", "selfsubjectaccessreviews",
+			func(action clienttesting.Action) (bool, runtime.Object, error) {
+				review := action.(clienttesting.CreateAction).GetObject().(*authorizationapiv1.SelfSubjectAccessReview)
+				review.Status.Allowed = c.allowed
+				return true, review, nil
+			})
+
+		lister := NewCachedResourceLister[corev1.PodList](client.AuthorizationV1(),
+			WithToken[corev1.PodList]("token"),
+			WithNamespace[corev1.PodList](c.namespace),
+			WithResourceKind[corev1.PodList](types.ResourceKindPod),
+		)
+		apiServerLister := &fakePodLister{}
+
+		list, err := lister.listFromInformer(context.TODO(), apiServerLister, c.opts)
+		if c.expectedErr != nil {
+			if !c.expectedErr(err) {
+				t.Errorf("listFromInformer() of %s returned error %v", c.info, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("listFromInformer() of %s returned error %v", c.info, err)
+			continue
+		}
+
+		if apiServerLister.called != (c.storeErr != nil) {
+			t.Errorf("listFromInformer() of %s called API server: %v", c.info, apiServerLister.called)
+		}
+
+		actual := make([]string, 0)
+		for _, pod := range list.Items {
+			actual = append(actual, pod.Name)
+		}
+
+		sort.Strings(actual)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("listFromInformer() of %s == %v, expected %v", c.info, actual, c.expected)
+		}
+	}
+}
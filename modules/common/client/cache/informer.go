@@ -0,0 +1,134 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"k8s.io/dashboard/client/args"
+)
+
+// informerSyncTimeout is the maximum time that a request waits for a newly started informer to sync.
+const informerSyncTimeout = 30 * time.Second
+
+var (
+	// informerClient is a dynamic client created with the dashboard credentials.
+	// It is used by all shared informers, so every resource type is watched only once
+	// no matter how many users are accessing it. Access is still verified per user
+	// before serving data from the informer store.
+	informerClient dynamic.Interface
+
+	// informers maps resources to shared informers that are currently running.
+	informers     = make(map[schema.GroupVersionResource]*sharedInformer)
+	informersLock sync.Mutex
+)
+
+// sharedInformer wraps cluster-wide informer of a single resource type together with
+// information required to stop it once it is not used anymore.
+type sharedInformer struct {
+	informer   toolscache.SharedIndexInformer
+	stopCh     chan struct{}
+	lastAccess time.Time
+}
+
+// InitInformers initializes the 'informer' cache backend. Provided config should use the dashboard credentials.
+func InitInformers(config *rest.Config) error {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	informerClient = client
+	return nil
+}
+
+// InformerStore gives access to the synced store of the shared informer of the given resource.
+// Informer is started on first use and stopped after being idle for `cache-informer-idle-ttl`.
+func InformerStore(ctx context.Context, gvr schema.GroupVersionResource) (toolscache.Store, error) {
+	if informerClient == nil {
+		return nil, fmt.Errorf("informer cache backend has not been initialized")
+	}
+
+	informersLock.Lock()
+	entry, exists := informers[gvr]
+	if !exists {
+		entry = newSharedInformer(gvr)
+		informers[gvr] = entry
+	}
+	entry.lastAccess = time.Now()
+	informersLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, informerSyncTimeout)
+	defer cancel()
+
+	if !toolscache.WaitForCacheSync(ctx.Done(), entry.informer.HasSynced) {
+		return nil, fmt.Errorf("informer for %s has not synced", gvr.String())
+	}
+
+	return entry.informer.GetStore(), nil
+}
+
+func newSharedInformer(gvr schema.GroupVersionResource) *sharedInformer {
+	result := &sharedInformer{
+		informer: dynamicinformer.NewFilteredDynamicInformer(
+			informerClient, gvr, "", 0, toolscache.Indexers{}, nil,
+		).Informer(),
+		stopCh: make(chan struct{}),
+	}
+
+	if err := result.informer.SetWatchErrorHandler(func(_ *toolscache.Reflector, err error) {
+		klog.ErrorS(err, "shared informer watch failed", "resource", gvr.String())
+	}); err != nil {
+		klog.ErrorS(err, "could not set shared informer watch error handler", "resource", gvr.String())
+	}
+
+	go result.informer.Run(result.stopCh)
+	go result.stopWhenIdle(gvr, args.CacheInformerIdleTTL())
+
+	klog.V(3).InfoS("shared informer started", "resource", gvr.String())
+	return result
+}
+
+// stopWhenIdle periodically checks when the informer was used last time and stops it
+// once it has been idle for longer than the ttl.
+func (in *sharedInformer) stopWhenIdle(gvr schema.GroupVersionResource, ttl time.Duration) {
+	ticker := time.NewTicker(ttl / 2)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		informersLock.Lock()
+		if time.Since(in.lastAccess) < ttl {
+			informersLock.Unlock()
+			continue
+		}
+
+		delete(informers, gvr)
+		informersLock.Unlock()
+
+		close(in.stopCh)
+		klog.V(3).InfoS("shared informer stopped after being idle", "resource", gvr.String())
+		return
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func isStopped(informer *sharedInformer) bool {
+	select {
+	case <-informer.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestStopWhenIdle(t *testing.T) {
+	const ttl = 20 * time.Millisecond
+
+	cases := []struct {
+		info string
+		// used is the time for which the informer keeps being accessed.
+		used time.Duration
+	}{
+		{"idle informer", 0},
+		{"used informer", 10 * ttl},
+	}
+
+	for _, c := range cases {
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		informer := &sharedInformer{stopCh: make(chan struct{}), lastAccess: time.Now()}
+		informersLock.Lock()
+		informers[gvr] = informer
+		informersLock.Unlock()
+
+		go informer.stopWhenIdle(gvr, ttl)
+
+		for start := time.Now(); time.Since(start) < c.used; time.Sleep(ttl / 10) {
+			informersLock.Lock()
+			informer.lastAccess = time.Now()
+			informersLock.Unlock()
+
+			if isStopped(informer) {
+				t.Fatalf("%s has been stopped while being used", c.info)
+			}
+		}
+
+		select {
+		case <-informer.stopCh:
+		case <-time.After(100 * ttl):
+			t.Fatalf("%s has not been stopped after being idle", c.info)
+		}
+
+		informersLock.Lock()
+		_, exists := informers[gvr]
+		informersLock.Unlock()
+		if exists {
+			t.Errorf("%s has not been removed from running informers", c.info)
+		}
+	}
+}
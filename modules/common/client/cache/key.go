@@ -60,7 +60,8 @@ func (k key) SHA() (string, error) {
 }
 
 // MarshalJSON is a custom marshall implementation that allows to marshall internal key fields.
-// It is required during SHA calculation.
+// It is required during SHA calculation. Limit and continue token are a part of the key, as every
+// chunk of the list has to be cached separately.
 func (k key) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Kind      types.ResourceKind
@@ -69,10 +70,21 @@ func (k key) MarshalJSON() ([]byte, error) {
 	}{
 		Kind:      k.kind,
 		Namespace: k.namespace,
-		Opts:      metav1.ListOptions{LabelSelector: k.opts.LabelSelector, FieldSelector: k.opts.FieldSelector},
+		Opts: metav1.ListOptions{
+			LabelSelector: k.opts.LabelSelector,
+			FieldSelector: k.opts.FieldSelector,
+			Limit:         k.opts.Limit,
+			Continue:      k.opts.Continue,
+		},
 	})
 }
 
+// IsChunkContinuation returns true if the key identifies a chunk of the list that follows another chunk.
+// Such chunks are served from a consistent snapshot encoded in the continue token, so they never change.
+func (k key) IsChunkContinuation() bool {
+	return len(k.opts.Continue) > 0
+}
+
 // Key embeds an internal key structure and extends it with the support
 // for the multi-cluster cache key creation.
 type Key struct {
@@ -23,6 +23,7 @@ import (
 	v1 "k8s.io/api/authorization/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -92,6 +93,19 @@ func APIExtensionsClient(request *http.Request) (apiextensionsclientset.Interfac
 	return apiextensionsclientset.NewForConfig(config)
 }
 
+func DynamicClient(request *http.Request) (dynamic.Interface, error) {
+	if !isInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
+
+	config, err := configFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamic.NewForConfig(config)
+}
+
 func Config(request *http.Request) (*rest.Config, error) {
 	if !isInitialized() {
 		return nil, fmt.Errorf("client package not initialized")
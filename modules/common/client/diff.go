@@ -0,0 +1,159 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gomodules.xyz/jsonpatch/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// ignoredDiffFields are fields maintained by the API server that are removed before the objects are compared.
+var ignoredDiffFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"status"},
+}
+
+// ResourceDiff is a difference between the live object and the object that would be persisted by the change.
+type ResourceDiff struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Exists is false when the object does not exist yet and would be created.
+	Exists bool `json:"exists"`
+	// Patch is a JSON patch (RFC 6902) that transforms the live object into the proposed one.
+	Patch []jsonpatch.Operation `json:"patch"`
+	// Diff is a unified diff of the live and the proposed object in the YAML format.
+	Diff string `json:"diff"`
+	// Conflicts are fields owned by other field managers. Only set for server-side apply.
+	Conflicts []ApplyConflict `json:"conflicts,omitempty"`
+}
+
+// Diff performs a server-side dry-run of the change and compares the result with the live object. When apply options
+// are given, server-side apply is used. Otherwise, the object is updated or created if it does not exist yet. The
+// resource version of the object is kept, so the diff of a stale object fails with a conflict as the update would.
+func Diff(ctx context.Context, resource dynamic.ResourceInterface, object *unstructured.Unstructured,
+	applyOptions *ApplyOptions) (*ResourceDiff, error) {
+	live, err := resource.Get(ctx, object.GetName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		live = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result := &ResourceDiff{
+		Kind:      object.GetKind(),
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
+		Exists:    live != nil,
+	}
+
+	var proposed *unstructured.Unstructured
+	dryRun := []string{metav1.DryRunAll}
+	switch {
+	case applyOptions != nil:
+		opts := *applyOptions
+		opts.DryRun = true
+		applyResult, err := Apply(ctx, resource, object.DeepCopy(), opts)
+		if err != nil {
+			return nil, err
+		}
+		proposed, result.Conflicts = applyResult.Object, applyResult.Conflicts
+	case live == nil:
+		proposed, err = resource.Create(ctx, object, metav1.CreateOptions{DryRun: dryRun})
+	default:
+		proposed, err = resource.Update(ctx, object, metav1.UpdateOptions{DryRun: dryRun})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Patch, result.Diff, err = diffObjects(live, proposed)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// diffObjects returns JSON patch and unified diff of two objects, ignoring fields maintained by the API server.
+// Nil object is treated as empty.
+func diffObjects(live, proposed *unstructured.Unstructured) ([]jsonpatch.Operation, string, error) {
+	liveJSON, liveYAML, err := normalizeForDiff(live)
+	if err != nil {
+		return nil, "", err
+	}
+
+	proposedJSON, proposedYAML, err := normalizeForDiff(proposed)
+	if err != nil {
+		return nil, "", err
+	}
+
+	patch, err := jsonpatch.CreatePatch(liveJSON, proposedJSON)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed creating json patch: %w", err)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(liveYAML),
+		B:        splitLines(proposedYAML),
+		FromFile: "live",
+		ToFile:   "proposed",
+		Context:  3,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed creating unified diff: %w", err)
+	}
+
+	return patch, diff, nil
+}
+
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return difflib.SplitLines(strings.TrimSuffix(string(data), "\n"))
+}
+
+func normalizeForDiff(object *unstructured.Unstructured) (jsonData []byte, yamlData []byte, err error) {
+	if object == nil {
+		return []byte("{}"), nil, nil
+	}
+
+	normalized := object.DeepCopy()
+	for _, field := range ignoredDiffFields {
+		unstructured.RemoveNestedField(normalized.Object, field...)
+	}
+
+	if jsonData, err = normalized.MarshalJSON(); err != nil {
+		return nil, nil, err
+	}
+
+	if yamlData, err = yaml.JSONToYAML(jsonData); err != nil {
+		return nil, nil, err
+	}
+
+	return jsonData, yamlData, nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// withServerFields returns the copy of the object with fields maintained by the API server.
+func withServerFields(object *unstructured.Unstructured, resourceVersion, manager string) *unstructured.Unstructured {
+	result := object.DeepCopy()
+	result.SetResourceVersion(resourceVersion)
+	result.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: manager, Operation: metav1.ManagedFieldsOperationApply}})
+	result.Object["status"] = map[string]interface{}{"observedGeneration": resourceVersion}
+	return result
+}
+
+// sortPatch sorts operations by path, as their order depends on the map iteration.
+func sortPatch(patch []jsonpatch.Operation) []jsonpatch.Operation {
+	sort.Slice(patch, func(i, j int) bool { return patch[i].Path < patch[j].Path })
+	return patch
+}
+
+func TestDiffObjects(t *testing.T) {
+	live := newConfigMap("a", map[string]interface{}{"key": "value", "other": "value"})
+
+	cases := []struct {
+		info          string
+		live          *unstructured.Unstructured
+		proposed      *unstructured.Unstructured
+		expectedPatch []jsonpatch.Operation
+		expectedDiff  string
+	}{
+		{
+			"no change",
+			live,
+			live,
+			[]jsonpatch.Operation{},
+			"",
+		},
+		{
+			"changed managed fields, resource version and status",
+			withServerFields(live, "1", "kubectl"),
+			withServerFields(live, "2", "dashboard"),
+			[]jsonpatch.Operation{},
+			"",
+		},
+		{
+			"changed data",
+			withServerFields(live, "1", "kubectl"),
+			withServerFields(newConfigMap("a", map[string]interface{}{"key": "changed", "other": "value"}), "2",
+				"dashboard"),
+			[]jsonpatch.Operation{{Operation: "replace", Path: "/data/key", Value: "changed"}},
+			`--- live
++++ proposed
+@@ -1,6 +1,6 @@
+ apiVersion: v1
+ data:
+-  key: value
++  key: changed
+   other: value
+ kind: ConfigMap
+ metadata:
+`,
+		},
+		{
+			"new object",
+			nil,
+			withServerFields(newConfigMap("a", map[string]interface{}{"key": "value"}), "1", "dashboard"),
+			[]jsonpatch.Operation{
+				{Operation: "add", Path: "/apiVersion", Value: "v1"},
+				{Operation: "add", Path: "/data", Value: map[string]interface{}{"key": "value"}},
+				{Operation: "add", Path: "/kind", Value: "ConfigMap"},
+				{Operation: "add", Path: "/metadata", Value: map[string]interface{}{"name": "a", "namespace": "default"}},
+			},
+			`--- live
++++ proposed
+@@ -0,0 +1,7 @@
++apiVersion: v1
++data:
++  key: value
++kind: ConfigMap
++metadata:
++  name: a
++  namespace: default
+`,
+		},
+	}
+
+	for _, c := range cases {
+		patch, diff, err := diffObjects(c.live, c.proposed)
+		if err != nil {
+			t.Errorf("diffObjects() of %s returned error: %v", c.info, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(sortPatch(patch), c.expectedPatch) {
+			t.Errorf("diffObjects() of %s returned patch %#v, expected %#v", c.info, patch, c.expectedPatch)
+		}
+
+		if diff != c.expectedDiff {
+			t.Errorf("diffObjects() of %s returned diff \n%s\nexpected \n%s", c.info, diff, c.expectedDiff)
+		}
+	}
+}
+
+func TestNormalizeForDiff(t *testing.T) {
+	object := withServerFields(newConfigMap("a", map[string]interface{}{"key": "value"}), "1", "kubectl")
+	jsonData, yamlData, err := normalizeForDiff(object)
+	if err != nil {
+		t.Fatalf("normalizeForDiff() returned error: %v", err)
+	}
+
+	expectedJSON := `{"apiVersion":"v1","data":{"key":"value"},"kind":"ConfigMap","metadata":{"name":"a","namespace":"default"}}` + "\n"
+	if string(jsonData) != expectedJSON {
+		t.Errorf("normalizeForDiff() returned JSON %s, expected %s", jsonData, expectedJSON)
+	}
+
+	expectedYAML := "apiVersion: v1\ndata:\n  key: value\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: default\n"
+	if string(yamlData) != expectedYAML {
+		t.Errorf("normalizeForDiff() returned YAML %s, expected %s", yamlData, expectedYAML)
+	}
+
+	if object.GetResourceVersion() != "1" || object.GetManagedFields() == nil || object.Object["status"] == nil {
+		t.Errorf("normalizeForDiff() should not change the object, got %#v", object)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	live := withServerFields(newConfigMap("a", map[string]interface{}{"key": "value"}), "1", "kubectl")
+	changed := newConfigMap("a", map[string]interface{}{"key": "changed"})
+	changed.SetResourceVersion("1")
+	dryRun := []string{metav1.DryRunAll}
+	replaceKey := []jsonpatch.Operation{{Operation: "replace", Path: "/data/key", Value: "changed"}}
+
+	cases := []struct {
+		info            string
+		resource        *fakeResource
+		applyOptions    *ApplyOptions
+		expectedExists  bool
+		expectedPatch   []jsonpatch.Operation
+		expectedCreated []metav1.CreateOptions
+		expectedUpdated []metav1.UpdateOptions
+		expectedApplied []metav1.ApplyOptions
+		expectedManager string
+	}{
+		{
+			"create",
+			newFakeResource(nil),
+			nil,
+			false,
+			[]jsonpatch.Operation{
+				{Operation: "add", Path: "/apiVersion", Value: "v1"},
+				{Operation: "add", Path: "/data", Value: map[string]interface{}{"key": "changed"}},
+				{Operation: "add", Path: "/kind", Value: "ConfigMap"},
+				{Operation: "add", Path: "/metadata", Value: map[string]interface{}{"name": "a", "namespace": "default"}},
+			},
+			[]metav1.CreateOptions{{DryRun: dryRun}},
+			nil,
+			nil,
+			"",
+		},
+		{
+			"update",
+			newFakeResource(nil, live),
+			nil,
+			true,
+			replaceKey,
+			nil,
+			[]metav1.UpdateOptions{{DryRun: dryRun}},
+			nil,
+			"",
+		},
+		{
+			"server-side apply",
+			newFakeResource([]fakeApplyResult{
+				{err: newApplyConflict(replicasConflict)},
+				{object: withServerFields(changed, "2", "dashboard")},
+			}, live),
+			&ApplyOptions{FieldManager: "dashboard"},
+			true,
+			replaceKey,
+			nil,
+			nil,
+			[]metav1.ApplyOptions{
+				{FieldManager: "dashboard", DryRun: dryRun},
+				{FieldManager: "dashboard", DryRun: dryRun, Force: true},
+			},
+			"kubectl-client-side-apply",
+		},
+	}
+
+	for _, c := range cases {
+		var applyOptions *ApplyOptions
+		if c.applyOptions != nil {
+			options := *c.applyOptions
+			applyOptions = &options
+		}
+
+		actual, err := Diff(context.TODO(), c.resource, changed.DeepCopy(), applyOptions)
+		if err != nil {
+			t.Errorf("Diff() with %s returned error: %v", c.info, err)
+			continue
+		}
+
+		if actual.Exists != c.expectedExists || actual.Kind != "ConfigMap" || actual.Name != "a" ||
+			actual.Namespace != "default" {
+			t.Errorf("Diff() with %s == %#v, expected existing %v ConfigMap default/a", c.info, actual,
+				c.expectedExists)
+		}
+
+		if !reflect.DeepEqual(sortPatch(actual.Patch), c.expectedPatch) || len(actual.Diff) == 0 {
+			t.Errorf("Diff() with %s returned patch %#v and diff %q, expected patch %#v", c.info, actual.Patch,
+				actual.Diff, c.expectedPatch)
+		}
+
+		if (len(actual.Conflicts) > 0 || len(c.expectedManager) > 0) &&
+			(len(actual.Conflicts) != 1 || actual.Conflicts[0].Manager != c.expectedManager) {
+			t.Errorf("Diff() with %s returned conflicts %#v, expected conflict with %q", c.info, actual.Conflicts,
+				c.expectedManager)
+		}
+
+		if !reflect.DeepEqual(c.resource.created, c.expectedCreated) ||
+			!reflect.DeepEqual(c.resource.updated, c.expectedUpdated) ||
+			!reflect.DeepEqual(c.resource.applied, c.expectedApplied) {
+			t.Errorf("Diff() with %s used options %#v, %#v, %#v, expected %#v, %#v, %#v", c.info,
+				c.resource.created, c.resource.updated, c.resource.applied, c.expectedCreated, c.expectedUpdated,
+				c.expectedApplied)
+		}
+
+		if !reflect.DeepEqual(applyOptions, c.applyOptions) {
+			t.Errorf("Diff() with %s changed apply options to %#v", c.info, applyOptions)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/dashboard/client/args"
+	"k8s.io/dashboard/client/cache"
 	"k8s.io/dashboard/errors"
 )
 
@@ -207,6 +208,13 @@ func Init(options ...Option) {
 		os.Exit(1)
 	}
 
+	if args.CacheEnabled() && args.CacheBackend() == args.CacheBackendInformer {
+		if err = cache.InitInformers(config); err != nil {
+			klog.Errorf("Could not init informer cache backend: %s", err)
+			os.Exit(1)
+		}
+	}
+
 	baseConfig = config
 }
 
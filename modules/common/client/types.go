@@ -15,8 +15,10 @@
 package client
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -50,6 +52,10 @@ const (
 // ResourceVerber is responsible for performing generic CRUD operations on all supported resources.
 type ResourceVerber interface {
 	Update(object *unstructured.Unstructured) error
+	Apply(object *unstructured.Unstructured, opts ApplyOptions) (*ApplyResult, error)
+	Diff(object *unstructured.Unstructured, applyOptions *ApplyOptions) (*ResourceDiff, error)
 	Get(kind string, namespace string, name string) (runtime.Object, error)
 	Delete(kind string, namespace string, name string, propagationPolicy string, deleteNow bool) error
+	Patch(kind string, namespace string, name string, patchType k8stypes.PatchType, data []byte, subresources ...string) (*unstructured.Unstructured, error)
+	List(kind string, namespace string, options metav1.ListOptions) (*unstructured.UnstructuredList, error)
 }
@@ -147,6 +147,29 @@ func (v *resourceVerber) Delete(kind string, namespace string, name string, prop
 	return v.client.Resource(gvr).Namespace(namespace).Delete(context.TODO(), name, defaultDeleteOptions)
 }
 
+// Patch patches the resource of the given kind in the given namespace with the given name and returns the patched
+// resource. Optional subresource, i.e. scale, can be patched instead of the resource itself.
+func (v *resourceVerber) Patch(kind string, namespace string, name string, patchType k8stypes.PatchType, data []byte,
+	subresources ...string) (*unstructured.Unstructured, error) {
+	gvr, err := v.groupVersionResourceFromKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(2).InfoS("patching resource", "kind", kind, "namespace", namespace, "name", name, "subresources", subresources, "patch", string(data))
+	return v.client.Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, patchType, data, metav1.PatchOptions{}, subresources...)
+}
+
+// List lists resources of the given kind in the given namespace. Empty namespace lists resources from all namespaces.
+func (v *resourceVerber) List(kind string, namespace string, options metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	gvr, err := v.groupVersionResourceFromKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.client.Resource(gvr).Namespace(namespace).List(context.TODO(), options)
+}
+
 // Update patches resource of the given kind in the given namespace with the given name.
 func (v *resourceVerber) Update(object *unstructured.Unstructured) error {
 	name := object.GetName()
@@ -208,6 +231,19 @@ func (v *resourceVerber) Update(object *unstructured.Unstructured) error {
 	})
 }
 
+// Apply applies the resource using server-side apply. Field ownership conflicts are returned as a part of the result.
+func (v *resourceVerber) Apply(object *unstructured.Unstructured, opts ApplyOptions) (*ApplyResult, error) {
+	gvr := v.groupVersionResourceFromUnstructured(object)
+	return Apply(context.TODO(), v.client.Resource(gvr).Namespace(object.GetNamespace()), object, opts)
+}
+
+// Diff returns the difference between the live resource and the given one, as it would be persisted by Update, or
+// by Apply if apply options are given.
+func (v *resourceVerber) Diff(object *unstructured.Unstructured, applyOptions *ApplyOptions) (*ResourceDiff, error) {
+	gvr := v.groupVersionResourceFromUnstructured(object)
+	return Diff(context.TODO(), v.client.Resource(gvr).Namespace(object.GetNamespace()), object, applyOptions)
+}
+
 // Get gets the resource of the given kind in the given namespace with the given name.
 func (v *resourceVerber) Get(kind string, namespace string, name string) (runtime.Object, error) {
 	gvr, err := v.groupVersionResourceFromKind(kind)
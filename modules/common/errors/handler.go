@@ -35,6 +35,14 @@ func HandleError(err error) (int, error) {
 		return http.StatusForbidden, NewForbidden(MsgForbiddenError, err)
 	}
 
+	if IsBadRequest(err) {
+		return http.StatusBadRequest, err
+	}
+
+	if IsResourceExpired(err) {
+		return http.StatusGone, err
+	}
+
 	return http.StatusInternalServerError, err
 }
 
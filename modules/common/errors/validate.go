@@ -46,3 +46,10 @@ func IsForbidden(err error) bool {
 }
 
 func IsNotFound(err error) bool { return k8serrors.IsNotFound(err) }
+
+// IsBadRequest determines if the request is invalid and can not be processed.
+func IsBadRequest(err error) bool { return k8serrors.IsBadRequest(err) }
+
+// IsResourceExpired determines if the continue token used to list the next chunk of resources has expired
+// and the list has to be started from the beginning.
+func IsResourceExpired(err error) bool { return k8serrors.IsResourceExpired(err) }
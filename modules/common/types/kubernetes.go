@@ -100,6 +100,20 @@ type TypeMeta struct {
 type ListMeta struct {
 	// Total number of items on the list. Used for pagination.
 	TotalItems int `json:"totalItems"`
+
+	// Continue is a token used to get the next chunk of the list from the apiserver. It is empty if the list was not
+	// listed in chunks or if there are no more items.
+	Continue string `json:"continue,omitempty"`
+
+	// RemainingItemCount is the number of items that are left on the apiserver after the current chunk of the list.
+	// It is an estimate and the apiserver does not provide it when label or field selectors are used.
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
+}
+
+// SetChunk copies the continue token and the remaining item count from the list chunk returned by the apiserver.
+func (in *ListMeta) SetChunk(listMeta metav1.ListMeta) {
+	in.Continue = listMeta.Continue
+	in.RemainingItemCount = listMeta.RemainingItemCount
 }
 
 func toOwnerReferences(ownerReferences []metav1.OwnerReference) []OwnerReference {
@@ -22,6 +22,7 @@ type ResourceKind string
 // List of all resource kinds supported by the UI.
 const (
 	ResourceKindConfigMap                = "configmap"
+	ResourceKindControllerRevision       = "controllerrevision"
 	ResourceKindDaemonSet                = "daemonset"
 	ResourceKindDeployment               = "deployment"
 	ResourceKindEvent                    = "event"
@@ -37,6 +38,7 @@ const (
 	ResourceKindPersistentVolume         = "persistentvolume"
 	ResourceKindCustomResourceDefinition = "customresourcedefinition"
 	ResourceKindPod                      = "pod"
+	ResourceKindPodDisruptionBudget      = "poddisruptionbudget"
 	ResourceKindReplicaSet               = "replicaset"
 	ResourceKindReplicationController    = "replicationcontroller"
 	ResourceKindResourceQuota            = "resourcequota"
@@ -51,6 +53,11 @@ const (
 	ResourceKindEndpoint                 = "endpoint"
 	ResourceKindNetworkPolicy            = "networkpolicy"
 	ResourceKindIngressClass             = "ingressclass"
+	ResourceKindGatewayClass             = "gatewayclass"
+	ResourceKindGateway                  = "gateway"
+	ResourceKindHTTPRoute                = "httproute"
+	ResourceKindGRPCRoute                = "grpcroute"
+	ResourceKindReferenceGrant           = "referencegrant"
 )
 
 // Scalable method return whether ResourceKind is scalable.
@@ -52,8 +52,16 @@ enum SortableColumn {
   Status = 'status',
   FirstSeen = 'firstSeen',
   LastSeen = 'lastSeen',
+  CPU = 'cpu',
+  Memory = 'mem',
 }
 
+const backendSortableColumns: {[column: string]: string} = {
+  [SortableColumn.Created]: 'creationTimestamp',
+  [SortableColumn.CPU]: 'cpuUsage',
+  [SortableColumn.Memory]: 'memoryUsage',
+};
+
 @Directive()
 export abstract class ResourceListBase<T extends ResourceList, R extends Resource> implements OnInit {
   isLoading = false;
@@ -335,7 +343,7 @@ export abstract class ResourceListBase<T extends ResourceList, R extends Resourc
   }
 
   private mapToBackendValue_(sortByColumnName: string): string {
-    return sortByColumnName === SortableColumn.Created ? 'creationTimestamp' : sortByColumnName;
+    return backendSortableColumns[sortByColumnName] || sortByColumnName;
   }
 
   private onListChange_(data: T): void {
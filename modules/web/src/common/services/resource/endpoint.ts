@@ -51,7 +51,7 @@ export enum Resource {
 }
 
 export enum Utility {
-  shell = 'shell',
+  terminal = 'terminal',
 }
 
 class ResourceEndpoint {
@@ -82,8 +82,8 @@ class ResourceEndpoint {
 class UtilityEndpoint {
   constructor(private readonly utility_: Utility) {}
 
-  shell(namespace: string, resourceName: string): string {
-    return `${baseHref}/${Resource.pod}/${namespace}/${resourceName}/${this.utility_}`;
+  terminal(namespace: string, resourceName: string): string {
+    return `${baseHref}/${this.utility_}/${Resource.pod}/${namespace}/${resourceName}`;
   }
 }
 
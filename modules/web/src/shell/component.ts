@@ -18,24 +18,36 @@ import {
   Component,
   DestroyRef,
   ElementRef,
+  Inject,
   inject,
   OnDestroy,
   ViewChild,
 } from '@angular/core';
 import {MatSnackBar} from '@angular/material/snack-bar';
 import {ActivatedRoute, Router} from '@angular/router';
-import {PodContainerList, ShellFrame, SJSCloseEvent, SJSMessageEvent, TerminalResponse} from '@api/root.api';
+import {PodContainerList, TerminalStatus} from '@api/root.api';
+import {IConfig} from '@api/root.ui';
 import debounce from 'lodash-es/debounce';
-import {ReplaySubject, Subject} from 'rxjs';
+import {CookieService} from 'ngx-cookie-service';
 import {Terminal} from 'xterm';
 import {FitAddon} from 'xterm-addon-fit';
 
 import {EndpointManager, Resource, Utility} from '@common/services/resource/endpoint';
 import {NamespacedResourceService} from '@common/services/resource/resource';
-import {UtilityService} from '@common/services/resource/utility';
 import {takeUntilDestroyed} from '@angular/core/rxjs-interop';
+import {CONFIG_DI_TOKEN} from '../index.config';
+
+// Channels of the v5.channel.k8s.io streaming protocol. Every binary message starts with the channel number.
+enum Channel {
+  Stdin = 0,
+  Stdout = 1,
+  Stderr = 2,
+  Status = 3,
+  Resize = 4,
+}
 
-declare let SockJS: any;
+const streamingProtocol = 'v5.channel.k8s.io';
+const bearerProtocolPrefix = 'base64url.bearer.authorization.k8s.io.';
 
 @Component({
   selector: 'kd-shell',
@@ -50,24 +62,22 @@ export class ShellComponent implements AfterViewInit, OnDestroy {
   containers: string[];
 
   private readonly namespace_: string;
-  private connecting_: boolean;
-  private connectionClosed_: boolean;
   private conn_: WebSocket;
   private connected_ = false;
   private debouncedFit_: Function;
-  private connSubject_ = new ReplaySubject<ShellFrame>(100);
-  private incommingMessage$_ = new Subject<ShellFrame>();
+  private readonly encoder_ = new TextEncoder();
+  private readonly decoder_ = new TextDecoder();
   private readonly endpoint_ = EndpointManager.resource(Resource.pod, true);
-  private readonly keyEvent$_ = new ReplaySubject<KeyboardEvent>(2);
 
   private destroyRef = inject(DestroyRef);
   constructor(
     private readonly containers_: NamespacedResourceService<PodContainerList>,
-    private readonly utility_: UtilityService<TerminalResponse>,
     private readonly activatedRoute_: ActivatedRoute,
     private readonly matSnackBar_: MatSnackBar,
     private readonly cdr_: ChangeDetectorRef,
-    private readonly _router: Router
+    private readonly _router: Router,
+    private readonly cookies_: CookieService,
+    @Inject(CONFIG_DI_TOKEN) private readonly appConfig_: IConfig
   ) {
     this.namespace_ = this.activatedRoute_.snapshot.params.resourceNamespace;
     this.podName = this.activatedRoute_.snapshot.params.resourceName;
@@ -88,32 +98,20 @@ export class ShellComponent implements AfterViewInit, OnDestroy {
     this.activatedRoute_.paramMap.pipe(takeUntilDestroyed(this.destroyRef)).subscribe(paramMap => {
       const container = paramMap.get('containerName');
 
-      if (this.conn_ && this.connected_) {
+      if (this.conn_) {
         this.disconnect();
       }
 
       if (container) {
         this.selectedContainer = container;
-        this.setupConnection();
         this.initTerm();
+        this.setupConnection();
       }
     });
   }
 
   ngOnDestroy(): void {
-    if (this.conn_) {
-      this.conn_.close();
-    }
-
-    if (this.connSubject_) {
-      this.connSubject_.complete();
-    }
-
-    if (this.term) {
-      this.term.dispose();
-    }
-
-    this.incommingMessage$_.complete();
+    this.disconnect();
   }
 
   onPodContainerChange(podContainer: string): void {
@@ -124,32 +122,18 @@ export class ShellComponent implements AfterViewInit, OnDestroy {
 
   disconnect(): void {
     if (this.conn_) {
+      this.connected_ = false;
       this.conn_.close();
-    }
-
-    if (this.connSubject_) {
-      this.connSubject_.complete();
-      this.connSubject_ = new ReplaySubject<ShellFrame>(100);
+      this.conn_ = null;
     }
 
     if (this.term) {
       this.term.dispose();
+      this.term = null;
     }
-
-    this.incommingMessage$_.complete();
-    this.incommingMessage$_ = new Subject<ShellFrame>();
   }
 
   initTerm(): void {
-    if (this.connSubject_) {
-      this.connSubject_.complete();
-      this.connSubject_ = new ReplaySubject<ShellFrame>(100);
-    }
-
-    if (this.term) {
-      this.term.dispose();
-    }
-
     this.term = new Terminal({
       fontSize: 14,
       fontFamily: 'Consolas, "Courier New", monospace',
@@ -166,47 +150,47 @@ export class ShellComponent implements AfterViewInit, OnDestroy {
     this.debouncedFit_();
     window.addEventListener('resize', () => this.debouncedFit_());
 
-    this.connSubject_.pipe(takeUntilDestroyed(this.destroyRef)).subscribe(frame => {
-      this.handleConnectionMessage(frame);
-    });
-
     this.term.onData(this.onTerminalSendString.bind(this));
     this.term.onResize(this.onTerminalResize.bind(this));
-    this.term.onKey(event => {
-      this.keyEvent$_.next(event.domEvent);
-    });
 
     this.cdr_.markForCheck();
   }
 
-  private async setupConnection(): Promise<void> {
-    if (!(this.selectedContainer && this.podName && this.namespace_ && !this.connecting_)) {
+  private setupConnection(): void {
+    if (!(this.selectedContainer && this.podName && this.namespace_)) {
       return;
     }
 
-    this.connecting_ = true;
-    this.connectionClosed_ = false;
-
-    const terminalSessionUrl = `${EndpointManager.utility(Utility.shell).shell(this.namespace_, this.podName)}/${
+    const path = `${EndpointManager.utility(Utility.terminal).terminal(this.namespace_, this.podName)}/${
       this.selectedContainer
     }`;
-    const {id} = await this.utility_.shell(terminalSessionUrl).toPromise();
+    const url = new URL(path, document.baseURI);
+    url.protocol = url.protocol === 'https:' ? 'wss:' : 'ws:';
+    const shell = this.activatedRoute_.snapshot.queryParamMap.get('shell');
+    if (shell) {
+      url.searchParams.set('shell', shell);
+    }
+
+    // Browsers do not allow setting the authorization header of WebSocket requests, so the token is sent in the
+    // subprotocol the same way as to the API server.
+    const protocols = [streamingProtocol];
+    const token = this.cookies_.get(this.appConfig_.authTokenCookieName);
+    if (token) {
+      protocols.push(bearerProtocolPrefix + this.encodeToken_(token));
+    }
 
-    this.conn_ = new SockJS(`api/sockjs?${id}`);
-    this.conn_.onopen = this.onConnectionOpen.bind(this, id);
-    this.conn_.onmessage = this.onConnectionMessage.bind(this);
-    this.conn_.onclose = this.onConnectionClose.bind(this);
+    const conn = new WebSocket(url.toString(), protocols);
+    conn.binaryType = 'arraybuffer';
+    conn.onopen = this.onConnectionOpen.bind(this);
+    conn.onmessage = this.onConnectionMessage.bind(this);
+    conn.onclose = this.onConnectionClose.bind(this);
+    this.conn_ = conn;
 
     this.cdr_.markForCheck();
   }
 
-  private onConnectionOpen(sessionId: string): void {
-    const startData = {Op: 'bind', SessionID: sessionId};
-    this.conn_.send(JSON.stringify(startData));
-    this.connSubject_.next(startData);
+  private onConnectionOpen(): void {
     this.connected_ = true;
-    this.connecting_ = false;
-    this.connectionClosed_ = false;
 
     // Make sure the terminal is with correct display size.
     this.onTerminalResize();
@@ -216,59 +200,62 @@ export class ShellComponent implements AfterViewInit, OnDestroy {
     this.cdr_.markForCheck();
   }
 
-  private handleConnectionMessage(frame: ShellFrame): void {
-    if (frame.Op === 'stdout') {
-      this.term.write(frame.Data);
+  private onConnectionMessage(evt: MessageEvent<ArrayBuffer>): void {
+    const data = new Uint8Array(evt.data);
+    if (data.length === 0) {
+      return;
     }
 
-    if (frame.Op === 'toast') {
-      this.matSnackBar_.open(frame.Data, null, {duration: 3000});
+    switch (data[0]) {
+      case Channel.Stdout:
+      case Channel.Stderr:
+        this.term.write(data.subarray(1));
+        break;
+      case Channel.Status:
+        this.onProcessStatus(JSON.parse(this.decoder_.decode(data.subarray(1))));
+        break;
+      default:
     }
 
-    this.incommingMessage$_.next(frame);
     this.cdr_.markForCheck();
   }
 
-  private onConnectionMessage(evt: SJSMessageEvent): void {
-    const msg = JSON.parse(evt.data);
-    this.connSubject_.next(msg);
+  private onProcessStatus(status: TerminalStatus): void {
+    this.matSnackBar_.open(status.status === 'Success' ? 'Process exited' : status.message, null, {duration: 3000});
   }
 
-  private onConnectionClose(_evt?: SJSCloseEvent): void {
+  private onConnectionClose(evt: CloseEvent): void {
     if (!this.connected_) {
+      if (!evt.wasClean) {
+        this.matSnackBar_.open('Could not connect to the container', null, {duration: 3000});
+      }
       return;
     }
-    this.conn_.close();
-    this.connected_ = false;
-    this.connecting_ = false;
-    this.connectionClosed_ = true;
-    this.matSnackBar_.open(_evt.reason, null, {duration: 3000});
 
+    this.connected_ = false;
     this.cdr_.markForCheck();
   }
 
   private onTerminalSendString(str: string): void {
-    if (this.connected_) {
-      this.conn_.send(
-        JSON.stringify({
-          Op: 'stdin',
-          Data: str,
-          Cols: this.term.cols,
-          Rows: this.term.rows,
-        })
-      );
-    }
+    this.send_(Channel.Stdin, this.encoder_.encode(str));
   }
 
   private onTerminalResize(): void {
-    if (this.connected_) {
-      this.conn_.send(
-        JSON.stringify({
-          Op: 'resize',
-          Cols: this.term.cols,
-          Rows: this.term.rows,
-        })
-      );
+    this.send_(Channel.Resize, this.encoder_.encode(JSON.stringify({Width: this.term.cols, Height: this.term.rows})));
+  }
+
+  private send_(channel: Channel, data: Uint8Array): void {
+    if (!this.connected_) {
+      return;
     }
+
+    const message = new Uint8Array(data.length + 1);
+    message[0] = channel;
+    message.set(data, 1);
+    this.conn_.send(message);
+  }
+
+  private encodeToken_(token: string): string {
+    return btoa(token).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
   }
 }
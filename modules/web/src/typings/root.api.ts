@@ -23,6 +23,8 @@ export interface TypeMeta {
 
 export interface ListMeta {
   totalItems: number;
+  continue?: string;
+  remainingItemCount?: number;
 }
 
 export interface ObjectMeta {
@@ -1268,18 +1270,6 @@ export interface SystemBanner {
   severity: string;
 }
 
-export interface TerminalResponse {
-  id: string;
-}
-
-export interface ShellFrame {
-  Op: string;
-  Data?: string;
-  SessionID?: string;
-  Rows?: number;
-  Cols?: number;
-}
-
 export interface TerminalPageParams {
   namespace: string;
   resourceKind: string;
@@ -1288,20 +1278,9 @@ export interface TerminalPageParams {
   container?: string;
 }
 
-export interface SockJSSimpleEvent {
-  type: string;
-
-  toString(): string;
-}
-
-export interface SJSCloseEvent extends SockJSSimpleEvent {
-  code: number;
-  reason: string;
-  wasClean: boolean;
-}
-
-export interface SJSMessageEvent extends SockJSSimpleEvent {
-  data: string;
+export interface TerminalStatus {
+  status: string;
+  message?: string;
 }
 
 export interface User {